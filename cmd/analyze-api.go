@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// AnalyzeOptions configures Analyze, the fields mirror the analyze flags.
+// Fields left empty or nil keep the default of their flag, e.g. the default
+// rulesets are enabled and 100 context lines are included unless set.
+type AnalyzeOptions struct {
+	// Input is the application source directory or binary to analyze
+	Input string
+	// Output is the directory the analysis output is written to
+	Output                string
+	Overwrite             *bool
+	Sources               []string
+	Targets               []string
+	LabelSelector         string
+	Rules                 []string
+	EnableDefaultRulesets *bool
+	// Mode is 'full', 'source-only' or 'dependencies-only'
+	Mode             string
+	Providers        []string
+	ContextLines     *int
+	IncidentSelector string
+	IncidentLimit    *int
+	// Workers is the number of rules evaluated in parallel
+	Workers               *int
+	AnalyzeKnownLibraries *bool
+	NoDependencyRules     *bool
+	MavenSettingsFile     string
+	Exclude               []string
+	SkipStaticReport      *bool
+	JSONOutput            *bool
+	// NoCleanup keeps temporary files and provider settings after analysis
+	NoCleanup bool
+	// Log defaults to discarding all messages
+	Log logr.Logger
+}
+
+// AnalyzeResult holds the results of Analyze, also written to the output dir
+type AnalyzeResult struct {
+	RuleSets     []outputv1.RuleSet
+	Dependencies []outputv1.DepsFlatItem
+}
+
+// Analyze runs an analysis the same as `kantra analyze --no-progress` with
+// the flags of opts and returns the results. Java applications are analyzed
+// in containerless mode, other languages need a container runtime. Errors
+// are returned to the caller, the process is never exited. Use errors.As
+// with *ValidationError, *ProviderInitError, *RuleParseError or
+// *OutputWriteError to tell failures apart.
+func Analyze(ctx context.Context, opts AnalyzeOptions) (AnalyzeResult, error) {
+	cmd, a := newAnalyzeCommand(opts.Log)
+	a.cleanup = !opts.NoCleanup
+	cmd.SetArgs(opts.args())
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		return AnalyzeResult{}, err
+	}
+	return AnalyzeResult{
+		RuleSets:     a.results,
+		Dependencies: a.depsFlat,
+	}, nil
+}
+
+// args returns the analyze flags for the options, only the options that are
+// set are passed so the others keep their flag default
+func (o AnalyzeOptions) args() []string {
+	args := []string{"--no-progress"}
+	stringFlag := func(name string, value string) {
+		if value != "" {
+			args = append(args, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
+	stringArrayFlag := func(name string, values []string) {
+		for _, value := range values {
+			args = append(args, fmt.Sprintf("--%s=%s", name, value))
+		}
+	}
+	boolFlag := func(name string, value *bool) {
+		if value != nil {
+			args = append(args, fmt.Sprintf("--%s=%t", name, *value))
+		}
+	}
+	intFlag := func(name string, value *int) {
+		if value != nil {
+			args = append(args, fmt.Sprintf("--%s=%d", name, *value))
+		}
+	}
+	stringFlag("input", o.Input)
+	stringFlag("output", o.Output)
+	boolFlag("overwrite", o.Overwrite)
+	stringArrayFlag("source", o.Sources)
+	stringArrayFlag("target", o.Targets)
+	stringFlag("label-selector", o.LabelSelector)
+	stringArrayFlag("rules", o.Rules)
+	boolFlag("enable-default-rulesets", o.EnableDefaultRulesets)
+	stringFlag("mode", o.Mode)
+	stringArrayFlag("provider", o.Providers)
+	intFlag("context-lines", o.ContextLines)
+	stringFlag("incident-selector", o.IncidentSelector)
+	intFlag("incident-limit", o.IncidentLimit)
+	intFlag("workers", o.Workers)
+	boolFlag("analyze-known-libraries", o.AnalyzeKnownLibraries)
+	boolFlag("no-dependency-rules", o.NoDependencyRules)
+	stringFlag("maven-settings", o.MavenSettingsFile)
+	stringArrayFlag("exclude", o.Exclude)
+	boolFlag("skip-static-report", o.SkipStaticReport)
+	boolFlag("json-output", o.JSONOutput)
+	return args
+}
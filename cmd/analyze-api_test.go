@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeOptionsDefaults(t *testing.T) {
+	parse := func(opts AnalyzeOptions) *analyzeCommand {
+		cmd, a := newAnalyzeCommand(logr.Discard())
+		require.NoError(t, cmd.ParseFlags(opts.args()))
+		return a
+	}
+
+	a := parse(AnalyzeOptions{
+		Input:   "/app",
+		Output:  "/out",
+		Targets: []string{"quarkus"},
+	})
+	assert.Equal(t, "/app", a.input)
+	assert.Equal(t, "/out", a.output)
+	assert.Equal(t, []string{"quarkus"}, a.targets)
+	// unset options keep the flag defaults, the same as the CLI
+	assert.Equal(t, string(provider.FullAnalysisMode), a.mode)
+	assert.True(t, a.enableDefaultRulesets)
+	assert.Equal(t, 100, a.contextLines)
+	assert.Equal(t, defaultWorkers, a.workers)
+	assert.Equal(t, depOutputFlat, a.depOutput)
+	assert.True(t, a.runLocal)
+	assert.True(t, a.noProgress)
+	assert.True(t, a.cleanup)
+
+	disabled := false
+	a = parse(AnalyzeOptions{
+		Input:                 "/app",
+		Output:                "/out",
+		EnableDefaultRulesets: &disabled,
+		ContextLines:          intPtr(0),
+		MavenSettingsFile:     "/settings.xml",
+	})
+	assert.False(t, a.enableDefaultRulesets)
+	assert.Equal(t, 0, a.contextLines)
+	assert.Equal(t, []string{"/settings.xml"}, a.mavenSettingsFiles)
+}
+
+func TestAnalyzeReturnsError(t *testing.T) {
+	_, err := Analyze(context.Background(), AnalyzeOptions{
+		Input:  filepath.Join(t.TempDir(), "missing"),
+		Output: t.TempDir(),
+		Log:    logr.Discard(),
+	})
+	assert.Error(t, err)
+}
+
+func TestAnalyzeCleansUpOnError(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+	// credentials make validation write a temp maven settings file
+	t.Setenv(mavenUsernameEnv, "user")
+	t.Setenv(mavenPasswordEnv, "secret")
+	t.Setenv(mavenServerIDEnv, "nexus")
+
+	input := filepath.Join(tmpDir, "input")
+	require.NoError(t, os.Mkdir(input, 0755))
+	_, err := Analyze(context.Background(), AnalyzeOptions{
+		Input:  input,
+		Output: filepath.Join(tmpDir, "output"),
+	})
+	// an empty input has no language to analyze
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no supported language")
+
+	leftover, err := filepath.Glob(filepath.Join(tmpDir, "analyze-maven-settings-*"))
+	require.NoError(t, err)
+	assert.Empty(t, leftover)
+}
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -79,7 +80,7 @@ func renderProgressBar(percent int, current, total int, message string) {
 		percent, bar, current, total, message)
 }
 
-func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
+func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) (err error) {
 	startTotal := time.Now()
 
 	// Create progress mode to encapsulate progress reporting behavior
@@ -91,6 +92,17 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 
 	operationalLog.Info("[TIMING] Containerless analysis starting")
 
+	stopCPUProfile, err := a.startCPUProfile()
+	if err != nil {
+		return err
+	}
+	defer stopCPUProfile()
+	defer func() {
+		if err := a.writeHeapProfile(); err != nil {
+			a.log.Error(err, "failed to write heap profile")
+		}
+	}()
+
 	// Initialize Jaeger tracing if endpoint is provided
 	if a.jaegerEndpoint != "" {
 		operationalLog.Info("initializing Jaeger tracing", "endpoint", a.jaegerEndpoint)
@@ -106,23 +118,49 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 		defer tracing.Shutdown(ctx, a.log, tp)
 		operationalLog.Info("Jaeger tracing initialized successfully")
 	}
+	if a.otelEndpoint != "" {
+		operationalLog.Info("initializing OpenTelemetry tracing", "endpoint", a.otelEndpoint)
+		tp, err := initOtelTracing(ctx, a.log, a.otelEndpoint, a.otelSampleRate)
+		if err != nil {
+			return fmt.Errorf("failed to initialize tracing: %w", err)
+		}
+		defer shutdownOtelTracing(a.log, tp)
+	}
+
+	// listen before the providers start so consumers can connect meanwhile
+	a.stream, err = a.openIncidentStream()
+	if err != nil {
+		return err
+	}
+	defer a.stream.Close()
 
 	// Hide cursor at the very start if progress is enabled
 	progressMode.HideCursor()
 	// Ensure cursor is shown at the end
 	defer progressMode.ShowCursor()
 
-	err := a.ValidateContainerless(ctx)
+	err = a.ValidateContainerless(ctx)
 	if err != nil {
 		a.log.Error(err, "failed to validate flags")
-		return err
+		return &ValidationError{Err: err}
 	}
 
 	if a.reqMap == nil {
 		a.reqMap = make(map[string]string)
 	}
 
-	defer os.Remove(filepath.Join(a.output, "settings.json"))
+	// clean provider settings and jdtls dirs after analysis
+	settingsPath := filepath.Join(a.output, "settings.json")
+	defer func() {
+		if !a.cleanup {
+			a.logSkippedContainerlessCleanup(settingsPath)
+			return
+		}
+		os.Remove(settingsPath)
+		if err := a.cleanlsDirs(); err != nil {
+			a.log.Error(err, "failed to clean language server directories")
+		}
+	}()
 
 	analysisLogFilePath := filepath.Join(a.output, "analysis.log")
 	analysisLog, err := os.Create(analysisLogFilePath)
@@ -131,40 +169,31 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 	}
 	defer analysisLog.Close()
 
-	// clean jdtls dirs after analysis
-	defer func() {
-		if err := a.cleanlsDirs(); err != nil {
-			a.log.Error(err, "failed to clean language server directories")
-		}
-	}()
-
 	// log output from analyzer to file
 	logrusAnalyzerLog := logrus.New()
-	logrusAnalyzerLog.SetOutput(analysisLog)
+	logrusAnalyzerLog.SetOutput(a.analyzerLogWriter(analysisLog))
 	logrusAnalyzerLog.SetFormatter(&logrus.TextFormatter{})
 	logrusAnalyzerLog.SetLevel(logrus.Level(logLevel))
 
 	// add log hook, print the rule processing to the console
 	// but only if progress is disabled (to avoid interfering with progress bar)
-	if progressMode.ShouldAddConsoleHook() {
+	if progressMode.ShouldAddConsoleHook() && !a.verboseProvider {
 		consoleHook := &ConsoleHook{Level: logrus.InfoLevel, Log: a.log}
 		logrusAnalyzerLog.AddHook(consoleHook)
 	}
 
 	analyzeLog := logrusr.New(logrusAnalyzerLog)
+	defer a.closeProviderLogs()
 
 	// log kantra errs to stderr
 	logrusErrLog := logrus.New()
 	logrusErrLog.SetOutput(os.Stderr)
+	if a.quiet {
+		logrusErrLog.SetLevel(logrus.WarnLevel)
+	}
 	errLog := logrusr.New(logrusErrLog)
 
-	// Detect if this is binary analysis based on file extension
-	isBinaryAnalysis := false
-	if a.isFileInput {
-		ext := filepath.Ext(a.input)
-		isBinaryAnalysis = (ext == util.JavaArchive || ext == util.WebArchive ||
-			ext == util.EnterpriseArchive || ext == util.ClassFile)
-	}
+	isBinaryAnalysis := a.isBinaryInput()
 
 	if isBinaryAnalysis {
 		progressMode.Printf("Running binary analysis...\n")
@@ -183,15 +212,22 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 		}
 		selectors = append(selectors, selector)
 	}
+	ruleIDSelectors, err := a.ruleIDSelectors()
+	if err != nil {
+		return fmt.Errorf("failed to create rule id selector: %w", err)
+	}
+	selectors = append(selectors, ruleIDSelectors...)
+	rulesVersionSelectors, err := a.rulesVersionSelectors()
+	if err != nil {
+		return fmt.Errorf("failed to create rules version selector: %w", err)
+	}
+	selectors = append(selectors, rulesVersionSelectors...)
+	selectors = a.includeDisabledRules(selectors)
 
-	var dependencyLabelSelector *labels.LabelSelector[*konveyor.Dep]
-	depLabel := fmt.Sprintf("!%v=open-source", provider.DepSourceLabel)
-	if !a.analyzeKnownLibraries {
-		dependencyLabelSelector, err = labels.NewLabelSelector[*konveyor.Dep](depLabel, nil)
-		if err != nil {
-			errLog.Error(err, "failed to create label selector from expression", "selector", depLabel)
-			return fmt.Errorf("failed to create dependency label selector: %w", err)
-		}
+	dependencyLabelSelector, err := a.dependencyLabelSelector()
+	if err != nil {
+		errLog.Error(err, "failed to create dependency label selector")
+		return err
 	}
 
 	err = a.setBinMapContainerless()
@@ -200,6 +236,10 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 		return fmt.Errorf("unable to find kantra dependencies: %w", err)
 	}
 
+	if a.dryRun {
+		return a.printDryRunPlan(os.Stdout, analyzeLog)
+	}
+
 	// Create progress reporter early (before provider preparation)
 	reporter, progressDone, progressCancel := setupProgressReporter(ctx, a.noProgress)
 	if progressCancel != nil {
@@ -209,47 +249,82 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 	providers := map[string]provider.InternalProviderClient{}
 	providerLocations := []string{}
 
-	// Load override provider settings if specified
-	overrideConfigs, err := a.loadOverrideProviderSettings()
-	if err != nil {
-		errLog.Error(err, "failed to load override provider settings")
-		return fmt.Errorf("failed to load override provider settings: %w", err)
-	}
-	if overrideConfigs != nil {
-		operationalLog.Info("loaded override provider settings", "file", a.overrideProviderSettings, "providers", len(overrideConfigs))
-	}
+	if a.providerSettings != "" {
+		operationalLog.Info("creating providers from provider settings", "file", a.providerSettings)
+		providers, providerLocations, err = a.startProvidersFromSettings(ctx, analyzeLog)
+		if err != nil {
+			errLog.Error(err, "unable to start providers from provider settings")
+			return &ProviderInitError{Err: fmt.Errorf("unable to start providers from provider settings: %w", err)}
+		}
+	} else {
+		// Load override provider settings if specified
+		overrideConfigs, err := a.loadOverrideProviderSettings()
+		if err != nil {
+			errLog.Error(err, "failed to load override provider settings")
+			return fmt.Errorf("failed to load override provider settings: %w", err)
+		}
+		if overrideConfigs != nil {
+			operationalLog.Info("loaded override provider settings", "file", a.overrideProviderSettings, "providers", len(overrideConfigs))
+		}
 
-	// Show decompiling message for binary analysis
-	if isBinaryAnalysis {
-		progressMode.Printf("  Decompiling binary...\n")
-	}
+		// Show decompiling message for binary analysis
+		if isBinaryAnalysis {
+			progressMode.Printf("  Decompiling binary...\n")
+		}
 
-	startJavaProvider := time.Now()
-	operationalLog.Info("[TIMING] Starting Java provider setup")
-	javaProvider, javaLocations, additionalBuiltinConfigs, err := a.setupJavaProvider(ctx, analyzeLog, operationalLog, reporter)
-	if err != nil {
-		errLog.Error(err, "unable to start Java provider")
-		return fmt.Errorf("unable to start Java provider: %w", err)
-	}
-	providers[util.JavaProvider] = javaProvider
-	providerLocations = append(providerLocations, javaLocations...)
-	operationalLog.Info("[TIMING] Java provider setup complete", "duration_ms", time.Since(startJavaProvider).Milliseconds())
+		startJavaProvider := time.Now()
+		operationalLog.Info("[TIMING] Starting Java provider setup")
+		javaProvider, javaLocations, additionalBuiltinConfigs, err := a.setupJavaProvider(ctx, a.providerLogger(util.JavaProvider, analyzeLog), operationalLog, reporter)
+		if err != nil {
+			errLog.Error(err, "unable to start Java provider")
+			if !a.continueWithoutProviders() {
+				return &ProviderInitError{Provider: util.JavaProvider, Err: fmt.Errorf("unable to start Java provider: %w", err)}
+			}
+			a.markProviderUnavailable(util.JavaProvider, err)
+		} else {
+			providers[util.JavaProvider] = javaProvider
+			providerLocations = append(providerLocations, javaLocations...)
+		}
+		operationalLog.Info("[TIMING] Java provider setup complete", "duration_ms", time.Since(startJavaProvider).Milliseconds())
 
-	// Show completion checkmark for binary decompilation
-	if isBinaryAnalysis {
-		progressMode.Printf("  ✓ Decompiling complete\n")
-	}
+		// Show completion checkmark for binary decompilation
+		if isBinaryAnalysis {
+			progressMode.Printf("  ✓ Decompiling complete\n")
+		}
 
-	startBuiltinProvider := time.Now()
-	operationalLog.Info("[TIMING] Starting builtin provider setup")
-	builtinProvider, builtinLocations, err := a.setupBuiltinProvider(ctx, additionalBuiltinConfigs, analyzeLog, operationalLog, overrideConfigs, reporter)
-	if err != nil {
-		errLog.Error(err, "unable to start builtin provider")
-		return fmt.Errorf("unable to start builtin provider: %w", err)
+		for _, name := range a.genericProvidersContainerless() {
+			startGenericProvider := time.Now()
+			operationalLog.Info("[TIMING] Starting generic provider setup", "provider", name)
+			genericProvider, genericLocations, genericBuiltinConfigs, err := a.setupGenericProvider(ctx, name, a.providerLogger(name, analyzeLog), operationalLog, overrideConfigs, reporter)
+			if err != nil {
+				errLog.Error(err, "unable to start provider", "provider", name)
+				if !a.continueWithoutProviders() {
+					return &ProviderInitError{Provider: name, Err: fmt.Errorf("unable to start %s provider: %w", name, err)}
+				}
+				a.markProviderUnavailable(name, err)
+			} else {
+				providers[name] = genericProvider
+				providerLocations = append(providerLocations, genericLocations...)
+				additionalBuiltinConfigs = append(additionalBuiltinConfigs, genericBuiltinConfigs...)
+			}
+			operationalLog.Info("[TIMING] Generic provider setup complete", "provider", name, "duration_ms", time.Since(startGenericProvider).Milliseconds())
+		}
+
+		startBuiltinProvider := time.Now()
+		operationalLog.Info("[TIMING] Starting builtin provider setup")
+		builtinProvider, builtinLocations, err := a.setupBuiltinProvider(ctx, additionalBuiltinConfigs, a.providerLogger("builtin", analyzeLog), operationalLog, overrideConfigs, reporter)
+		if err != nil {
+			errLog.Error(err, "unable to start builtin provider")
+			if !a.checkProviders {
+				return &ProviderInitError{Provider: "builtin", Err: fmt.Errorf("unable to start builtin provider: %w", err)}
+			}
+			a.markProviderUnavailable("builtin", err)
+		} else {
+			providers["builtin"] = builtinProvider
+			providerLocations = append(providerLocations, builtinLocations...)
+		}
+		operationalLog.Info("[TIMING] Builtin provider setup complete", "duration_ms", time.Since(startBuiltinProvider).Milliseconds())
 	}
-	providers["builtin"] = builtinProvider
-	providerLocations = append(providerLocations, builtinLocations...)
-	operationalLog.Info("[TIMING] Builtin provider setup complete", "duration_ms", time.Since(startBuiltinProvider).Milliseconds())
 
 	// Build provider names dynamically from the providers map
 	providerNames := make([]string, 0, len(providers))
@@ -259,10 +334,25 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 	sort.Strings(providerNames) // Sort for consistent output
 	progressMode.Printf("  ✓ Initialized providers (%s)\n", strings.Join(providerNames, ", "))
 
+	if a.checkProviders {
+		if progressMode.IsEnabled() {
+			progressCancel()
+			<-progressDone
+		}
+		for _, provider := range providers {
+			provider.Stop()
+		}
+		return a.writeProviderCheck(os.Stdout, providerNames)
+	}
+
+	if a.depsOnly {
+		return a.runDependencyOnlyContainerless(ctx, providers, operationalLog, progressMode, progressDone, progressCancel)
+	}
+
 	engineCtx, engineSpan := tracing.StartNewSpan(ctx, "rule-engine")
 	//start up the rule eng
 	eng := engine.CreateRuleEngine(engineCtx,
-		10,
+		a.workers,
 		analyzeLog,
 		engine.WithContextLines(a.contextLines),
 		engine.WithIncidentSelector(a.incidentSelector),
@@ -276,35 +366,30 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 		DepLabelSelector:     dependencyLabelSelector,
 	}
 
-	ruleSets := []engine.RuleSet{}
-	needProviders := map[string]provider.InternalProviderClient{}
+	if a.watch {
+		engineSpan.End()
+		if progressMode.IsEnabled() {
+			progressCancel()
+			<-progressDone
+		}
+		progressMode.ShowCursor()
+		return a.runWatchContainerless(ctx, eng, parser, providers, selectors, os.Stdout)
+	}
 
 	if a.enableDefaultRulesets {
-		a.rules = append(a.rules, filepath.Join(a.kantraDir, RulesetsLocation))
+		a.rules = append(a.rules, a.defaultRulesetPaths(filepath.Join(a.kantraDir, RulesetsLocation))...)
 	}
-	providerConditions := map[string][]provider.ConditionsByCap{}
 
 	progressMode.Printf("  ✓ Started rules engine\n")
 
 	startRuleLoading := time.Now()
 	operationalLog.Info("[TIMING] Starting rule loading")
-	for _, f := range a.rules {
-		operationalLog.Info("parsing rules for analysis", "rules", f)
-
-		internRuleSet, internNeedProviders, provConditions, err := parser.LoadRules(f)
-		if err != nil {
-			a.log.Error(err, "unable to parse all the rules for ruleset", "file", f)
-		}
-		ruleSets = append(ruleSets, internRuleSet...)
-		for k, v := range internNeedProviders {
-			needProviders[k] = v
-		}
-		for k, v := range provConditions {
-			if _, ok := providerConditions[k]; !ok {
-				providerConditions[k] = []provider.ConditionsByCap{}
-			}
-			providerConditions[k] = append(providerConditions[k], v...)
-		}
+	ruleSets, needProviders, providerConditions, err := a.loadRulesContainerless(parser, operationalLog)
+	if err != nil {
+		return err
+	}
+	if err := a.checkStrictTargets(); err != nil {
+		return err
 	}
 
 	for name, conditions := range providerConditions {
@@ -319,13 +404,13 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 	// start dependency analysis for full analysis mode only
 	wg := &sync.WaitGroup{}
 	var depSpan trace.Span
-	if a.mode == string(provider.FullAnalysisMode) {
+	if a.modeForProvider(util.JavaProvider) == provider.FullAnalysisMode {
 		var depCtx context.Context
 		depCtx, depSpan = tracing.StartNewSpan(ctx, "dep")
 		wg.Add(1)
 
 		operationalLog.Info("running dependency analysis")
-		go a.DependencyOutputContainerless(depCtx, providers, "dependencies.yaml", wg)
+		go a.DependencyOutputContainerless(depCtx, providers, a.dependencyOutputFile(), wg)
 	}
 
 	// This will already wait
@@ -334,9 +419,16 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 	operationalLog.Info("evaluating rules for violations. see analysis.log for more info")
 
 	// Run analysis with progress reporter (already created earlier)
-	rulesets := eng.RunRulesWithOptions(ctx, ruleSets, []engine.RunOption{
+	stopRuleProgressLog := startRuleProgressLog(ctx, operationalLog, reporter, len(ruleSets), ruleProgressLogInterval)
+	rulesets := []outputv1.RuleSet{}
+	defer a.flushOnPanic(&rulesets, reporter, &err)
+	a.applyRuleTimeout(ruleSets)
+	a.collectPartialResults(ruleSets)
+	a.applyIncidentStream(ruleSets)
+	rulesets = eng.RunRulesWithOptions(ctx, ruleSets, []engine.RunOption{
 		engine.WithProgressReporter(reporter),
 	}, selectors...)
+	stopRuleProgressLog()
 
 	// Cancel progress context and wait for goroutine to finish
 	if progressMode.IsEnabled() {
@@ -354,29 +446,55 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 	for _, provider := range needProviders {
 		provider.Stop()
 	}
+	// partial results are not written when the analysis was interrupted
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("analysis interrupted: %w", err)
+	}
 	operationalLog.Info("[TIMING] Rule execution complete", "duration_ms", time.Since(startRuleExecution).Milliseconds())
 
+	if err := a.writeRuleStats(ruleSets, rulesets); err != nil {
+		a.log.Error(err, "failed to write rule stats")
+		return &OutputWriteError{Path: filepath.Join(a.output, ruleStatsFile), Err: err}
+	}
+
+	rulesets = a.remapMappedIncidents(rulesets)
+	rulesets = a.applySeverityOverlay(rulesets)
+	rulesets = a.filterExcludedIncidents(rulesets)
+	rulesets = a.filterIncidentsByPath(rulesets)
+	rulesets = a.filterIncidentsSince(rulesets)
+	rulesets = a.dedupeByRulesetPriority(rulesets)
+	rulesets = a.addStableIncidentIDs(rulesets)
+	rulesets, err = a.applyBaseline(rulesets)
+	if err != nil {
+		return err
+	}
+	if err := a.writeEffortReport(rulesets); err != nil {
+		a.log.Error(err, "failed to write effort report")
+		return &OutputWriteError{Path: filepath.Join(a.output, effortReportFile), Err: err}
+	}
+	rulesets = limitIncidents(rulesets, a.incidentLimit)
+	rulesets = a.redactIncidents(rulesets)
+	rulesets = a.addIncidentLabels(rulesets)
+	rulesets = a.relativizeIncidentURIs(rulesets)
+
 	sort.SliceStable(rulesets, func(i, j int) bool {
 		return rulesets[i].Name < rulesets[j].Name
 	})
+	a.results = rulesets
+
+	if a.outputToStdout {
+		if err := a.writeStdoutOutput(os.Stdout, rulesets); err != nil {
+			return err
+		}
+		return checkFailOn(rulesets, a.failOn)
+	}
 
 	// Write results out to CLI
 	startWriting := time.Now()
 	operationalLog.Info("[TIMING] Starting output writing")
 	operationalLog.Info("writing analysis results to output", "output", a.output)
-	b, err := yaml.Marshal(rulesets)
-	if err != nil {
-		return err
-	}
-
-	err = os.WriteFile(filepath.Join(a.output, "output.yaml"), b, 0644)
+	err = a.writeAnalysisOutput(rulesets)
 	if err != nil {
-		return fmt.Errorf("failed to write output.yaml: %w", err)
-	}
-
-	err = a.CreateJSONOutput()
-	if err != nil {
-		a.log.Error(err, "failed to create json output file")
 		return err
 	}
 	operationalLog.Info("[TIMING] Output writing complete", "duration_ms", time.Since(startWriting).Milliseconds())
@@ -389,22 +507,187 @@ func (a *analyzeCommand) RunAnalysisContainerless(ctx context.Context) error {
 	err = a.GenerateStaticReportContainerless(ctx, operationalLog)
 	if err != nil {
 		a.log.Error(err, "failed to generate static report")
-		return err
+		return &OutputWriteError{Path: filepath.Join(a.output, "static-report"), Err: err}
 	}
 	operationalLog.Info("[TIMING] Static report generation complete", "duration_ms", time.Since(startStaticReport).Milliseconds())
 
+	err = a.writeRunMetadata(startTotal, providerNames)
+	if err != nil {
+		a.log.Error(err, "failed to write run metadata")
+		return &OutputWriteError{Path: filepath.Join(a.output, runMetadataFile), Err: err}
+	}
+
+	if a.outputArchive != "" {
+		operationalLog.Info("writing output archive", "path", a.outputArchive)
+		err = writeOutputArchive(a.output, a.outputArchive)
+		if err != nil {
+			a.log.Error(err, "failed to write output archive")
+			return &OutputWriteError{Path: a.outputArchive, Err: err}
+		}
+	}
+	err = a.chownOutput()
+	if err != nil {
+		a.log.Error(err, "failed to change the owner of the output")
+		return err
+	}
+
 	// Print results summary (only in progress mode, not in --no-progress mode)
 	progressMode.Println("\nResults:")
-	reportPath := filepath.Join(a.output, "static-report", "index.html")
-	progressMode.Printf("  Report: file://%s\n", reportPath)
+	// the static report URL is not printed when the report was skipped, e.g. with --json-only
+	if !a.skipStaticReport {
+		reportPath := filepath.Join(a.output, "static-report", "index.html")
+		progressMode.Printf("  Report: file://%s\n", reportPath)
+	}
 	analysisLogPath := filepath.Join(a.output, "analysis.log")
 	progressMode.Printf("  Analysis logs: %s\n", analysisLogPath)
+	if len(a.unavailableProviders) > 0 {
+		progressMode.Printf("  Unavailable providers: %s\n", strings.Join(a.unavailableProviders, ", "))
+	}
+	if a.outputArchive != "" {
+		progressMode.Printf("  Archive: %s\n", a.outputArchive)
+	}
+	if progressMode.IsEnabled() && !a.quiet {
+		fmt.Fprintln(os.Stderr)
+		if err := writeResultsSummary(os.Stderr, rulesets); err != nil {
+			a.log.Error(err, "failed to print results summary")
+		}
+	}
 
 	operationalLog.Info("[TIMING] Containerless analysis complete", "total_duration_ms", time.Since(startTotal).Milliseconds())
+	// results are written before failing so they can be inspected
+	return checkFailOn(rulesets, a.failOn)
+}
+
+// writeAnalysisOutput writes the analysis results to the output dir in the
+// requested formats
+func (a *analyzeCommand) writeAnalysisOutput(rulesets []outputv1.RuleSet) error {
+	// --json-only skips output.yaml, output.json is written as --json-output is set
+	if !a.jsonOnly {
+		err := a.writeYAMLOutput(rulesets)
+		if err != nil {
+			return &OutputWriteError{Path: a.outputFilePath("output.yaml"), Err: fmt.Errorf("failed to write output.yaml: %w", err)}
+		}
+	}
+	err := a.CreateJSONOutput(rulesets)
+	if err != nil {
+		a.log.Error(err, "failed to create json output file")
+		return &OutputWriteError{Path: a.outputFilePath("output.json"), Err: err}
+	}
+
+	err = a.validateOutputFile()
+	if err != nil {
+		a.log.Error(err, "analysis output failed schema validation")
+		return err
+	}
+	err = a.writeJUnitOutput(rulesets)
+	if err != nil {
+		a.log.Error(err, "failed to create junit output file")
+		return &OutputWriteError{Path: filepath.Join(a.output, "junit.xml"), Err: err}
+	}
+	err = a.writeCSVOutput(rulesets)
+	if err != nil {
+		a.log.Error(err, "failed to create csv output file")
+		return &OutputWriteError{Path: filepath.Join(a.output, "incidents.csv"), Err: err}
+	}
+	err = a.writeGitHubAnnotations(os.Stdout, rulesets)
+	if err != nil {
+		a.log.Error(err, "failed to write github annotations")
+		return err
+	}
+	return nil
+}
+
+// loadRulesContainerless parses the rules, returning the providers they need
+// with their conditions. Rules that fail to parse are logged and left out,
+// unless --strict-rules is set.
+func (a *analyzeCommand) loadRulesContainerless(ruleParser parser.RuleParser, operationalLog logr.Logger) ([]engine.RuleSet,
+	map[string]provider.InternalProviderClient, map[string][]provider.ConditionsByCap, error) {
+	startParsing := time.Now()
+	ruleSets := []engine.RuleSet{}
+	needProviders := map[string]provider.InternalProviderClient{}
+	providerConditions := map[string][]provider.ConditionsByCap{}
+	for _, f := range a.rules {
+		operationalLog.Info("parsing rules for analysis", "rules", f)
+
+		rulePath, err := a.resolveRuleIncludes(f)
+		if err != nil {
+			return nil, nil, nil, &RuleParseError{Path: f, Err: fmt.Errorf("unable to resolve the includes of ruleset %s: %w", f, err)}
+		}
+		internRuleSet, internNeedProviders, provConditions, err := ruleParser.LoadRules(rulePath)
+		if err != nil {
+			if a.strictRules {
+				return nil, nil, nil, &RuleParseError{Path: f, Err: fmt.Errorf("unable to parse all the rules for ruleset %s: %w", f, err)}
+			}
+			a.log.Error(err, "unable to parse all the rules for ruleset", "file", f)
+		}
+		ruleSets = append(ruleSets, internRuleSet...)
+		for k, v := range internNeedProviders {
+			needProviders[k] = v
+		}
+		for k, v := range provConditions {
+			if _, ok := providerConditions[k]; !ok {
+				providerConditions[k] = []provider.ConditionsByCap{}
+			}
+			providerConditions[k] = append(providerConditions[k], v...)
+		}
+	}
+	operationalLog.Info("[TIMING] Rule parsing complete", "rulesets", len(ruleSets), "duration_ms", time.Since(startParsing).Milliseconds())
+	return ruleSets, needProviders, providerConditions, nil
+}
+
+// runDependencyOnlyContainerless writes the dependency output of the started
+// providers without loading or running any rules, used with --deps-only.
+func (a *analyzeCommand) runDependencyOnlyContainerless(ctx context.Context, providers map[string]provider.InternalProviderClient,
+	operationalLog logr.Logger, progressMode *ProgressMode, progressDone chan struct{}, progressCancel context.CancelFunc) error {
+	startDeps := time.Now()
+	operationalLog.Info("[TIMING] Starting dependency only analysis")
+	depCtx, depSpan := tracing.StartNewSpan(ctx, "dep")
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	a.DependencyOutputContainerless(depCtx, providers, a.dependencyOutputFile(), wg)
+	depSpan.End()
+
+	if progressMode.IsEnabled() {
+		progressCancel()
+		<-progressDone
+	}
+	for _, provider := range providers {
+		provider.Stop()
+	}
+	operationalLog.Info("[TIMING] Dependency only analysis complete", "duration_ms", time.Since(startDeps).Milliseconds())
+
+	depsPaths := []string{}
+	for _, depsFile := range a.dependencyOutputFiles() {
+		depsPath := filepath.Join(a.output, depsFile)
+		if _, err := os.Stat(depsPath); err != nil {
+			return fmt.Errorf("%w failed to get dependency output", err)
+		}
+		depsPaths = append(depsPaths, depsPath)
+	}
+	if err := a.chownOutput(); err != nil {
+		return err
+	}
+	progressMode.Println("\nResults:")
+	for _, depsPath := range depsPaths {
+		progressMode.Printf("  Dependencies: %s\n", depsPath)
+	}
+	progressMode.Printf("  Analysis logs: %s\n", filepath.Join(a.output, "analysis.log"))
 	return nil
 }
 
 func (a *analyzeCommand) ValidateContainerless(ctx context.Context) error {
+	// remote and image input are usually already fetched in Validate
+	if isRemoteArchiveInput(a.input) {
+		if err := a.fetchRemoteInput(ctx); err != nil {
+			return err
+		}
+	}
+	if isImageInput(a.input) {
+		if err := a.fetchImageInput(ctx); err != nil {
+			return err
+		}
+	}
+
 	// validate input app is not the current dir
 	// .metadata cannot initialize in the app root
 	currentDir, err := os.Getwd()
@@ -442,9 +725,17 @@ func (a *analyzeCommand) ValidateContainerless(ctx context.Context) error {
 		return fmt.Errorf("JAVA_HOME is not set; ensure JAVA_HOME is set")
 	}
 
-	// Validate .kantra in home directory and its content (containerless)
-	requiredDirs := []string{a.kantraDir, filepath.Join(a.kantraDir, RulesetsLocation), filepath.Join(a.kantraDir, JavaBundlesLocation),
-		filepath.Join(a.kantraDir, JDTLSBinLocation), filepath.Join(a.kantraDir, "fernflower.jar")}
+	if a.mavenSettingsFile != "" {
+		if err := validateMavenSettingsFile(a.mavenSettingsFile); err != nil {
+			return err
+		}
+	}
+
+	// Validate .kantra in home directory and its content (containerless),
+	// the java bundle and jdtls overrides replace the ones of the kantra dir
+	bins := a.javaProviderBins()
+	requiredDirs := []string{a.kantraDir, filepath.Join(a.kantraDir, RulesetsLocation), bins["bundle"],
+		bins["jdtls"], filepath.Join(a.kantraDir, "fernflower.jar")}
 	for _, path := range requiredDirs {
 		if _, err := os.Stat(path); os.IsNotExist(err) {
 			a.log.Error(err, "cannot open required path, ensure that container-less dependencies are installed")
@@ -509,6 +800,17 @@ func (a *analyzeCommand) walkRuleFilesForLabelsContainerless(label string) ([]st
 }
 
 func (a *analyzeCommand) setKantraDir() error {
+	dir, err := findKantraDir(a.log)
+	if err != nil {
+		return err
+	}
+	a.kantraDir = dir
+	return nil
+}
+
+// findKantraDir returns the directory containing the containerless
+// requirements, either the current dir or $HOME/.kantra
+func findKantraDir(log logr.Logger) (string, error) {
 	var dir string
 	var err error
 	set := true
@@ -520,20 +822,19 @@ func (a *analyzeCommand) setKantraDir() error {
 	// check current dir first for reqs
 	dir, err = os.Getwd()
 	if err != nil {
-		return err
+		return "", err
 	}
 	for _, v := range reqs {
 		_, err := os.Stat(filepath.Join(dir, v))
 		if err != nil {
 			set = false
-			a.log.V(7).Info("requirement not found in current dir. Checking $HOME/.kantra")
+			log.V(7).Info("requirement not found in current dir. Checking $HOME/.kantra")
 			break
 		}
 	}
 	// all reqs found here
 	if set {
-		a.kantraDir = dir
-		return nil
+		return dir, nil
 	}
 	// fall back to $HOME/.kantra
 	ops := runtime.GOOS
@@ -544,33 +845,88 @@ func (a *analyzeCommand) setKantraDir() error {
 		// on Unix, including macOS, this returns the $HOME environment variable. On Windows, it returns %USERPROFILE%
 		dir, err = os.UserHomeDir()
 		if err != nil {
-			return err
+			return "", err
 		}
 	}
-	a.kantraDir = filepath.Join(dir, ".kantra")
-	return nil
+	return filepath.Join(dir, ".kantra"), nil
 }
 
 func (a *analyzeCommand) setBinMapContainerless() error {
-	a.reqMap["bundle"] = filepath.Join(a.kantraDir, JavaBundlesLocation)
-	a.reqMap["jdtls"] = filepath.Join(a.kantraDir, JDTLSBinLocation)
+	for name, path := range a.javaProviderBins() {
+		a.reqMap[name] = path
+	}
 	// validate
 	for _, v := range a.reqMap {
-		stat, err := os.Stat(v)
-		if err != nil {
-			return fmt.Errorf("%w failed to stat bin %s", err, v)
+		if err := checkBinFile(v); err != nil {
+			return err
 		}
-		if stat.Mode().IsDir() {
-			return fmt.Errorf("unable to find expected file at %s", v)
+	}
+	return nil
+}
+
+// javaProviderBins returns the java bundle and jdtls paths under the kantra
+// dir, or their --provider-binary overrides
+func (a *analyzeCommand) javaProviderBins() map[string]string {
+	bins := map[string]string{
+		"bundle": filepath.Join(a.kantraDir, JavaBundlesLocation),
+		"jdtls":  filepath.Join(a.kantraDir, JDTLSBinLocation),
+	}
+	for name := range bins {
+		if path, ok := a.providerBinaryOverrides[name]; ok {
+			bins[name] = path
 		}
 	}
+	return bins
+}
+
+func checkBinFile(path string) error {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%w failed to stat bin %s", err, path)
+	}
+	if stat.Mode().IsDir() {
+		return fmt.Errorf("unable to find expected file at %s", path)
+	}
 	return nil
 }
 
+// analyzerLogWriter returns where the analyzer and provider logs go, the
+// analysis log file and with --verbose-provider also stdout
+func (a *analyzeCommand) analyzerLogWriter(analysisLog io.Writer) io.Writer {
+	if a.verboseProvider {
+		return io.MultiWriter(analysisLog, os.Stdout)
+	}
+	return analysisLog
+}
+
+// isBinaryInput reports whether the input is a java binary, which the java
+// provider decompiles before analysis
+func (a *analyzeCommand) isBinaryInput() bool {
+	if !a.isFileInput {
+		return false
+	}
+	switch filepath.Ext(a.input) {
+	case util.JavaArchive, util.WebArchive, util.EnterpriseArchive, util.ClassFile:
+		return true
+	}
+	return false
+}
+
 func (a *analyzeCommand) makeBuiltinProviderConfig() provider.Config {
+	// a binary is no directory to search, the java provider returns the
+	// location of the decompiled sources for the builtin provider instead
+	if a.isBinaryInput() {
+		return provider.Config{
+			Name:       "builtin",
+			InitConfig: []provider.InitConfig{},
+		}
+	}
 	providerSpecificConfig := map[string]interface{}{}
-	if excludedDir := util.GetProfilesExcludedDir(a.input, false); excludedDir != "" {
-		providerSpecificConfig["excludedDirs"] = []interface{}{excludedDir}
+	if excludedDirs := a.excludedDirs(a.input, false); len(excludedDirs) > 0 {
+		providerSpecificConfig["excludedDirs"] = excludedDirs
+	}
+	if includedPaths := a.sinceIncludedPaths(); len(includedPaths) > 0 {
+		providerSpecificConfig[provider.IncludedPathsConfigKey] = includedPaths
 	}
 
 	builtinConfig := provider.Config{
@@ -578,11 +934,14 @@ func (a *analyzeCommand) makeBuiltinProviderConfig() provider.Config {
 		InitConfig: []provider.InitConfig{
 			{
 				Location:               a.input,
-				AnalysisMode:           provider.AnalysisMode(a.mode),
+				AnalysisMode:           a.modeForProvider("builtin"),
 				ProviderSpecificConfig: providerSpecificConfig,
 			},
 		},
 	}
+	if a.mappedSourcesDir != "" {
+		builtinConfig.InitConfig = append(builtinConfig.InitConfig, a.mappedSourcesInitConfig(builtinConfig.InitConfig[0]))
+	}
 	return builtinConfig
 }
 
@@ -599,8 +958,11 @@ func (a *analyzeCommand) makeJavaProviderConfig() provider.Config {
 		"gradleSourcesTaskFile":         filepath.Join(a.kantraDir, "task.gradle"),
 	}
 
-	if excludedDir := util.GetProfilesExcludedDir(a.input, false); excludedDir != "" {
-		providerSpecificConfig["excludedDirs"] = []interface{}{excludedDir}
+	if excludedDirs := a.excludedDirs(a.input, false); len(excludedDirs) > 0 {
+		providerSpecificConfig["excludedDirs"] = excludedDirs
+	}
+	if includedPaths := a.sinceIncludedPaths(); len(includedPaths) > 0 {
+		providerSpecificConfig[provider.IncludedPathsConfigKey] = includedPaths
 	}
 
 	javaConfig := provider.Config{
@@ -609,7 +971,7 @@ func (a *analyzeCommand) makeJavaProviderConfig() provider.Config {
 		InitConfig: []provider.InitConfig{
 			{
 				Location:               a.input,
-				AnalysisMode:           provider.AnalysisMode(a.mode),
+				AnalysisMode:           a.modeForProvider(util.JavaProvider),
 				ProviderSpecificConfig: providerSpecificConfig,
 			},
 		},
@@ -620,26 +982,140 @@ func (a *analyzeCommand) makeJavaProviderConfig() provider.Config {
 	if Settings.JvmMaxMem != "" {
 		javaConfig.InitConfig[0].ProviderSpecificConfig["jvmMaxMem"] = Settings.JvmMaxMem
 	}
+	// -Xmx from --jvm-args takes precedence over JVM_MAX_MEM
+	if maxMem, _ := splitJvmArgs(a.jvmArgs); maxMem != "" {
+		javaConfig.InitConfig[0].ProviderSpecificConfig["jvmMaxMem"] = maxMem
+	}
+	if a.mappedSourcesDir != "" {
+		javaConfig.InitConfig = append(javaConfig.InitConfig, a.mappedSourcesInitConfig(javaConfig.InitConfig[0]))
+	}
 	return javaConfig
 }
 
+// genericProviderLSPBinaries are the language servers of the providers run
+// on the host through the generic external provider, next to java
+var genericProviderLSPBinaries = map[string]string{
+	util.GoProvider:     GoLSPBinary,
+	util.PythonProvider: PythonLSPBinary,
+}
+
+// genericProvidersContainerless returns the detected providers to run through
+// the generic external provider, in a stable order
+func (a *analyzeCommand) genericProvidersContainerless() []string {
+	names := []string{}
+	for _, name := range a.foundProviders {
+		if _, ok := genericProviderLSPBinaries[name]; ok && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// genericProviderBins returns the generic external provider and language
+// server binaries used to run the given provider on the host
+func (a *analyzeCommand) genericProviderBins(name string) (string, string, error) {
+	genericProviderBin, err := a.lookupProviderBin(GenericProviderBinary)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to find %s for the %s provider, install it in %s or in PATH, or use --run-local=false: %w",
+			GenericProviderBinary, name, a.kantraDir, err)
+	}
+	lspBin, err := a.lookupProviderBin(genericProviderLSPBinaries[name])
+	if err != nil {
+		return "", "", fmt.Errorf("unable to find %s for the %s provider, install it in %s or in PATH, or use --run-local=false: %w",
+			genericProviderLSPBinaries[name], name, a.kantraDir, err)
+	}
+	return genericProviderBin, lspBin, nil
+}
+
+// lookupProviderBin returns the --provider-binary override of a provider
+// binary, or looks for it in the kantra dir first and then in PATH
+func (a *analyzeCommand) lookupProviderBin(name string) (string, error) {
+	if path, ok := a.providerBinaryOverrides[name]; ok {
+		return path, nil
+	}
+	kantraBin := filepath.Join(a.kantraDir, name)
+	if stat, err := os.Stat(kantraBin); err == nil && stat.Mode().IsRegular() {
+		return kantraBin, nil
+	}
+	return exec.LookPath(name)
+}
+
+func (a *analyzeCommand) makeGenericProviderConfig(name string, genericProviderBin string, lspBin string) provider.Config {
+	providerSpecificConfig := map[string]interface{}{
+		"lspServerName":                 "generic",
+		provider.LspServerPathConfigKey: lspBin,
+	}
+	if name == util.GoProvider {
+		// go dependencies are listed by a separate binary, without it the
+		// analysis runs but no go dependencies are reported
+		if depProviderBin, err := a.lookupProviderBin(GoDependencyProviderBinary); err == nil {
+			providerSpecificConfig["dependencyProviderPath"] = depProviderBin
+		} else {
+			a.log.Info("WARNING: go dependencies are not listed, install it in the kantra directory or in PATH",
+				"binary", GoDependencyProviderBinary)
+		}
+	}
+	if excludedDirs := a.excludedDirs(a.input, false); len(excludedDirs) > 0 {
+		providerSpecificConfig["excludedDirs"] = excludedDirs
+	}
+	if includedPaths := a.sinceIncludedPaths(); len(includedPaths) > 0 {
+		providerSpecificConfig[provider.IncludedPathsConfigKey] = includedPaths
+	}
+	genericConfig := provider.Config{
+		Name:       name,
+		BinaryPath: genericProviderBin,
+		InitConfig: []provider.InitConfig{
+			{
+				Location:               a.input,
+				AnalysisMode:           a.modeForProvider(name),
+				ProviderSpecificConfig: providerSpecificConfig,
+			},
+		},
+	}
+	if name == util.PythonProvider && len(a.depFolders) != 0 {
+		genericConfig.InitConfig[0].ProviderSpecificConfig["dependencyFolders"] = a.depFolders
+	}
+	return genericConfig
+}
+
+// providerProxy returns the proxy of the providers, nil when none is
+// configured. The proxy flags default to the HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY environment variables.
+func (a *analyzeCommand) providerProxy() *provider.Proxy {
+	if a.httpProxy == "" && a.httpsProxy == "" {
+		return nil
+	}
+	return &provider.Proxy{
+		HTTPProxy:  a.httpProxy,
+		HTTPSProxy: a.httpsProxy,
+		NoProxy:    a.noProxy,
+	}
+}
+
 func (a *analyzeCommand) createProviderConfigsContainerless() ([]provider.Config, error) {
+	if a.providerSettings != "" {
+		configs, err := loadProviderSettings(a.providerSettings)
+		if err != nil {
+			return nil, err
+		}
+		return a.setConfigsContainerless(configs), nil
+	}
 	builtinConfig := a.makeBuiltinProviderConfig()
 	javaConfig := a.makeJavaProviderConfig()
 
 	provConfigs := []provider.Config{builtinConfig, javaConfig}
+	for _, name := range a.genericProvidersContainerless() {
+		genericProviderBin, lspBin, err := a.genericProviderBins(name)
+		if err != nil {
+			return nil, err
+		}
+		provConfigs = append(provConfigs, a.makeGenericProviderConfig(name, genericProviderBin, lspBin))
+	}
 
 	for i := range provConfigs {
 		// Set proxy to providers
-		if a.httpProxy != "" || a.httpsProxy != "" {
-			proxy := provider.Proxy{
-				HTTPProxy:  a.httpProxy,
-				HTTPSProxy: a.httpsProxy,
-				NoProxy:    a.noProxy,
-			}
-
-			provConfigs[i].Proxy = &proxy
-		}
+		provConfigs[i].Proxy = a.providerProxy()
 		provConfigs[i].ContextLines = a.contextLines
 	}
 
@@ -677,6 +1153,10 @@ func (a *analyzeCommand) setConfigsContainerless(configs []provider.Config) []pr
 						if err != nil {
 							builtinLocation = initConf.Location
 						}
+						// nested projects below --max-depth aren't analyzed
+						if a.isBelowMaxDepth(builtinLocation) {
+							continue
+						}
 						seenBuiltinConfigs[builtinLocation] = true
 						builtinConf = provider.InitConfig{Location: builtinLocation}
 						if config.Name == "builtin" {
@@ -714,7 +1194,7 @@ func (a *analyzeCommand) setBuiltinProvider(config provider.Config, analysisLog
 	if a.mode != "" {
 		inits := []provider.InitConfig{}
 		for _, i := range config.InitConfig {
-			i.AnalysisMode = provider.AnalysisMode(a.mode)
+			i.AnalysisMode = a.modeForProvider(config.Name)
 			inits = append(inits, i)
 		}
 		config.InitConfig = inits
@@ -737,7 +1217,7 @@ func (a *analyzeCommand) setJavaProvider(config provider.Config, analysisLog log
 	if a.mode != "" {
 		inits := []provider.InitConfig{}
 		for _, i := range config.InitConfig {
-			i.AnalysisMode = provider.AnalysisMode(a.mode)
+			i.AnalysisMode = a.modeForProvider(config.Name)
 			inits = append(inits, i)
 		}
 		config.InitConfig = inits
@@ -748,14 +1228,7 @@ func (a *analyzeCommand) setJavaProvider(config provider.Config, analysisLog log
 
 func (a *analyzeCommand) setupJavaProvider(ctx context.Context, analysisLog logr.Logger, operationalLog logr.Logger, progressReporter progress.ProgressReporter) (provider.InternalProviderClient, []string, []provider.InitConfig, error) {
 	javaConfig := a.makeJavaProviderConfig()
-	if a.httpProxy != "" || a.httpsProxy != "" {
-		proxy := provider.Proxy{
-			HTTPProxy:  a.httpProxy,
-			HTTPSProxy: a.httpsProxy,
-			NoProxy:    a.noProxy,
-		}
-		javaConfig.Proxy = &proxy
-	}
+	javaConfig.Proxy = a.providerProxy()
 	javaConfig.ContextLines = a.contextLines
 
 	// Add prepare progress reporter if available
@@ -776,30 +1249,75 @@ func (a *analyzeCommand) setupJavaProvider(ctx context.Context, analysisLog logr
 	operationalLog.Info("starting provider", "provider", util.JavaProvider)
 	initCtx, initSpan := tracing.StartNewSpan(ctx, "init",
 		attribute.Key("provider").String(util.JavaProvider))
-	additionalBuiltinConfs, err := javaProvider.ProviderInit(initCtx, nil)
+	var additionalBuiltinConfs []provider.InitConfig
+	// extra JVM options reach the jdtls launch through its environment
+	err := a.withJavaToolOptions(operationalLog, func() (err error) {
+		additionalBuiltinConfs, err = javaProvider.ProviderInit(initCtx, nil)
+		return err
+	})
 	if err != nil {
 		a.log.Error(err, "unable to init the providers", "provider", util.JavaProvider)
 		initSpan.End()
 		return nil, nil, nil, err
 	}
 	initSpan.End()
+	if a.waitForProviderReady {
+		a.pollProviderReady(ctx, util.JavaProvider, javaProvider)
+	}
 
 	return javaProvider, providerLocations, additionalBuiltinConfs, nil
 }
 
+// setupGenericProvider starts a go or python provider on the host through
+// the generic external provider binary with the language server of the
+// provider
+func (a *analyzeCommand) setupGenericProvider(ctx context.Context, name string, analysisLog logr.Logger, operationalLog logr.Logger, overrideConfigs []provider.Config, progressReporter progress.ProgressReporter) (provider.InternalProviderClient, []string, []provider.InitConfig, error) {
+	genericProviderBin, lspBin, err := a.genericProviderBins(name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	genericConfig := a.makeGenericProviderConfig(name, genericProviderBin, lspBin)
+	genericConfig.Proxy = a.providerProxy()
+	genericConfig.ContextLines = a.contextLines
+	genericConfig = applyProviderOverrides(genericConfig, overrideConfigs)
+
+	// Add prepare progress reporter if available
+	// Note: Only set on InitConfig level to avoid duplicate progress events
+	if progressReporter != nil {
+		for i := range genericConfig.InitConfig {
+			genericConfig.InitConfig[i].PrepareProgressReporter = provider.NewPrepareProgressAdapter(progressReporter)
+		}
+	}
+
+	providerLocations := []string{}
+	for _, ind := range genericConfig.InitConfig {
+		providerLocations = append(providerLocations, ind.Location)
+	}
+
+	operationalLog.Info("setting provider from provider config", "provider", genericConfig.Name)
+	genericProvider, err := lib.GetProviderClient(genericConfig, analysisLog)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create %s provider: %w", name, err)
+	}
+
+	operationalLog.Info("starting provider", "provider", name)
+	initCtx, initSpan := tracing.StartNewSpan(ctx, "init",
+		attribute.Key("provider").String(name))
+	defer initSpan.End()
+	additionalBuiltinConfs, err := genericProvider.ProviderInit(initCtx, nil)
+	if err != nil {
+		a.log.Error(err, "unable to init the providers", "provider", name)
+		return nil, nil, nil, err
+	}
+
+	return genericProvider, providerLocations, additionalBuiltinConfs, nil
+}
+
 func (a *analyzeCommand) setupBuiltinProvider(ctx context.Context, additionalConfigs []provider.InitConfig, analysisLog logr.Logger, operationalLog logr.Logger, overrideConfigs []provider.Config, progressReporter progress.ProgressReporter) (provider.InternalProviderClient, []string, error) {
 	operationalLog.Info("setting up builtin provider")
 	builtinConfig := a.makeBuiltinProviderConfig()
 
-	// Set proxy if configured
-	if a.httpProxy != "" || a.httpsProxy != "" {
-		proxy := provider.Proxy{
-			HTTPProxy:  a.httpProxy,
-			HTTPSProxy: a.httpsProxy,
-			NoProxy:    a.noProxy,
-		}
-		builtinConfig.Proxy = &proxy
-	}
+	builtinConfig.Proxy = a.providerProxy()
 	builtinConfig.ContextLines = a.contextLines
 
 	// Apply override settings (same as containerized providers)
@@ -844,11 +1362,16 @@ func (a *analyzeCommand) setInternalProviders(finalConfigs []provider.Config, an
 		var prov provider.InternalProviderClient
 		var err error
 
-		// only create java and builtin providers
+		// only create java, go, python and builtin providers
 		if config.Name == util.JavaProvider {
-			prov = a.setJavaProvider(config, analysisLog, logr.Discard())
+			prov = a.setJavaProvider(config, a.providerLogger(config.Name, analysisLog), logr.Discard())
+		} else if _, ok := genericProviderLSPBinaries[config.Name]; ok {
+			prov, err = lib.GetProviderClient(config, a.providerLogger(config.Name, analysisLog))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to set %s provider: %w", config.Name, err)
+			}
 		} else if config.Name == "builtin" {
-			prov, err = a.setBuiltinProvider(config, analysisLog, logr.Discard())
+			prov, err = a.setBuiltinProvider(config, a.providerLogger(config.Name, analysisLog), logr.Discard())
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to set builtin provider: %w", err)
 			}
@@ -858,6 +1381,26 @@ func (a *analyzeCommand) setInternalProviders(finalConfigs []provider.Config, an
 	return providers, providerLocations, nil
 }
 
+// continueWithoutProviders reports whether providers failing to start are
+// left out instead of failing the analysis
+func (a *analyzeCommand) continueWithoutProviders() bool {
+	return a.bestEffortProviders || a.checkProviders
+}
+
+// markProviderUnavailable records a provider that failed to start with
+// --best-effort-providers or --check-providers, the analysis continues
+// without it
+func (a *analyzeCommand) markProviderUnavailable(name string, err error) {
+	if !a.checkProviders {
+		a.log.Info("WARNING: continuing analysis without provider that failed to start", "provider", name)
+	}
+	a.unavailableProviders = append(a.unavailableProviders, name)
+	if a.providerErrors == nil {
+		a.providerErrors = map[string]error{}
+	}
+	a.providerErrors[name] = err
+}
+
 func (a *analyzeCommand) startProvidersContainerless(ctx context.Context, needProviders map[string]provider.InternalProviderClient) error {
 	// Now that we have all the providers, we need to start them.
 	additionalBuiltinConfigs := []provider.InitConfig{}
@@ -875,18 +1418,30 @@ func (a *analyzeCommand) startProvidersContainerless(ctx context.Context, needPr
 			if err != nil {
 				a.log.Error(err, "unable to init the providers", "provider", name)
 				initSpan.End()
-				return fmt.Errorf("unable to init provider %s: %w", name, err)
+				if !a.continueWithoutProviders() {
+					return &ProviderInitError{Provider: name, Err: fmt.Errorf("unable to init provider %s: %w", name, err)}
+				}
+				a.markProviderUnavailable(name, err)
+				delete(needProviders, name)
+				continue
 			}
 			if additionalBuiltinConfs != nil {
 				additionalBuiltinConfigs = append(additionalBuiltinConfigs, additionalBuiltinConfs...)
 			}
 			initSpan.End()
+			if name == util.JavaProvider && a.waitForProviderReady {
+				a.pollProviderReady(ctx, name, provider)
+			}
 		}
 	}
 
 	if builtinClient, ok := needProviders["builtin"]; ok {
 		if _, err := builtinClient.ProviderInit(ctx, additionalBuiltinConfigs); err != nil {
-			return err
+			if !a.checkProviders {
+				return &ProviderInitError{Provider: "builtin", Err: err}
+			}
+			a.markProviderUnavailable("builtin", err)
+			delete(needProviders, "builtin")
 		}
 	}
 	return nil
@@ -896,42 +1451,106 @@ func (a *analyzeCommand) DependencyOutputContainerless(ctx context.Context, prov
 	defer wg.Done()
 	var depsFlat []konveyor.DepsFlatItem
 	var depsTree []konveyor.DepsTreeItem
-	var err error
 
-	for _, prov := range providers {
-		deps, err := prov.GetDependencies(ctx)
-		if err != nil {
-			a.log.Error(err, "failed to get list of dependencies for provider", "provider", "java")
+	writeFlat := a.depOutput != depOutputTree
+	writeTree := a.depOutput == depOutputTree || a.depOutput == depOutputBoth
+
+	// the same dependencies are left out as from the analysis
+	depSelector, err := a.dependencyLabelSelector()
+	if err != nil {
+		a.log.Error(err, "failed to create dependency label selector")
+		return
+	}
+
+	for name, prov := range providers {
+		// source-only providers, e.g. --provider-mode go=source-only, have
+		// no dependencies to list
+		if name != "builtin" && a.modeForProvider(name) != provider.FullAnalysisMode {
+			continue
 		}
-		for u, ds := range deps {
-			newDeps := ds
-			depsFlat = append(depsFlat, konveyor.DepsFlatItem{
-				Provider:     "java",
-				FileURI:      string(u),
-				Dependencies: newDeps,
-			})
+		if writeFlat {
+			deps, err := prov.GetDependencies(ctx)
+			if err != nil {
+				a.log.Error(err, "failed to get list of dependencies for provider", "provider", name)
+			}
+			for u, ds := range deps {
+				newDeps := ds
+				if depSelector != nil {
+					newDeps, err = depSelector.MatchList(ds)
+					if err != nil {
+						a.log.Error(err, "error matching label selector on deps", "provider", name)
+						continue
+					}
+				}
+				depsFlat = append(depsFlat, konveyor.DepsFlatItem{
+					Provider:     name,
+					FileURI:      string(u),
+					Dependencies: newDeps,
+				})
+			}
 		}
-
-		if depsFlat == nil && depsTree == nil {
-			a.log.V(4).Info("did not get dependencies from all given providers")
-			return
+		if writeTree {
+			deps, err := prov.GetDependenciesDAG(ctx)
+			if err != nil {
+				a.log.Error(err, "failed to get dependency tree for provider", "provider", name)
+			}
+			for u, ds := range deps {
+				if depSelector != nil {
+					ds, err = filterDependencyTree(depSelector, ds)
+					if err != nil {
+						a.log.Error(err, "error matching label selector on deps", "provider", name)
+						continue
+					}
+				}
+				depsTree = append(depsTree, konveyor.DepsTreeItem{
+					Provider:     name,
+					FileURI:      string(u),
+					Dependencies: ds,
+				})
+			}
 		}
 	}
 
+	if depsFlat == nil && depsTree == nil {
+		a.log.V(4).Info("did not get dependencies from all given providers")
+		return
+	}
+
+	if writeFlat {
+		// Sort depsFlat
+		sort.SliceStable(depsFlat, func(i, j int) bool {
+			if depsFlat[i].Provider == depsFlat[j].Provider {
+				return depsFlat[i].FileURI < depsFlat[j].FileURI
+			} else {
+				return depsFlat[i].Provider < depsFlat[j].Provider
+			}
+		})
+		a.depsFlat = depsFlat
+		a.writeDependencyOutput(depsFlat, depOutputFile)
+	}
+
+	if writeTree {
+		sortDepsTree(depsTree)
+		a.writeDependencyOutput(depsTree, dependencyTreeOutputFile(depOutputFile))
+	}
+}
+
+// writeDependencyOutput marshals deps as json or yaml based on the file extension
+func (a *analyzeCommand) writeDependencyOutput(deps interface{}, depOutputFile string) {
 	var by []byte
-	// Sort depsFlat
-	sort.SliceStable(depsFlat, func(i, j int) bool {
-		if depsFlat[i].Provider == depsFlat[j].Provider {
-			return depsFlat[i].FileURI < depsFlat[j].FileURI
-		} else {
-			return depsFlat[i].Provider < depsFlat[j].Provider
+	var err error
+	if filepath.Ext(depOutputFile) == ".json" {
+		by, err = json.MarshalIndent(deps, "", "	")
+		if err != nil {
+			a.log.Error(err, "failed to marshal dependency data as json")
+			return
+		}
+	} else {
+		by, err = yaml.Marshal(deps)
+		if err != nil {
+			a.log.Error(err, "failed to marshal dependency data as yaml")
+			return
 		}
-	})
-
-	by, err = yaml.Marshal(depsFlat)
-	if err != nil {
-		a.log.Error(err, "failed to marshal dependency data as yaml")
-		return
 	}
 
 	err = os.WriteFile(filepath.Join(a.output, depOutputFile), by, 0644)
@@ -939,7 +1558,60 @@ func (a *analyzeCommand) DependencyOutputContainerless(ctx context.Context, prov
 		a.log.Error(err, "failed to write dependencies to output file", "file", depOutputFile)
 		return
 	}
+}
+
+// dependencyTreeOutputFile returns the tree output file name for the given
+// flat dependency output file, e.g. dependencies-tree.yaml
+func dependencyTreeOutputFile(depOutputFile string) string {
+	ext := filepath.Ext(depOutputFile)
+	return fmt.Sprintf("%s-tree%s", strings.TrimSuffix(depOutputFile, ext), ext)
+}
+
+// sortDepsTree sorts tree roots by provider and file, and every level of
+// dependencies by name and version, so output is stable between runs
+func sortDepsTree(depsTree []konveyor.DepsTreeItem) {
+	sort.SliceStable(depsTree, func(i, j int) bool {
+		if depsTree[i].Provider == depsTree[j].Provider {
+			return depsTree[i].FileURI < depsTree[j].FileURI
+		}
+		return depsTree[i].Provider < depsTree[j].Provider
+	})
+	for i := range depsTree {
+		sortDepDAGItems(depsTree[i].Dependencies)
+	}
+}
+
+func sortDepDAGItems(items []provider.DepDAGItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Dep.Name == items[j].Dep.Name {
+			return items[i].Dep.Version < items[j].Dep.Version
+		}
+		return items[i].Dep.Name < items[j].Dep.Name
+	})
+	for i := range items {
+		sortDepDAGItems(items[i].AddedDeps)
+	}
+}
+
+// dependencyOutputFile returns the file name dependency output is written to.
+// With --json-only dependencies are written straight to json.
+func (a *analyzeCommand) dependencyOutputFile() string {
+	if a.jsonOnly {
+		return "dependencies.json"
+	}
+	return "dependencies.yaml"
+}
 
+// dependencyOutputFiles returns the dependency files written with
+// --dep-output
+func (a *analyzeCommand) dependencyOutputFiles() []string {
+	switch a.depOutput {
+	case depOutputTree:
+		return []string{dependencyTreeOutputFile(a.dependencyOutputFile())}
+	case depOutputBoth:
+		return []string{a.dependencyOutputFile(), dependencyTreeOutputFile(a.dependencyOutputFile())}
+	}
+	return []string{a.dependencyOutputFile()}
 }
 
 func (a *analyzeCommand) buildStaticReportFile(ctx context.Context, staticReportPath string, depsErr bool) error {
@@ -947,8 +1619,8 @@ func (a *analyzeCommand) buildStaticReportFile(ctx context.Context, staticReport
 		return nil
 	}
 	// Prepare report args list with single input analysis
-	applicationNames := []string{filepath.Base(a.input)}
-	outputAnalyses := []string{filepath.Join(a.output, "output.yaml")}
+	applicationNames := []string{a.reportAppName()}
+	outputAnalyses := []string{a.outputFilePath("output.yaml")}
 	outputDeps := []string{filepath.Join(a.output, "dependencies.yaml")}
 	outputJSPath := filepath.Join(staticReportPath, "output.js")
 
@@ -990,7 +1662,7 @@ func (a *analyzeCommand) buildStaticReportFile(ctx context.Context, staticReport
 		return fmt.Errorf("failed to load report data from analysis output: %w", err)
 	}
 
-	err = generateJSBundle(apps, outputJSPath, a.log)
+	err = generateJSBundle(apps, a.reportInfo(), outputJSPath, a.log)
 	if err != nil {
 		return fmt.Errorf("failed to generate output.js file from template: %w", err)
 	}
@@ -1046,6 +1718,10 @@ func (a *analyzeCommand) GenerateStaticReportContainerless(ctx context.Context,
 	if err != nil {
 		return err
 	}
+	err = a.customizeStaticReport(filepath.Join(a.output, "static-report"), false)
+	if err != nil {
+		return err
+	}
 	uri := uri.File(filepath.Join(a.output, "static-report", "index.html"))
 	operationalLog.Info("Static report created. Access it at this URL:", "URL", string(uri))
 
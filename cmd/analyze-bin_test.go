@@ -2,13 +2,17 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
 	kantraProvider "github.com/konveyor-ecosystem/kantra/pkg/provider"
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
 	"github.com/konveyor/analyzer-lsp/provider"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1099,6 +1103,41 @@ func TestValidateContainerlessInput(t *testing.T) {
 	}
 }
 
+func TestValidateContainerlessProviderBinaryOverrides(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as mvn and java")
+	}
+	bin := t.TempDir()
+	for _, name := range []string{"mvn", "java", "jdtls"} {
+		require.NoError(t, os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\n"), 0755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "bundle.jar"), []byte{}, 0644))
+	t.Setenv("PATH", bin)
+	t.Setenv("JAVA_HOME", bin)
+
+	// the kantra dir has neither jdtls nor the java bundle
+	kantraDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(kantraDir, RulesetsLocation), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(kantraDir, "fernflower.jar"), []byte{}, 0644))
+	a := &analyzeCommand{
+		input: t.TempDir(),
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log:       logr.Discard(),
+			kantraDir: kantraDir,
+		},
+	}
+	assert.Error(t, a.ValidateContainerless(context.Background()))
+
+	a.providerBinaryOverrides = map[string]string{
+		"jdtls":  filepath.Join(bin, "jdtls"),
+		"bundle": filepath.Join(bin, "bundle.jar"),
+	}
+	assert.NoError(t, a.ValidateContainerless(context.Background()))
+
+	a.providerBinaryOverrides["jdtls"] = filepath.Join(bin, "missing")
+	assert.Error(t, a.ValidateContainerless(context.Background()))
+}
+
 func TestGenerateStaticReportContainerlessSkipFlag(t *testing.T) {
 	log := logr.Discard()
 
@@ -1241,3 +1280,277 @@ func sliceContains(slice []string, item string) bool {
 	}
 	return false
 }
+
+func TestCreateJSONOutputJSONOnly(t *testing.T) {
+	tmpOutput := t.TempDir()
+	// --json-only implies --json-output
+	a := &analyzeCommand{
+		jsonOnly:   true,
+		jsonOutput: true,
+		output:     tmpOutput,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "test-ruleset",
+			Violations: map[string]outputv1.Violation{
+				"rule-001": {Description: "test violation"},
+			},
+		},
+	}
+	require.NoError(t, a.CreateJSONOutput(rulesets))
+
+	data, err := os.ReadFile(filepath.Join(tmpOutput, "output.json"))
+	require.NoError(t, err)
+	got := []outputv1.RuleSet{}
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Len(t, got, 1)
+	assert.Equal(t, "test-ruleset", got[0].Name)
+	assert.Contains(t, got[0].Violations, "rule-001")
+
+	_, statErr := os.Stat(filepath.Join(tmpOutput, "output.yaml"))
+	assert.True(t, os.IsNotExist(statErr), "output.yaml should not be written in json only mode")
+	assert.Equal(t, "dependencies.json", a.dependencyOutputFile())
+
+	a.jsonOnly = false
+	assert.Equal(t, "dependencies.yaml", a.dependencyOutputFile())
+}
+
+func TestSortDepsTree(t *testing.T) {
+	depsTree := []outputv1.DepsTreeItem{
+		{
+			Provider: "java",
+			FileURI:  "file:///app/b/pom.xml",
+			Dependencies: []provider.DepDAGItem{
+				{
+					Dep: provider.Dep{Name: "org.b", Version: "1.0"},
+					AddedDeps: []provider.DepDAGItem{
+						{Dep: provider.Dep{Name: "org.z", Version: "2.0"}},
+						{Dep: provider.Dep{Name: "org.y", Version: "1.0"}},
+					},
+				},
+				{Dep: provider.Dep{Name: "org.a", Version: "2.0"}},
+				{Dep: provider.Dep{Name: "org.a", Version: "1.0"}},
+			},
+		},
+		{
+			Provider: "java",
+			FileURI:  "file:///app/a/pom.xml",
+		},
+	}
+
+	sortDepsTree(depsTree)
+
+	assert.Equal(t, "file:///app/a/pom.xml", depsTree[0].FileURI)
+	deps := depsTree[1].Dependencies
+	require.Len(t, deps, 3)
+	assert.Equal(t, "org.a", deps[0].Dep.Name)
+	assert.Equal(t, "1.0", deps[0].Dep.Version)
+	assert.Equal(t, "2.0", deps[1].Dep.Version)
+	assert.Equal(t, "org.b", deps[2].Dep.Name)
+	assert.Equal(t, "org.y", deps[2].AddedDeps[0].Dep.Name)
+}
+
+func TestDependencyTreeOutputFile(t *testing.T) {
+	assert.Equal(t, "dependencies-tree.yaml", dependencyTreeOutputFile("dependencies.yaml"))
+	assert.Equal(t, "dependencies-tree.json", dependencyTreeOutputFile("dependencies.json"))
+}
+
+func TestDependencyOutputFiles(t *testing.T) {
+	tests := []struct {
+		depOutput string
+		jsonOnly  bool
+		want      []string
+	}{
+		{depOutput: depOutputFlat, want: []string{"dependencies.yaml"}},
+		{depOutput: depOutputTree, want: []string{"dependencies-tree.yaml"}},
+		{depOutput: depOutputBoth, want: []string{"dependencies.yaml", "dependencies-tree.yaml"}},
+		{depOutput: depOutputTree, jsonOnly: true, want: []string{"dependencies-tree.json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.depOutput, func(t *testing.T) {
+			a := &analyzeCommand{depOutput: tt.depOutput, jsonOnly: tt.jsonOnly}
+			assert.Equal(t, tt.want, a.dependencyOutputFiles())
+		})
+	}
+}
+
+func TestMakePythonProviderConfig(t *testing.T) {
+	a := analyzeCommand{
+		input:      "/test/input",
+		mode:       "source-only",
+		depFolders: []string{"/test/deps"},
+	}
+
+	config := a.makeGenericProviderConfig(util.PythonProvider, "/test/generic-external-provider", "/test/pylsp")
+
+	assert.Equal(t, "python", config.Name)
+	assert.Equal(t, "/test/generic-external-provider", config.BinaryPath)
+	assert.Empty(t, config.Address)
+	require.Len(t, config.InitConfig, 1)
+
+	initConfig := config.InitConfig[0]
+	assert.Equal(t, "/test/input", initConfig.Location)
+	assert.Equal(t, provider.SourceOnlyAnalysisMode, initConfig.AnalysisMode)
+	assert.Equal(t, "generic", initConfig.ProviderSpecificConfig["lspServerName"])
+	assert.Equal(t, "/test/pylsp", initConfig.ProviderSpecificConfig[provider.LspServerPathConfigKey])
+	assert.Equal(t, []string{"/test/deps"}, initConfig.ProviderSpecificConfig["dependencyFolders"])
+}
+
+func TestMakeGoProviderConfig(t *testing.T) {
+	kantraDir := t.TempDir()
+	t.Setenv("PATH", t.TempDir())
+	depProviderBin := filepath.Join(kantraDir, GoDependencyProviderBinary)
+	require.NoError(t, os.WriteFile(depProviderBin, []byte("#!/bin/sh\n"), 0755))
+
+	a := analyzeCommand{
+		input:      "/test/input",
+		mode:       "full",
+		depFolders: []string{"/test/deps"},
+	}
+	a.AnalyzeCommandContext.kantraDir = kantraDir
+
+	config := a.makeGenericProviderConfig(util.GoProvider, "/test/generic-external-provider", "/test/gopls")
+
+	assert.Equal(t, "go", config.Name)
+	assert.Equal(t, "/test/generic-external-provider", config.BinaryPath)
+	require.Len(t, config.InitConfig, 1)
+	initConfig := config.InitConfig[0]
+	assert.Equal(t, provider.FullAnalysisMode, initConfig.AnalysisMode)
+	assert.Equal(t, "/test/gopls", initConfig.ProviderSpecificConfig[provider.LspServerPathConfigKey])
+	assert.Equal(t, depProviderBin, initConfig.ProviderSpecificConfig["dependencyProviderPath"])
+	assert.NotContains(t, initConfig.ProviderSpecificConfig, "dependencyFolders")
+}
+
+func TestGenericProvidersContainerless(t *testing.T) {
+	a := analyzeCommand{}
+	a.foundProviders = []string{util.PythonProvider, util.JavaProvider, util.GoProvider, util.NodeJSProvider}
+	assert.Equal(t, []string{util.GoProvider, util.PythonProvider}, a.genericProvidersContainerless())
+
+	a.foundProviders = []string{util.JavaProvider}
+	assert.Empty(t, a.genericProvidersContainerless())
+}
+
+func TestLookupProviderBin(t *testing.T) {
+	kantraDir := t.TempDir()
+	pathDir := t.TempDir()
+	t.Setenv("PATH", pathDir)
+
+	a := analyzeCommand{}
+	a.AnalyzeCommandContext.kantraDir = kantraDir
+
+	_, err := a.lookupProviderBin("pylsp")
+	assert.Error(t, err)
+
+	// found in PATH
+	pathBin := filepath.Join(pathDir, "pylsp")
+	require.NoError(t, os.WriteFile(pathBin, []byte("#!/bin/sh\n"), 0755))
+	bin, err := a.lookupProviderBin("pylsp")
+	require.NoError(t, err)
+	assert.Equal(t, pathBin, bin)
+
+	// kantra dir takes precedence over PATH
+	kantraBin := filepath.Join(kantraDir, "pylsp")
+	require.NoError(t, os.WriteFile(kantraBin, []byte("#!/bin/sh\n"), 0755))
+	bin, err = a.lookupProviderBin("pylsp")
+	require.NoError(t, err)
+	assert.Equal(t, kantraBin, bin)
+}
+
+func TestBinaryInputProviderConfigs(t *testing.T) {
+	tmpDir := t.TempDir()
+	binary := filepath.Join(tmpDir, "app.war")
+	require.NoError(t, os.WriteFile(binary, []byte("war"), 0644))
+
+	a := analyzeCommand{
+		input:  binary,
+		output: tmpDir,
+		mode:   string(provider.FullAnalysisMode),
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			isFileInput: true,
+			kantraDir:   tmpDir,
+			log:         logr.Discard(),
+		},
+	}
+	assert.True(t, a.isBinaryInput())
+	assert.Empty(t, a.makeBuiltinProviderConfig().InitConfig)
+
+	configs, err := a.createProviderConfigsContainerless()
+	require.NoError(t, err)
+	for _, config := range configs {
+		switch config.Name {
+		case util.JavaProvider:
+			require.Len(t, config.InitConfig, 1)
+			assert.Equal(t, binary, config.InitConfig[0].Location)
+		case "builtin":
+			// the decompiled location is only known once the java provider started
+			assert.Empty(t, config.InitConfig)
+		}
+	}
+}
+
+func TestDetectProvidersBinaryInput(t *testing.T) {
+	a := analyzeCommand{
+		input: "/app.jar",
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			isFileInput: true,
+			log:         logr.Discard(),
+		},
+	}
+	providers, err := a.detectProviders(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{util.JavaProvider}, providers)
+
+	a.provider = []string{util.PythonProvider}
+	_, err = a.detectProviders(nil)
+	assert.ErrorContains(t, err, "can only be analyzed by the java provider")
+}
+
+func TestAnalyzerLogWriter(t *testing.T) {
+	analysisLog := &strings.Builder{}
+	a := analyzeCommand{}
+	assert.Equal(t, analysisLog, a.analyzerLogWriter(analysisLog))
+
+	a.verboseProvider = true
+	assert.NotEqual(t, analysisLog, a.analyzerLogWriter(analysisLog))
+	_, err := a.analyzerLogWriter(analysisLog).Write([]byte("provider started\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "provider started\n", analysisLog.String())
+}
+
+func TestProviderProxy(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  analyzeCommand
+		want *provider.Proxy
+	}{
+		{
+			name: "no proxy",
+		},
+		{
+			name: "http proxy",
+			cmd:  analyzeCommand{httpProxy: "http://proxy:8080", noProxy: "localhost"},
+			want: &provider.Proxy{HTTPProxy: "http://proxy:8080", NoProxy: "localhost"},
+		},
+		{
+			name: "https proxy",
+			cmd:  analyzeCommand{httpsProxy: "http://proxy:8443"},
+			want: &provider.Proxy{HTTPSProxy: "http://proxy:8443"},
+		},
+		{
+			name: "no proxy alone",
+			cmd:  analyzeCommand{noProxy: "example.com"},
+		},
+	}
+	// the flags default to the environment, empty flags disable the proxy
+	t.Setenv("HTTP_PROXY", "http://env:8080")
+	t.Setenv("HTTPS_PROXY", "http://env:8443")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cmd.providerProxy())
+		})
+	}
+}
@@ -20,7 +20,7 @@ import (
 	"github.com/konveyor-ecosystem/kantra/pkg/util"
 	"github.com/konveyor/analyzer-lsp/engine"
 	"github.com/konveyor/analyzer-lsp/engine/labels"
-	"github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
 	"github.com/konveyor/analyzer-lsp/parser"
 	"github.com/konveyor/analyzer-lsp/progress"
 	"github.com/konveyor/analyzer-lsp/provider"
@@ -28,7 +28,6 @@ import (
 	"github.com/konveyor/analyzer-lsp/tracing"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel/trace"
-	"gopkg.in/yaml.v2"
 )
 
 // validateProviderConfig validates hybrid-mode-specific configuration before starting provider containers.
@@ -231,6 +230,15 @@ func (a *analyzeCommand) setupNetworkProvider(ctx context.Context, providerName
 			// Use container path where settings.xml is mounted (copied by getConfigVolumes)
 			providerSpecificConfig["mavenSettingsFile"] = path.Join(util.ConfigMountPath, "settings.xml")
 		}
+		if excludedDirs := a.excludedDirs(util.SourceMountPath, true); len(excludedDirs) > 0 {
+			providerSpecificConfig["excludedDirs"] = excludedDirs
+		}
+		if includedPaths := a.sinceIncludedPaths(); len(includedPaths) > 0 {
+			providerSpecificConfig[provider.IncludedPathsConfigKey] = includedPaths
+		}
+		if maxMem, _ := splitJvmArgs(a.jvmArgs); maxMem != "" {
+			providerSpecificConfig["jvmMaxMem"] = maxMem
+		}
 
 	case util.GoProvider:
 		providerSpecificConfig["lspServerName"] = "generic"
@@ -278,7 +286,7 @@ func (a *analyzeCommand) setupNetworkProvider(ctx context.Context, providerName
 		InitConfig: []provider.InitConfig{
 			{
 				Location:               util.SourceMountPath,
-				AnalysisMode:           provider.AnalysisMode(a.mode),
+				AnalysisMode:           a.modeForProvider(providerName),
 				ProviderSpecificConfig: providerSpecificConfig,
 				Proxy:                  proxyConfig, // Keep as pointer - InitConfig.Proxy is *Proxy!
 			},
@@ -410,9 +418,13 @@ func (a *analyzeCommand) setupBuiltinProviderHybrid(ctx context.Context, additio
 		// (node_modules, vendor, dist, build, target, .git, .venv, venv)
 	}
 
-	// Check if profiles directory exists in input and add to excludedDirs
-	if excludedDir := util.GetProfilesExcludedDir(a.input, false); excludedDir != "" {
-		providerSpecificConfig["excludedDirs"] = []interface{}{excludedDir}
+	// Check if profiles directory exists in input and add it and
+	// any --exclude patterns to excludedDirs
+	if excludedDirs := a.excludedDirs(a.input, false); len(excludedDirs) > 0 {
+		providerSpecificConfig["excludedDirs"] = excludedDirs
+	}
+	if includedPaths := a.sinceIncludedPaths(); len(includedPaths) > 0 {
+		providerSpecificConfig[provider.IncludedPathsConfigKey] = includedPaths
 	}
 
 	builtinConfig := provider.Config{
@@ -420,21 +432,13 @@ func (a *analyzeCommand) setupBuiltinProviderHybrid(ctx context.Context, additio
 		InitConfig: []provider.InitConfig{
 			{
 				Location:               a.input,
-				AnalysisMode:           provider.AnalysisMode(a.mode),
+				AnalysisMode:           a.modeForProvider("builtin"),
 				ProviderSpecificConfig: providerSpecificConfig,
 			},
 		},
 	}
 
-	// Set proxy if configured
-	if a.httpProxy != "" || a.httpsProxy != "" {
-		proxy := provider.Proxy{
-			HTTPProxy:  a.httpProxy,
-			HTTPSProxy: a.httpsProxy,
-			NoProxy:    a.noProxy,
-		}
-		builtinConfig.Proxy = &proxy
-	}
+	builtinConfig.Proxy = a.providerProxy()
 	builtinConfig.ContextLines = a.contextLines
 
 	// Apply override settings (same as containerized providers)
@@ -480,7 +484,7 @@ func (a *analyzeCommand) setupBuiltinProviderHybrid(ctx context.Context, additio
 // This approach combines the best of both worlds:
 //   - Clean output and direct control from in-process execution
 //   - Provider isolation and consistency from containers
-func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
+func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) (err error) {
 	startTotal := time.Now()
 
 	// Create progress mode to encapsulate progress reporting behavior
@@ -508,6 +512,14 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 		defer tracing.Shutdown(ctx, a.log, tp)
 		a.log.Info("Jaeger tracing initialized successfully")
 	}
+	if a.otelEndpoint != "" {
+		a.log.Info("initializing OpenTelemetry tracing", "endpoint", a.otelEndpoint)
+		tp, err := initOtelTracing(ctx, a.log, a.otelEndpoint, a.otelSampleRate)
+		if err != nil {
+			return fmt.Errorf("failed to initialize tracing: %w", err)
+		}
+		defer shutdownOtelTracing(a.log, tp)
+	}
 
 	// Hide cursor at the very start if progress is enabled
 	progressMode.HideCursor()
@@ -539,22 +551,26 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 
 	// Setup logging - analyzer logs to file, clean output to console
 	logrusAnalyzerLog := logrus.New()
-	logrusAnalyzerLog.SetOutput(analysisLog)
+	logrusAnalyzerLog.SetOutput(a.analyzerLogWriter(analysisLog))
 	logrusAnalyzerLog.SetFormatter(&logrus.TextFormatter{})
 	logrusAnalyzerLog.SetLevel(logrus.Level(logLevel))
 
 	// Add console hook for rule processing messages
 	// but only if progress is disabled (to avoid interfering with progress bar)
-	if progressMode.ShouldAddConsoleHook() {
+	if progressMode.ShouldAddConsoleHook() && !a.verboseProvider {
 		consoleHook := &ConsoleHook{Level: logrus.InfoLevel, Log: a.log}
 		logrusAnalyzerLog.AddHook(consoleHook)
 	}
 
 	analyzeLog := logrusr.New(logrusAnalyzerLog)
+	defer a.closeProviderLogs()
 
 	// Error logging to stderr
 	logrusErrLog := logrus.New()
 	logrusErrLog.SetOutput(os.Stderr)
+	if a.quiet {
+		logrusErrLog.SetLevel(logrus.WarnLevel)
+	}
 	errLog := logrusr.New(logrusErrLog)
 
 	// Setup label selectors
@@ -570,15 +586,22 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 		}
 		selectors = append(selectors, selector)
 	}
+	ruleIDSelectors, err := a.ruleIDSelectors()
+	if err != nil {
+		return fmt.Errorf("failed to create rule id selector: %w", err)
+	}
+	selectors = append(selectors, ruleIDSelectors...)
+	rulesVersionSelectors, err := a.rulesVersionSelectors()
+	if err != nil {
+		return fmt.Errorf("failed to create rules version selector: %w", err)
+	}
+	selectors = append(selectors, rulesVersionSelectors...)
+	selectors = a.includeDisabledRules(selectors)
 
-	var dependencyLabelSelector *labels.LabelSelector[*konveyor.Dep]
-	depLabel := fmt.Sprintf("!%v=open-source", provider.DepSourceLabel)
-	if !a.analyzeKnownLibraries {
-		dependencyLabelSelector, err = labels.NewLabelSelector[*konveyor.Dep](depLabel, nil)
-		if err != nil {
-			errLog.Error(err, "failed to create label selector from expression", "selector", depLabel)
-			return fmt.Errorf("failed to create label selector from expression %q: %w", depLabel, err)
-		}
+	dependencyLabelSelector, err := a.dependencyLabelSelector()
+	if err != nil {
+		errLog.Error(err, "failed to create dependency label selector")
+		return err
 	}
 
 	// Load override provider settings if specified
@@ -604,7 +627,7 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 
 		// Add extracted rulesets to rules list if we got any
 		if rulesetsDir != "" {
-			a.rules = append(a.rules, rulesetsDir)
+			a.rules = append(a.rules, a.defaultRulesetPaths(rulesetsDir)...)
 		}
 
 		// For binary files, util.SourceMountPath includes the filename (e.g., /opt/input/source/app.war)
@@ -630,7 +653,7 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 		}
 
 		if err != nil {
-			return fmt.Errorf("failed to start providers: %w", err)
+			return &ProviderInitError{Err: fmt.Errorf("failed to start providers: %w", err)}
 		}
 
 		progressMode.Printf("  ✓ Started provider containers\n")
@@ -659,7 +682,7 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 		for i := 0; i < len(a.providersMap); i++ {
 			result := <-healthChan
 			if result.err != nil {
-				return fmt.Errorf("provider %s health check failed: %w", result.providerName, result.err)
+				return &ProviderInitError{Provider: result.providerName, Err: fmt.Errorf("provider %s health check failed: %w", result.providerName, result.err)}
 			}
 		}
 
@@ -682,7 +705,7 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 	// Setup network-based provider clients for all configured providers
 	for provName := range a.providersMap {
 		a.log.Info("setting up network provider", "provider", provName)
-		provClient, locs, configs, err := a.setupNetworkProvider(ctx, provName, analyzeLog, overrideConfigs, reporter)
+		provClient, locs, configs, err := a.setupNetworkProvider(ctx, provName, a.providerLogger(provName, analyzeLog), overrideConfigs, reporter)
 		if err != nil {
 			errLog.Error(err, "unable to start provider", "provider", provName)
 			// Clean up any providers that were started before this failure
@@ -694,7 +717,7 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 			if cleanupErr := a.RmProviderContainers(ctx); cleanupErr != nil {
 				errLog.Error(cleanupErr, "failed to cleanup providers after setup failure")
 			}
-			return fmt.Errorf("unable to start provider %s: %w", provName, err)
+			return &ProviderInitError{Provider: provName, Err: fmt.Errorf("unable to start provider %s: %w", provName, err)}
 		}
 		providers[provName] = provClient
 		providerLocations = append(providerLocations, locs...)
@@ -729,10 +752,10 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 	}
 
 	// Setup builtin provider (always in-process)
-	builtinProvider, builtinLocations, err := a.setupBuiltinProviderHybrid(ctx, transformedConfigs, analyzeLog, overrideConfigs, reporter)
+	builtinProvider, builtinLocations, err := a.setupBuiltinProviderHybrid(ctx, transformedConfigs, a.providerLogger("builtin", analyzeLog), overrideConfigs, reporter)
 	if err != nil {
 		errLog.Error(err, "unable to start builtin provider")
-		return fmt.Errorf("unable to start builtin provider: %w", err)
+		return &ProviderInitError{Provider: "builtin", Err: fmt.Errorf("unable to start builtin provider: %w", err)}
 	}
 	providers["builtin"] = builtinProvider
 	providerLocations = append(providerLocations, builtinLocations...)
@@ -749,7 +772,7 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 	// Create rule engine
 	engineCtx, engineSpan := tracing.StartNewSpan(ctx, "rule-engine")
 	eng := engine.CreateRuleEngine(engineCtx,
-		10,
+		a.workers,
 		analyzeLog,
 		engine.WithContextLines(a.contextLines),
 		engine.WithIncidentSelector(a.incidentSelector),
@@ -790,6 +813,10 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 	providerConditions := map[string][]provider.ConditionsByCap{}
 	// Load each ruleset in parallel
 	for _, f := range a.rules {
+		resolvedPath, err := a.resolveRuleIncludes(f)
+		if err != nil {
+			return &RuleParseError{Path: f, Err: fmt.Errorf("unable to resolve the includes of ruleset %s: %w", f, err)}
+		}
 		ruleWg.Add(1)
 		go func(rulePath string) {
 			defer ruleWg.Done()
@@ -807,7 +834,7 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 				provConditions: provConditions,
 				err:            err,
 			}
-		}(f)
+		}(resolvedPath)
 	}
 
 	// Wait for all rule loading to complete
@@ -816,9 +843,11 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 
 	// Collect and merge results
 	var ruleLoadErrors []error
+	failedRulePath := ""
 	for result := range resultChan {
 		if result.err != nil {
 			ruleLoadErrors = append(ruleLoadErrors, fmt.Errorf("failed to load ruleset %s: %w", result.rulePath, result.err))
+			failedRulePath = result.rulePath
 			continue
 		}
 		ruleSets = append(ruleSets, result.ruleSets...)
@@ -833,10 +862,14 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 		}
 	}
 
+	if a.strictRules && len(ruleLoadErrors) > 0 {
+		return &RuleParseError{Path: failedRulePath, Err: fmt.Errorf("unable to parse all the rules: %v", ruleLoadErrors)}
+	}
+
 	// Check if we have at least one ruleset loaded successfully
 	if len(ruleSets) == 0 {
 		if len(ruleLoadErrors) > 0 {
-			return fmt.Errorf("failed to load any rulesets: %v", ruleLoadErrors)
+			return &RuleParseError{Path: failedRulePath, Err: fmt.Errorf("failed to load any rulesets: %v", ruleLoadErrors)}
 		}
 		return fmt.Errorf("no rulesets loaded")
 	}
@@ -849,6 +882,9 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 	}
 
 	a.log.Info("[TIMING] Rule loading complete", "duration_ms", time.Since(startRuleLoading).Milliseconds())
+	if err := a.checkStrictTargets(); err != nil {
+		return err
+	}
 
 	// prepare the providers
 	for name, conditions := range providerConditions {
@@ -862,7 +898,7 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 	// Start dependency analysis for full analysis mode
 	wg := &sync.WaitGroup{}
 	var depSpan trace.Span
-	if a.mode == string(provider.FullAnalysisMode) {
+	if a.modeForProvider(util.JavaProvider) == provider.FullAnalysisMode {
 		_, hasJava := a.providersMap[util.JavaProvider]
 		if hasJava {
 			var depCtx context.Context
@@ -870,7 +906,7 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 			wg.Add(1)
 
 			a.log.Info("running dependency analysis")
-			go a.DependencyOutputContainerless(depCtx, providers, "dependencies.yaml", wg)
+			go a.DependencyOutputContainerless(depCtx, providers, a.dependencyOutputFile(), wg)
 		}
 	}
 
@@ -880,9 +916,15 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 	a.log.Info("evaluating rules for violations. see analysis.log for more info")
 
 	// Run analysis with progress reporter (already created earlier)
-	rulesets := eng.RunRulesWithOptions(ctx, ruleSets, []engine.RunOption{
+	stopRuleProgressLog := startRuleProgressLog(ctx, a.log, reporter, len(ruleSets), ruleProgressLogInterval)
+	rulesets := []outputv1.RuleSet{}
+	defer a.flushOnPanic(&rulesets, reporter, &err)
+	a.applyRuleTimeout(ruleSets)
+	a.collectPartialResults(ruleSets)
+	rulesets = eng.RunRulesWithOptions(ctx, ruleSets, []engine.RunOption{
 		engine.WithProgressReporter(reporter),
 	}, selectors...)
+	stopRuleProgressLog()
 
 	// Cancel progress context and wait for goroutine to finish
 	if progressMode.IsEnabled() {
@@ -901,31 +943,86 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 	for _, provider := range needProviders {
 		provider.Stop()
 	}
+	// partial results are not written when the analysis was interrupted
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("analysis interrupted: %w", err)
+	}
 	a.log.Info("[TIMING] Rule execution complete", "duration_ms", time.Since(startRuleExecution).Milliseconds())
 
+	if err := a.writeRuleStats(ruleSets, rulesets); err != nil {
+		a.log.Error(err, "failed to write rule stats")
+		return &OutputWriteError{Path: filepath.Join(a.output, ruleStatsFile), Err: err}
+	}
+
+	rulesets = a.applySeverityOverlay(rulesets)
+	rulesets = a.filterExcludedIncidents(rulesets)
+	rulesets = a.filterIncidentsByPath(rulesets)
+	rulesets = a.filterIncidentsSince(rulesets)
+	rulesets = a.dedupeByRulesetPriority(rulesets)
+	rulesets = a.addStableIncidentIDs(rulesets)
+	rulesets, err = a.applyBaseline(rulesets)
+	if err != nil {
+		return err
+	}
+	if err := a.writeEffortReport(rulesets); err != nil {
+		a.log.Error(err, "failed to write effort report")
+		return &OutputWriteError{Path: filepath.Join(a.output, effortReportFile), Err: err}
+	}
+	rulesets = limitIncidents(rulesets, a.incidentLimit)
+	rulesets = a.redactIncidents(rulesets)
+	rulesets = a.addIncidentLabels(rulesets)
+	rulesets = a.relativizeIncidentURIs(rulesets)
+
 	// Sort rulesets
 	sort.SliceStable(rulesets, func(i, j int) bool {
 		return rulesets[i].Name < rulesets[j].Name
 	})
+	a.results = rulesets
+
+	if a.outputToStdout {
+		if err := a.writeStdoutOutput(os.Stdout, rulesets); err != nil {
+			return err
+		}
+		return checkFailOn(rulesets, a.failOn)
+	}
 
 	// Write results
 	startWriting := time.Now()
 	a.log.Info("[TIMING] Starting output writing")
 	a.log.Info("writing analysis results to output", "output", a.output)
-	b, err := yaml.Marshal(rulesets)
+	// --json-only skips output.yaml, output.json is written as --json-output is set
+	if !a.jsonOnly {
+		err := a.writeYAMLOutput(rulesets)
+		if err != nil {
+			return fmt.Errorf("failed to write output.yaml: %w", err)
+		}
+	}
+
+	// Create JSON output if requested
+	err = a.CreateJSONOutput(rulesets)
 	if err != nil {
+		a.log.Error(err, "failed to create json output file")
 		return err
 	}
 
-	err = os.WriteFile(filepath.Join(a.output, "output.yaml"), b, 0644)
+	err = a.validateOutputFile()
 	if err != nil {
-		return fmt.Errorf("failed to write output.yaml: %w", err)
+		a.log.Error(err, "analysis output failed schema validation")
+		return err
 	}
-
-	// Create JSON output if requested
-	err = a.CreateJSONOutput()
+	err = a.writeJUnitOutput(rulesets)
 	if err != nil {
-		a.log.Error(err, "failed to create json output file")
+		a.log.Error(err, "failed to create junit output file")
+		return err
+	}
+	err = a.writeCSVOutput(rulesets)
+	if err != nil {
+		a.log.Error(err, "failed to create csv output file")
+		return err
+	}
+	err = a.writeGitHubAnnotations(os.Stdout, rulesets)
+	if err != nil {
+		a.log.Error(err, "failed to write github annotations")
 		return err
 	}
 	a.log.Info("[TIMING] Output writing complete", "duration_ms", time.Since(startWriting).Milliseconds())
@@ -952,14 +1049,40 @@ func (a *analyzeCommand) RunAnalysisHybridInProcess(ctx context.Context) error {
 	}
 	a.log.Info("[TIMING] Static report generation complete", "duration_ms", time.Since(startStaticReport).Milliseconds())
 
+	if a.outputArchive != "" {
+		a.log.Info("writing output archive", "path", a.outputArchive)
+		err = writeOutputArchive(a.output, a.outputArchive)
+		if err != nil {
+			a.log.Error(err, "failed to write output archive")
+			return err
+		}
+	}
+	err = a.chownOutput()
+	if err != nil {
+		a.log.Error(err, "failed to change the owner of the output")
+		return err
+	}
+
 	// Print results summary (only in progress mode, not in --no-progress mode)
 	progressMode.Println("\nResults:")
-	reportPath := filepath.Join(a.output, "static-report", "index.html")
-	progressMode.Printf("  Report: file://%s\n", reportPath)
+	if !a.skipStaticReport {
+		reportPath := filepath.Join(a.output, "static-report", "index.html")
+		progressMode.Printf("  Report: file://%s\n", reportPath)
+	}
 	analysisLogPath := filepath.Join(a.output, "analysis.log")
 	progressMode.Printf("  Analysis logs: %s\n", analysisLogPath)
+	if a.outputArchive != "" {
+		progressMode.Printf("  Archive: %s\n", a.outputArchive)
+	}
+	if progressMode.IsEnabled() && !a.quiet {
+		fmt.Fprintln(os.Stderr)
+		if err := writeResultsSummary(os.Stderr, rulesets); err != nil {
+			a.log.Error(err, "failed to print results summary")
+		}
+	}
 
 	a.log.Info("[TIMING] Hybrid analysis complete", "total_duration_ms", time.Since(startTotal).Milliseconds())
 	a.log.Info("hybrid analysis completed successfully")
-	return nil
+	// results are written before failing so they can be inspected
+	return checkFailOn(rulesets, a.failOn)
 }
@@ -4,13 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
 	"os"
 	"os/exec"
-	"os/signal"
 	"path"
 	"runtime"
 
@@ -19,8 +20,10 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/devfile/alizer/pkg/apis/model"
 	"github.com/devfile/alizer/pkg/apis/recognizer"
@@ -29,15 +32,29 @@ import (
 	"github.com/konveyor-ecosystem/kantra/pkg/container"
 	"github.com/konveyor-ecosystem/kantra/pkg/profile"
 	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	"github.com/konveyor/analyzer-lsp/engine"
+	"github.com/konveyor/analyzer-lsp/engine/labels"
 	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
 	"github.com/konveyor/analyzer-lsp/progress"
 	"github.com/konveyor/analyzer-lsp/provider"
 	"go.lsp.dev/uri"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"golang.org/x/exp/maps"
 )
 
+// dependency output formats
+const (
+	depOutputFlat = "flat"
+	depOutputTree = "tree"
+	depOutputBoth = "both"
+)
+
+// dependenciesOnlyMode is the --mode value for --deps-only, providers still
+// run in full mode to resolve the dependencies
+const dependenciesOnlyMode = "dependencies-only"
+
 // TODO add network and volume w/ interface
 type ProviderInit struct {
 	port  int
@@ -55,41 +72,150 @@ type analyzeCommand struct {
 	listProviders            bool
 	listLanguages            bool
 	skipStaticReport         bool
+	reportTitle              string
+	reportDescription        string
+	reportTheme              string
 	analyzeKnownLibraries    bool
+	analyzerImage            string
+	depLabelSelector         string
 	jsonOutput               bool
+	validateOutput           bool
+	jsonOnly                 bool
+	junitOutput              bool
+	csvOutput                bool
+	githubAnnotations        bool
 	overwrite                bool
 	bulk                     bool
+	resume                   bool
+	resumeSkip               bool
 	mavenSettingsFile        string
+	mavenSettingsFiles       []string
+	mavenUsername            string
+	mavenPassword            string
+	mavenServerID            string
 	sources                  []string
 	targets                  []string
 	labelSelector            string
 	input                    string
 	output                   string
 	mode                     string
+	providerModes            []string
+	providerModeOverrides    map[string]provider.AnalysisMode
+	jdtlsPath                string
+	providerBinaries         []string
+	providerBinaryOverrides  map[string]string
+	severityOverlay          string
+	severityOverrides        map[string]outputv1.Category
 	noDepRules               bool
+	depsOnly                 bool
+	dryRun                   bool
+	watch                    bool
+	depOutput                string
 	rules                    []string
+	rulesBundleKey           string
 	tempRuleDir              string
 	jaegerEndpoint           string
+	otelEndpoint             string
+	otelSampleRate           float64
 	enableDefaultRulesets    bool
+	rulesFromLabels          bool
+	loadAllRulesets          bool
+	discoveryRulesetsOnly    bool
+	defaultRulesetsDir       string
+	imagePath                string
+	strictTarget             bool
 	httpProxy                string
 	httpsProxy               string
 	noProxy                  string
+	clientCert               string
+	clientKey                string
+	caCert                   string
+	httpClient               *http.Client
 	contextLines             int
+	incidentLimit            int
+	workers                  int
 	incidentSelector         string
 	depFolders               []string
+	excludePatterns          []string
+	maxFileSize              string
+	oversizedFiles           []string
+	maxDepth                 int
+	tooDeepDirs              []string
+	extensionMap             []string
+	mappedFiles              map[string]string
+	mappedSourcesDir         string
+	reportOnly               bool
+	includePaths             []string
+	ruleIDs                  []string
+	rulesVersion             string
+	rulesetPriority          []string
+	rulesIncludeDisabled     bool
+	since                    string
+	initSubmodules           bool
+	sinceFiles               []string
+	baseline                 string
+	writeBaseline            bool
+	excludePaths             []string
 	provider                 []string
 	logLevel                 *uint32
 	cleanup                  bool
 	runLocal                 bool
 	disableMavenSearch       bool
+	jvmArgs                  []string
+	outputArchive            string
+	chown                    string
+	compressOutput           bool
+	stableIncidentIDs        bool
+	incidentLabels           []string
+	incidentLabelValues      map[string]string
+	strictRules              bool
+	ruleStats                bool
+	interactive              bool
+	redact                   bool
+	relativePaths            bool
+	redactPatterns           []string
+	redactRegexps            []*regexp.Regexp
+	effortReport             bool
+	effortToDays             float64
+	foundProviders           []string
+	bestEffortProviders      bool
+	unavailableProviders     []string
+	providerErrors           map[string]error
+	checkProviders           bool
+	waitForProviderReady     bool
+	providerReadyTimeout     time.Duration
+	ruleTimeout              time.Duration
+	ruleTimeouts             *ruleTimeouts
+	streamSocket             string
+	stream                   *incidentStream
+	partialResults           *partialResults
+	results                  []outputv1.RuleSet
+	depsFlat                 []outputv1.DepsFlatItem
+	failOn                   string
 	noProgress               bool
+	quiet                    bool
+	verboseProvider          bool
+	splitProviderLogs        bool
+	providerLogFiles         []*os.File
+	outputToStdout           bool
 	overrideProviderSettings string
+	providerSettings         string
 	profileDir               string
+	pprofCPU                 string
+	pprofMem                 string
+	flags                    *pflag.FlagSet
 	AnalyzeCommandContext
 }
 
 // analyzeCmd represents the analyze command
 func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
+	analyzeCommand, _ := newAnalyzeCommand(log)
+	return analyzeCommand
+}
+
+// newAnalyzeCommand returns the analyze command and the state its flags are
+// parsed into, Analyze reads the results from it
+func newAnalyzeCommand(log logr.Logger) (*cobra.Command, *analyzeCommand) {
 	analyzeCmd := &analyzeCommand{
 		cleanup: true,
 	}
@@ -99,16 +225,42 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 		Use:   "analyze",
 		Short: "Analyze application source code",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			analyzeCmd.flags = cmd.Flags()
+			if err := analyzeCmd.applyFlagsConfig(cmd); err != nil {
+				log.Error(err, "failed to load config file")
+				return &ValidationError{Err: err}
+			}
+			if analyzeCmd.quiet {
+				// quiet mode implies no progress reporting
+				analyzeCmd.log = quietConsoleLogger(logrusLog)
+				analyzeCmd.noProgress = true
+			}
+			if analyzeCmd.verboseProvider {
+				if analyzeCmd.quiet {
+					return &ValidationError{Err: fmt.Errorf("--verbose-provider cannot be used with --quiet")}
+				}
+				// provider logs on stdout would break the progress bar
+				analyzeCmd.noProgress = true
+			}
+			if err := analyzeCmd.setupStdoutOutput(); err != nil {
+				log.Error(err, "failed to validate flags")
+				return &ValidationError{Err: err}
+			}
+			// --quiet and --output - replace the console logger
+			log := analyzeCmd.log
 			// TODO (pgaikwad): this is nasty
 			if !cmd.Flags().Lookup("list-sources").Changed &&
 				!cmd.Flags().Lookup("list-targets").Changed &&
 				!cmd.Flags().Lookup("list-providers").Changed &&
 				!cmd.Flags().Lookup("list-languages").Changed &&
 				!cmd.Flags().Lookup("profile-dir").Changed {
-				cmd.MarkFlagRequired("input")
+				// the report is regenerated from the output of an earlier analysis
+				if !analyzeCmd.reportOnly {
+					cmd.MarkFlagRequired("input")
+				}
 				cmd.MarkFlagRequired("output")
 				if err := cmd.ValidateRequiredFlags(); err != nil {
-					return err
+					return &ValidationError{Err: err}
 				}
 			}
 			if cmd.Flags().Lookup("list-languages").Changed {
@@ -124,22 +276,42 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 			err := analyzeCmd.Validate(cmd.Context(), cmd)
 			if err != nil {
 				log.Error(err, "failed to validate flags")
+				// validation already creates temp dirs, e.g. for remote input
+				if err := analyzeCmd.CleanAnalysisResources(context.TODO()); err != nil {
+					log.Error(err, "failed to clean temporary directories")
+				}
 				return err
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// --quiet and --output - replace the console logger
+			log := analyzeCmd.log
 			if val, err := cmd.Flags().GetUint32(logLevelFlag); err == nil {
 				analyzeCmd.logLevel = &val
 			}
 			if val, err := cmd.Flags().GetBool(noCleanupFlag); err == nil {
 				analyzeCmd.cleanup = !val
 			}
-			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			ctx, stop := interruptContext(cmd.Context(), log)
 			defer stop()
+			// defer cleaning created resources here instead of PostRun
+			// if Run returns an error, PostRun does not run
+			defer func() {
+				// start other context here to cleanup in case of program interrupt
+				if err := analyzeCmd.CleanAnalysisResources(context.TODO()); err != nil {
+					log.Error(err, "failed to clean temporary directories")
+				}
+			}()
 
 			if analyzeCmd.listProviders {
-				analyzeCmd.ListAllProviders()
+				return analyzeCmd.ListAllProviders(os.Stdout)
+			}
+			if analyzeCmd.reportOnly {
+				return analyzeCmd.runReportOnly(ctx)
+			}
+			if analyzeCmd.resumeSkip {
+				log.Info("skipping input, its --bulk analysis is already complete", "input", analyzeCmd.input, "output", analyzeCmd.output)
 				return nil
 			}
 
@@ -152,11 +324,11 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 			if analyzeCmd.profileDir != "" {
 				stat, err := os.Stat(analyzeCmd.profileDir)
 				if err != nil {
-					return fmt.Errorf("failed to stat profiles directory %s: %w", analyzeCmd.profileDir, err)
+					return &ValidationError{Err: fmt.Errorf("failed to stat profiles directory %s: %w", analyzeCmd.profileDir, err)}
 				}
 
 				if !stat.IsDir() {
-					return fmt.Errorf("found profiles path %s is not a directory", analyzeCmd.profileDir)
+					return &ValidationError{Err: fmt.Errorf("found profiles path %s is not a directory", analyzeCmd.profileDir)}
 				}
 				profilePath := filepath.Join(analyzeCmd.profileDir, "profile.yaml")
 				err = analyzeCmd.applyProfileSettings(profilePath, cmd)
@@ -199,6 +371,10 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 				}
 				return nil
 			}
+			if err := analyzeCmd.promptTechnologies(ctx); err != nil {
+				log.Error(err, "failed to select sources and targets")
+				return err
+			}
 			if analyzeCmd.providersMap == nil {
 				analyzeCmd.providersMap = make(map[string]ProviderInit)
 			}
@@ -220,28 +396,26 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 				return nil
 			}
 
-			foundProviders := []string{}
-			// file input means a binary was given which only the java provider can use
-			if analyzeCmd.isFileInput {
-				foundProviders = append(foundProviders, util.JavaProvider)
-			} else {
-				foundProviders, err = analyzeCmd.setProviders(analyzeCmd.provider, languages, foundProviders)
-				if err != nil {
-					log.Error(err, "failed to set provider info")
-					return err
-				}
-				err = analyzeCmd.validateProviders(foundProviders)
-				if err != nil {
-					return err
-				}
+			foundProviders, err := analyzeCmd.detectProviders(languages)
+			if err != nil {
+				return err
+			}
+
+			if len(analyzeCmd.jvmArgs) > 0 && !slices.Contains(foundProviders, util.JavaProvider) {
+				log.Info("WARNING: --jvm-args only applies to the java provider which is not used for this input", "jvmArgs", analyzeCmd.jvmArgs)
 			}
 
 			// default to run container mode if no Java provider found
 			if len(foundProviders) > 0 && !slices.Contains(foundProviders, util.JavaProvider) {
 				log.V(1).Info("detected non-Java providers, switching to hybrid mode", "providers", foundProviders)
+				if analyzeCmd.providerSettings != "" {
+					log.Info("WARNING: --provider-settings is ignored in hybrid mode", "file", analyzeCmd.providerSettings)
+				}
 				analyzeCmd.runLocal = false
 			}
 
+			analyzeCmd.foundProviders = foundProviders
+
 			// ***** RUN CONTAINERLESS MODE *****
 			if analyzeCmd.runLocal {
 				log.V(1).Info("\n --run-local set. running analysis in containerless mode")
@@ -253,7 +427,7 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 					}
 					return nil
 				}
-				cmdCtx, cancelFunc := context.WithCancel(cmd.Context())
+				cmdCtx, cancelFunc := context.WithCancel(ctx)
 				err := analyzeCmd.RunAnalysisContainerless(cmdCtx)
 				defer cancelFunc()
 				if err != nil {
@@ -263,6 +437,36 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 			}
 
 			// ******* RUN HYBRID MODE ******
+			if analyzeCmd.depsOnly {
+				return &ValidationError{Err: fmt.Errorf("--deps-only is only supported in containerless mode for Java applications")}
+			}
+			if analyzeCmd.dryRun {
+				return &ValidationError{Err: fmt.Errorf("--dry-run is only supported in containerless mode for Java applications")}
+			}
+			if analyzeCmd.watch {
+				return &ValidationError{Err: fmt.Errorf("--watch is only supported in containerless mode for Java applications")}
+			}
+			if analyzeCmd.compressOutput {
+				return &ValidationError{Err: fmt.Errorf("--compress-output is only supported in containerless mode for Java applications")}
+			}
+			if analyzeCmd.checkProviders {
+				return &ValidationError{Err: fmt.Errorf("--check-providers is only supported in containerless mode for Java applications")}
+			}
+			if analyzeCmd.waitForProviderReady {
+				return &ValidationError{Err: fmt.Errorf("--wait-for-provider-ready is only supported in containerless mode for Java applications")}
+			}
+			if len(analyzeCmd.extensionMap) > 0 {
+				return &ValidationError{Err: fmt.Errorf("--extension-map is only supported in containerless mode for Java applications")}
+			}
+			if analyzeCmd.streamSocket != "" {
+				return &ValidationError{Err: fmt.Errorf("--stream-socket is only supported in containerless mode for Java applications")}
+			}
+			if analyzeCmd.jdtlsPath != "" || len(analyzeCmd.providerBinaries) > 0 {
+				return &ValidationError{Err: fmt.Errorf("--jdtls-path and --provider-binary are only supported in containerless mode for Java applications")}
+			}
+			if analyzeCmd.pprofCPU != "" || analyzeCmd.pprofMem != "" {
+				return &ValidationError{Err: fmt.Errorf("--pprof-cpu and --pprof-mem are only supported in containerless mode for Java applications")}
+			}
 			if analyzeCmd.noProgress {
 				log.Info("--run-local set to false. Running analysis in hybrid mode")
 			}
@@ -270,7 +474,7 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 			// default rulesets are only java rules
 			// may want to change this in the future
 			if len(foundProviders) > 0 && len(analyzeCmd.rules) == 0 && !slices.Contains(foundProviders, util.JavaProvider) {
-				return fmt.Errorf("no providers found with default rules. Use --rules option")
+				return &ValidationError{Err: fmt.Errorf("no providers found with default rules. Use --rules option")}
 			}
 
 			// alizer does not detect certain files such as xml
@@ -293,14 +497,6 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 				log.Error(err, "failed to set provider init info")
 				return err
 			}
-			// defer cleaning created resources here instead of PostRun
-			// if Run returns an error, PostRun does not run
-			defer func() {
-				// start other context here to cleanup in case of program interrupt
-				if err := analyzeCmd.CleanAnalysisResources(context.TODO()); err != nil {
-					log.Error(err, "failed to clean temporary directories")
-				}
-			}()
 			// Run hybrid mode analysis (analyzer in-process, providers in containers)
 			cmdCtx, cancelFunc := context.WithCancel(ctx)
 			err = analyzeCmd.RunAnalysisHybridInProcess(cmdCtx)
@@ -317,43 +513,207 @@ func NewAnalyzeCmd(log logr.Logger) *cobra.Command {
 	}
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.listSources, "list-sources", false, "list rules for available migration sources")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.listTargets, "list-targets", false, "list rules for available migration targets")
-	analyzeCommand.Flags().BoolVar(&analyzeCmd.listProviders, "list-providers", false, "list available supported providers")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.listProviders, "list-providers", false, "list the supported providers, whether their containerless binaries are installed and the analysis mode they run in")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.listLanguages, "list-languages", false, "list found application language(s)")
 	analyzeCommand.Flags().StringArrayVarP(&analyzeCmd.sources, "source", "s", []string{}, "source technology to consider for analysis. Use multiple times for additional sources: --source <source1> --source <source2> ...")
 	analyzeCommand.Flags().StringArrayVarP(&analyzeCmd.targets, "target", "t", []string{}, "target technology to consider for analysis. Use multiple times for additional targets: --target <target1> --target <target2> ...")
-	analyzeCommand.Flags().StringVarP(&analyzeCmd.labelSelector, "label-selector", "l", "", "run rules based on specified label selector expression")
-	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.rules, "rules", []string{}, "filename or directory containing rule files. Use multiple times for additional rules: --rules <rule1> --rules <rule2> ...")
-	analyzeCommand.Flags().StringVarP(&analyzeCmd.input, "input", "i", "", "path to application source code or a binary")
-	analyzeCommand.Flags().StringVarP(&analyzeCmd.output, "output", "o", "", "path to the directory for analysis output")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.interactive, "interactive", false, "pick the sources and targets from a menu when neither is given and stdin is a terminal")
+	analyzeCommand.Flags().StringVarP(&analyzeCmd.labelSelector, "label-selector", "l", "", "run rules based on specified label selector expression, cannot be used with --source or --target")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.rules, "rules", []string{}, "filename or directory containing rule files, a .zip or .jar bundle of rule files, or '-' to read rules from stdin. Use multiple times for additional rules: --rules <rule1> --rules <rule2> ...")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.rulesBundleKey, "rules-bundle-key", "", "PEM public key to verify the <bundle>.sig signature of every --rules bundle with, bundles without a valid signature are rejected")
+	analyzeCommand.Flags().StringVarP(&analyzeCmd.input, "input", "i", "", "path to application source code or a binary, an http(s) url to a .tar.gz or .zip source archive with an optional sha256 query parameter, an oci://<image> to analyze the filesystem of a container image, or a git::<url>[//<subpath>][@<ref>] to analyze a shallow clone of a git ref")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.imagePath, "image-path", "", "only analyze the given directory of an oci:// --input image, e.g. /deployments")
+	analyzeCommand.Flags().StringVarP(&analyzeCmd.output, "output", "o", "", "path to the directory for analysis output, or '-' to write the results and dependencies as JSON to stdout without a static report and the run metadata to stderr")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.severityOverlay, "severity-overlay", "", "path to a yaml file mapping rule IDs to the category their violations are reported with, e.g. 'rules: {jakarta-00010: mandatory}'. Applied before --fail-on")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.failOn, "fail-on", failOnNone, "exit with code 5 when incidents at or above a severity are found. Must be one of 'mandatory', 'optional', 'potential' or 'none'")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.strictRules, "strict-rules", false, "fail the analysis when any rule file can not be parsed instead of running with the rules that could be loaded")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.strictTarget, "strict-target", false, "fail before running the rules when a --source or --target is not on any default ruleset or --rules rule, see --list-sources and --list-targets")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.reportOnly, "report-only", false, "skip the analysis and regenerate the static report from the output.yaml and the optional dependencies.yaml in --output, e.g. after changing the report options")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.skipStaticReport, "skip-static-report", false, "do not generate static report")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.reportTheme, "report-theme", reportThemeLight, "theme of the static report, one of 'light', 'dark' or 'auto' to follow the browser's prefers-color-scheme")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.reportTitle, "report-title", "", "heading and browser tab title of the static report (default the input directory name)")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.reportDescription, "report-description", "", "subtitle stored in the static report data as reportDescription, not rendered by the current report UI")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.analyzeKnownLibraries, "analyze-known-libraries", false, "analyze known open-source libraries")
-	analyzeCommand.Flags().StringVar(&analyzeCmd.mavenSettingsFile, "maven-settings", "", "path to a custom maven settings file to use")
-	analyzeCommand.Flags().StringVarP(&analyzeCmd.mode, "mode", "m", string(provider.FullAnalysisMode), "analysis mode. Must be one of 'full' (source + dependencies) or 'source-only'")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.depLabelSelector, "dep-label-selector", "", "label selector expression of the dependencies to analyze, the other dependencies and their incidents are left out of the output, combined with the open-source filter unless --analyze-known-libraries is set")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.mavenSettingsFiles, "maven-settings", []string{}, "path to a custom maven settings file to use, repeat to merge several files where later files override mirrors, servers, proxies and profiles of earlier ones by id")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.mavenUsername, "maven-username", "", "username for a private maven repository, added to the maven settings (env MAVEN_USERNAME)")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.mavenPassword, "maven-password", "", "password for a private maven repository, added to the maven settings (env MAVEN_PASSWORD)")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.mavenServerID, "maven-server-id", "", "id of the private maven repository the credentials are for (env MAVEN_SERVER_ID)")
+	analyzeCommand.Flags().StringVarP(&analyzeCmd.mode, "mode", "m", string(provider.FullAnalysisMode), "analysis mode. Must be one of 'full' (source + dependencies), 'source-only' or 'dependencies-only' (same as --deps-only)")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.jdtlsPath, "jdtls-path", "", "path to a jdtls binary to use instead of the one in the kantra dir, containerless mode only")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.providerBinaries, "provider-binary", []string{}, "override a provider binary as name=path, name is one of bundle, jdtls, generic-external-provider, gopls, golang-dependency-provider or pylsp. Use multiple times for additional binaries")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.providerModes, "provider-mode", []string{}, "override analysis mode for a provider as provider=mode. Use multiple times for additional providers: --provider-mode java=full --provider-mode builtin=source-only")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.noDepRules, "no-dependency-rules", false, "disable dependency analysis rules")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.dryRun, "dry-run", false, "print the resolved provider configs, rules, label selector and output paths without running analysis (containerless mode only)")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.watch, "watch", false, "keep the providers running and re-run the rules every time a file under --rules changes, printing the results until interrupted (containerless mode only)")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.depsOnly, "deps-only", false, "only run dependency analysis without rules, writing dependencies.yaml (containerless mode only)")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.depOutput, "dep-output", depOutputFlat, "dependency output format. Must be one of 'flat', 'tree' (dependencies-tree.yaml) or 'both'")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.jsonOutput, "json-output", false, "create analysis and dependency output as json")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.validateOutput, "validate-output", false, "validate output.json against the output JSON schema and fail the analysis if it does not conform, implies --json-output")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.jsonOnly, "json-only", false, "create analysis and dependency output only as json, skipping yaml output and the static report")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.junitOutput, "junit-output", false, "create a junit.xml report with mandatory violations as failures")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.githubAnnotations, "github-annotations", false, "print every incident as a GitHub Actions annotation to stdout, mandatory incidents as errors and others as warnings")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.csvOutput, "csv-output", false, "create an incidents.csv with one row per incident alongside the yaml output")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.chown, "chown", "", "uid:gid to recursively change the owner of the output dir and archive to after analysis, e.g. $(id -u):$(id -g) when running as root. Ignored on Windows")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.outputArchive, "output-archive", "", "path to a .zip file to bundle all generated output into after analysis")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.analyzerImage, "analyzer-image", "", "image of the analyzer containers, e.g. a mirror in an internal registry (default the upstream kantra image)")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.compressOutput, "compress-output", false, "write output.yaml and output.json gzipped as output.yaml.gz and output.json.gz (containerless mode only)")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.incidentLabels, "incident-label", []string{}, "key=value label stored in the incidentLabels variable of every incident, e.g. --incident-label run-id=42. Keys in the konveyor.io domain are reserved. Use multiple times for additional labels")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.stableIncidentIDs, "stable-incident-ids", false, "store an id computed from the code around each incident in its variables, used by --baseline and diff so moved code is matched")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.overwrite, "overwrite", false, "overwrite output directory")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.bulk, "bulk", false, "running multiple analyze commands in bulk will result to combined static report")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.resume, "resume", false, "with --bulk, skip the input when the output dir already has its complete analysis and remove the results of an unfinished one, so a failed batch can be run again. Refused while analysis.log of a running or failed --bulk analysis exists")
 	analyzeCommand.Flags().StringVar(&analyzeCmd.jaegerEndpoint, "jaeger-endpoint", "", "jaeger endpoint to collect traces")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.otelEndpoint, "otel-endpoint", "", "OTLP/HTTP collector endpoint to export traces to, e.g. http://localhost:4318")
+	analyzeCommand.Flags().Float64Var(&analyzeCmd.otelSampleRate, "otel-sample-rate", 1.0, "fraction of traces to export to the otel endpoint, between 0 and 1")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.enableDefaultRulesets, "enable-default-rulesets", true, "run default rulesets with analysis")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.rulesFromLabels, "rules-from-labels", false, "only load the default rulesets that can match the --label-selector labels, reducing parse time and memory. Always done for --source and --target")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.loadAllRulesets, "load-all-rulesets", false, "load every default ruleset instead of only those that can match the --source, --target or --label-selector labels")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.discoveryRulesetsOnly, "discovery-rulesets-only", false, "only load the discovery rules of the default rulesets, which label the technologies of the input, next to the --rules")
 	analyzeCommand.Flags().StringVar(&analyzeCmd.httpProxy, "http-proxy", util.LoadEnvInsensitive("http_proxy"), "HTTP proxy string URL")
 	analyzeCommand.Flags().StringVar(&analyzeCmd.httpsProxy, "https-proxy", util.LoadEnvInsensitive("https_proxy"), "HTTPS proxy string URL")
 	analyzeCommand.Flags().StringVar(&analyzeCmd.noProxy, "no-proxy", util.LoadEnvInsensitive("no_proxy"), "proxy excluded URLs (relevant only with proxy)")
-	analyzeCommand.Flags().IntVar(&analyzeCmd.contextLines, "context-lines", 100, "number of lines of source code to include in the output for each incident")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.clientCert, "client-cert", "", "path to a PEM client certificate for mutual TLS when downloading remote input, requires --client-key")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.clientKey, "client-key", "", "path to the PEM private key of --client-cert")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.caCert, "ca-cert", "", "path to a PEM CA certificate to trust in addition to the system trust store when downloading remote input")
+	analyzeCommand.Flags().IntVar(&analyzeCmd.contextLines, "context-lines", 100, fmt.Sprintf("number of lines of source code to include in the output for each incident, at most %d", maxContextLines))
+	analyzeCommand.Flags().IntVar(&analyzeCmd.workers, "workers", defaultWorkers, "number of rules evaluated in parallel, 0 uses the number of CPUs. Each worker holds its rule's incidents in memory, lower it on memory constrained runners")
+	analyzeCommand.Flags().IntVar(&analyzeCmd.incidentLimit, "incident-limit", 0, "maximum number of incidents to keep for each rule, zero or less means unlimited")
 	analyzeCommand.Flags().StringVar(&analyzeCmd.incidentSelector, "incident-selector", "", "an expression to select incidents based on custom variables. ex: (!package=io.konveyor.demo.config-utils)")
 	analyzeCommand.Flags().StringArrayVarP(&analyzeCmd.depFolders, "dependency-folders", "d", []string{}, "directory for dependencies")
-	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.provider, "provider", []string{}, "specify which provider(s) to run")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.effortReport, "effort-report", false, "write effort.json to the output dir with the story points of the incidents by category and ruleset, and log the estimate in person-days")
+	analyzeCommand.Flags().Float64Var(&analyzeCmd.effortToDays, "effort-to-days", 1, "person-days per story point used for the --effort-report estimate")
+	analyzeCommand.Flags().IntVar(&analyzeCmd.maxDepth, "max-depth", -1, "only analyze files up to N directories below the input, 0 analyzes only the files of the input directory and -1 has no limit")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.maxFileSize, "max-file-size", "", "skip input files larger than the size in bytes, or with a KB, MB or GB unit, e.g. 10MB. Every skipped file is logged, unlimited by default")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.excludePatterns, "exclude", []string{}, "glob pattern relative to input of paths to exclude from analysis, supports '**'. Use multiple times for additional patterns: --exclude vendor --exclude '**/node_modules'")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.extensionMap, "extension-map", []string{}, "analyze files with an extension as files of a language, as .ext=language, e.g. --extension-map .tmpl=java. Only java is supported (containerless mode only). Use multiple times for additional extensions")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.relativePaths, "relative-paths", false, "write incident file URIs as paths relative to the input, incidents outside the input keep their absolute URI")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.redact, "redact", false, "replace AWS keys, bearer tokens and private key headers in incident messages, code snippets and variables with ***REDACTED*** in every output, including --stream-socket")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.redactPatterns, "redact-pattern", []string{}, "additional regular expression to redact with --redact. Use multiple times for additional patterns")
+	analyzeCommand.Flags().DurationVar(&analyzeCmd.ruleTimeout, "rule-timeout", 0, "cancel the evaluation of a rule after the given duration, e.g. 5m, and report it as a rule error so a slow rule doesn't stall the analysis, 0 disables the timeout")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.streamSocket, "stream-socket", "", "listen on the given Unix socket and write every violation as a JSON line to the connected consumers as rules match, e.g. for IDE integrations (containerless mode only)")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.ruleStats, "rule-stats", false, "write rule-stats.yaml to the output dir listing every loaded rule, whether it matched or timed out and its number of incidents before filtering")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.ruleIDs, "rule-id", []string{}, "only evaluate rules whose ID matches the glob pattern, e.g. my-ruleset-*. Combines with the label selector. Use multiple times for additional patterns")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.rulesVersion, "rules-version", "", "only evaluate rules of rulesets labeled konveyor.io/rules-version=<version>, rulesets without the label are skipped. Combines with the label selector")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.rulesetPriority, "ruleset-priority", []string{}, "ruleset name in precedence order, when rulesets report incidents at the same file and line only the highest priority ruleset's are kept. Unlisted rulesets come last sorted by name. Use multiple times for additional rulesets")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.rulesIncludeDisabled, "rules-include-disabled", false, "also evaluate rules labeled konveyor.io/include=never, results then include disabled and experimental rules")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.includePaths, "include-path", []string{}, "glob pattern relative to input of paths to report incidents for, supports '**'. Rules still run on all files. Use multiple times for additional patterns")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.excludePaths, "exclude-path", []string{}, "glob pattern relative to input of paths to not report incidents for, supports '**'. Rules still run on all files. Use multiple times for additional patterns")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.initSubmodules, "init-submodules", false, "run 'git submodule update --init --recursive' in the input before analysis when it is a git working tree, may fetch from the submodule remotes")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.since, "since", "", "only analyze files changed since the given git ref, e.g. origin/main, when input is a git working tree")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.baseline, "baseline", "", "yaml file of accepted incidents to leave out of the output and --fail-on, create it with --write-baseline")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.writeBaseline, "write-baseline", false, "write the incidents found to the --baseline file instead of suppressing them")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.bestEffortProviders, "best-effort-providers", false, "continue the analysis with the remaining providers when a provider other than builtin fails to start, the unavailable providers are listed in metadata.json (containerless mode only)")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.checkProviders, "check-providers", false, "start every provider, print whether each one started and exit without running rules (containerless mode only)")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.waitForProviderReady, "wait-for-provider-ready", false, "after the java provider starts, query it until its results are consistent and non-empty so rules don't run before jdtls built its indexes (containerless mode only)")
+	analyzeCommand.Flags().DurationVar(&analyzeCmd.providerReadyTimeout, "provider-ready-timeout", 2*time.Minute, "how long --wait-for-provider-ready waits for the java provider before the analysis goes on")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.provider, "provider", []string{}, "specify which provider(s) to run instead of detecting them from the input languages. Use multiple times for additional providers")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.runLocal, "run-local", true, "run Java analysis in containerless mode")
 	analyzeCommand.Flags().BoolVar(&analyzeCmd.disableMavenSearch, "disable-maven-search", false, "disable maven search for dependencies")
-	analyzeCommand.Flags().BoolVar(&analyzeCmd.noProgress, "no-progress", false, "disable progress reporting (useful for scripting)")
+	analyzeCommand.Flags().StringArrayVar(&analyzeCmd.jvmArgs, "jvm-args", []string{}, "extra JVM option for the java language server, e.g. --jvm-args=-Xmx6g. Without -Xmx or JVM_MAX_MEM the JVM default max heap (1/4 of physical memory) is used")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.noProgress, "no-progress", false, "disable progress reporting and the results summary (useful for scripting)")
+	analyzeCommand.Flags().BoolVarP(&analyzeCmd.quiet, "quiet", "q", false, "only log errors, to stderr, and skip the results summary, full details are still written to analysis.log")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.splitProviderLogs, "split-provider-logs", false, "also write the logs of each provider to <provider>.log in the output dir, analysis.log keeps the logs of all providers")
+	analyzeCommand.Flags().BoolVar(&analyzeCmd.verboseProvider, "verbose-provider", false, "also write the analyzer and provider logs of analysis.log to stdout, use with --log-level to control the detail. Disables the progress bar")
 	analyzeCommand.Flags().StringVar(&analyzeCmd.overrideProviderSettings, "override-provider-settings", "", "override provider settings with custom provider config file")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.providerSettings, "provider-settings", "", "path to a provider settings.json to use instead of building provider configs from flags, containerless mode only")
 	analyzeCommand.Flags().StringVar(&analyzeCmd.profileDir, "profile-dir", "", "path to a directory containing analysis profiles")
-	return analyzeCommand
+	analyzeCommand.Flags().StringVar(&analyzeCmd.pprofCPU, "pprof-cpu", "", "write a CPU profile of the analysis to the file, for performance reports (containerless mode only)")
+	analyzeCommand.Flags().StringVar(&analyzeCmd.pprofMem, "pprof-mem", "", "write a heap profile to the file at the end of the analysis, for performance reports (containerless mode only)")
+	analyzeCommand.Flags().MarkHidden("pprof-cpu")
+	analyzeCommand.Flags().MarkHidden("pprof-mem")
+	analyzeCmd.registerFlagCompletions(analyzeCommand)
+	return analyzeCommand, analyzeCmd
 }
 
+// maxContextLines caps --context-lines, every incident carries this many
+// lines of source so larger values bloat the output and static report
+const maxContextLines = 1000
+
+func (a *analyzeCommand) validateContextLines() error {
+	if a.contextLines < 0 {
+		return fmt.Errorf("context-lines must be zero or greater, got %d", a.contextLines)
+	}
+	if a.contextLines > maxContextLines {
+		a.log.Info("WARNING: capping context-lines", "requested", a.contextLines, "max", maxContextLines)
+		a.contextLines = maxContextLines
+	}
+	return nil
+}
+
+// defaultWorkers is the number of rules the engine evaluates in parallel
+const defaultWorkers = 10
+
+func (a *analyzeCommand) validateWorkers() error {
+	if a.workers < 0 {
+		return fmt.Errorf("workers must be at least 1, or 0 to use the number of CPUs, got %d", a.workers)
+	}
+	if a.workers == 0 {
+		a.workers = runtime.NumCPU()
+		a.log.V(1).Info("sizing rule engine workers to the number of CPUs", "workers", a.workers)
+	}
+	return nil
+}
+
+// expandPathEnv expands $VAR and ${VAR} in the input, output and rules
+// paths, for paths passed through wrappers that don't run a shell. Unset
+// variables expand to an empty string, a literal '$' can't be escaped.
+func (a *analyzeCommand) expandPathEnv() {
+	expand := func(flag string, value string) string {
+		expanded := os.ExpandEnv(value)
+		if expanded != value {
+			a.log.V(1).Info("expanded environment variables in path", "flag", flag, "path", expanded)
+		}
+		return expanded
+	}
+	a.input = expand("input", a.input)
+	a.output = expand("output", a.output)
+	for i := range a.rules {
+		a.rules[i] = expand("rules", a.rules[i])
+	}
+}
+
+// Validate checks the flags before the analysis, returning a ValidationError
 func (a *analyzeCommand) Validate(ctx context.Context, cmd *cobra.Command) error {
+	if err := a.validate(ctx, cmd); err != nil {
+		return &ValidationError{Err: err}
+	}
+	return nil
+}
+
+func (a *analyzeCommand) validate(ctx context.Context, cmd *cobra.Command) error {
+	a.expandPathEnv()
 	if a.listSources || a.listTargets || a.listProviders {
 		return nil
 	}
+	if err := a.validateReportTheme(); err != nil {
+		return err
+	}
+	if a.reportOnly {
+		return a.validateReportOnly()
+	}
+
+	if _, err := a.downloadClient(); err != nil {
+		return err
+	}
+	if isRemoteArchiveInput(a.input) {
+		if err := a.fetchRemoteInput(ctx); err != nil {
+			return err
+		}
+	}
+	if isGitInput(a.input) {
+		if err := a.fetchGitInput(ctx); err != nil {
+			return err
+		}
+	}
+	if err := a.validateImageInput(); err != nil {
+		return err
+	}
+	if isImageInput(a.input) {
+		if err := a.fetchImageInput(ctx); err != nil {
+			return err
+		}
+	}
 
 	if a.listLanguages {
 		stat, err := os.Stat(a.input)
@@ -369,7 +729,35 @@ func (a *analyzeCommand) Validate(ctx context.Context, cmd *cobra.Command) error
 	if a.labelSelector != "" && (len(a.sources) > 0 || len(a.targets) > 0) {
 		return fmt.Errorf("must not specify label-selector and sources or targets")
 	}
+	if a.labelSelector != "" {
+		// fail before starting providers instead of at rule evaluation
+		if _, err := labels.NewLabelSelector[*engine.RuleMeta](a.labelSelector, nil); err != nil {
+			return fmt.Errorf("invalid label selector expression %q: %w", a.labelSelector, err)
+		}
+	}
+	if a.depLabelSelector != "" {
+		if _, err := labels.NewLabelSelector[*outputv1.Dep](a.depLabelSelector, nil); err != nil {
+			return fmt.Errorf("invalid dependency label selector expression %q: %w", a.depLabelSelector, err)
+		}
+	}
+
+	if err := validateOtelFlags(a.otelEndpoint, a.otelSampleRate, a.jaegerEndpoint); err != nil {
+		return err
+	}
+
+	if err := a.validateContextLines(); err != nil {
+		return err
+	}
+	if err := a.validateWorkers(); err != nil {
+		return err
+	}
 
+	if err := a.resolveStdinRules(stdinReader(cmd)); err != nil {
+		return err
+	}
+	if err := a.resolveRulesBundles(); err != nil {
+		return err
+	}
 	for _, rulePath := range a.rules {
 		if _, err := os.Stat(rulePath); rulePath != "" && err != nil {
 			return fmt.Errorf("%w failed to stat rules at path %s", err, rulePath)
@@ -488,12 +876,38 @@ func (a *analyzeCommand) Validate(ctx context.Context, cmd *cobra.Command) error
 			}
 		}
 	}
+	if a.mode == dependenciesOnlyMode {
+		a.depsOnly = true
+		a.mode = string(provider.FullAnalysisMode)
+	}
 	if a.mode != string(provider.FullAnalysisMode) &&
 		a.mode != string(provider.SourceOnlyAnalysisMode) {
-		return fmt.Errorf("mode must be one of 'full' or 'source-only'")
+		return fmt.Errorf("mode must be one of 'full', 'source-only' or 'dependencies-only'")
 	}
-	if _, err := os.Stat(a.mavenSettingsFile); a.mavenSettingsFile != "" && err != nil {
-		return fmt.Errorf("%w failed to stat maven settings file at path %s", err, a.mavenSettingsFile)
+	for _, arg := range a.jvmArgs {
+		if !strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("invalid jvm arg %q, JVM options must start with '-'", arg)
+		}
+	}
+	if _, err := compileExcludePatterns(a.excludePatterns); err != nil {
+		return err
+	}
+	if err := a.validatePathFilters(); err != nil {
+		return err
+	}
+	if _, err := newRuleIDSelector(a.ruleIDs); err != nil {
+		return err
+	}
+	if _, err := a.rulesVersionSelectors(); err != nil {
+		return err
+	}
+	overrides, err := parseProviderModes(a.providerModes)
+	if err != nil {
+		return err
+	}
+	a.providerModeOverrides = overrides
+	if err := a.resolveMavenSettings(); err != nil {
+		return err
 	}
 	// try to get abs path, if not, continue with relative path
 	if absPath, err := filepath.Abs(a.output); err == nil {
@@ -502,12 +916,165 @@ func (a *analyzeCommand) Validate(ctx context.Context, cmd *cobra.Command) error
 	if absPath, err := filepath.Abs(a.input); err == nil {
 		a.input = absPath
 	}
-	if absPath, err := filepath.Abs(a.mavenSettingsFile); a.mavenSettingsFile != "" && err == nil {
-		a.mavenSettingsFile = absPath
+	if err := a.resolveMavenCredentials(); err != nil {
+		return err
+	}
+	if err := a.validateProviderSettings(); err != nil {
+		return err
+	}
+	if err := a.initGitSubmodules(ctx); err != nil {
+		return err
+	}
+	if err := a.resolveSince(ctx); err != nil {
+		return err
+	}
+	if err := a.findOversizedFiles(); err != nil {
+		return err
+	}
+	if err := validateMaxDepth(a.maxDepth); err != nil {
+		return err
+	}
+	if err := a.findTooDeepDirs(); err != nil {
+		return err
+	}
+	if err := a.stageMappedFiles(); err != nil {
+		return err
+	}
+	if a.loadAllRulesets && a.rulesFromLabels {
+		return fmt.Errorf("--load-all-rulesets cannot be used with --rules-from-labels")
 	}
 	if !a.enableDefaultRulesets && len(a.rules) == 0 {
 		return fmt.Errorf("must specify rules if default rulesets are not enabled")
 	}
+	if a.discoveryRulesetsOnly {
+		if !a.enableDefaultRulesets {
+			return fmt.Errorf("--discovery-rulesets-only requires the default rulesets to be enabled")
+		}
+		if a.loadAllRulesets {
+			return fmt.Errorf("--discovery-rulesets-only cannot be used with --load-all-rulesets")
+		}
+	}
+	if err := validateFailOn(a.failOn); err != nil {
+		return err
+	}
+	if err := a.validateSeverityOverlay(); err != nil {
+		return err
+	}
+	if err := a.validateBaseline(); err != nil {
+		return err
+	}
+	if err := a.validateSplitProviderLogs(); err != nil {
+		return err
+	}
+	if err := a.validateOutputArchive(); err != nil {
+		return err
+	}
+	if err := a.validateChown(); err != nil {
+		return err
+	}
+	if err := a.validateRedact(); err != nil {
+		return err
+	}
+	if err := a.validateEffortReport(); err != nil {
+		return err
+	}
+	if err := a.validateProviderBinaries(); err != nil {
+		return err
+	}
+	if err := a.validateRulesetPriority(); err != nil {
+		return err
+	}
+	if err := a.validateResume(); err != nil {
+		return err
+	}
+	if err := a.validateProviderReady(); err != nil {
+		return err
+	}
+	if err := a.validateRuleTimeout(); err != nil {
+		return err
+	}
+	if err := a.validateIncidentLabels(); err != nil {
+		return err
+	}
+	switch a.depOutput {
+	case "":
+		a.depOutput = depOutputFlat
+	case depOutputFlat, depOutputTree, depOutputBoth:
+	default:
+		return fmt.Errorf("dep-output must be one of 'flat', 'tree' or 'both'")
+	}
+	if a.depsOnly {
+		if a.mode != string(provider.FullAnalysisMode) {
+			return fmt.Errorf("--deps-only requires 'full' analysis mode")
+		}
+		if a.bulk {
+			return fmt.Errorf("--deps-only cannot be used with --bulk")
+		}
+	}
+	if a.watch {
+		if len(a.rules) == 0 {
+			return fmt.Errorf("--watch requires --rules to watch")
+		}
+		if a.depsOnly || a.bulk || a.dryRun {
+			return fmt.Errorf("--watch cannot be used with --deps-only, --dry-run or --bulk")
+		}
+	}
+	if a.analyzerImage != "" {
+		if err := validateImageReference(a.analyzerImage); err != nil {
+			return fmt.Errorf("--analyzer-image: %w", err)
+		}
+	}
+	if a.compressOutput && a.bulk {
+		return fmt.Errorf("--compress-output cannot be used with --bulk")
+	}
+	if a.jsonOnly {
+		if a.bulk {
+			return fmt.Errorf("--json-only cannot be used with --bulk")
+		}
+		// json only output implies json output without a static report
+		a.jsonOutput = true
+		a.skipStaticReport = true
+	}
+	if a.validateOutput {
+		// the schema validates output.json
+		a.jsonOutput = true
+	}
+	return nil
+}
+
+// validateMavenSettingsFile checks that the given maven settings file exists,
+// is a regular file and contains well-formed XML so that a bad path or typo is
+// reported before any provider is started.
+func validateMavenSettingsFile(settingsPath string) error {
+	stat, err := os.Stat(settingsPath)
+	if err != nil {
+		return fmt.Errorf("%w failed to stat maven settings file at path %s", err, settingsPath)
+	}
+	if stat.IsDir() {
+		return fmt.Errorf("maven settings file at path %s is a directory", settingsPath)
+	}
+	f, err := os.Open(settingsPath)
+	if err != nil {
+		return fmt.Errorf("%w failed to open maven settings file at path %s", err, settingsPath)
+	}
+	defer f.Close()
+	decoder := xml.NewDecoder(f)
+	foundElement := false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("maven settings file at path %s is not well-formed XML: %w", settingsPath, err)
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			foundElement = true
+		}
+	}
+	if !foundElement {
+		return fmt.Errorf("maven settings file at path %s does not contain any XML elements", settingsPath)
+	}
 	return nil
 }
 
@@ -520,6 +1087,14 @@ func (a *analyzeCommand) CheckOverwriteOutput() error {
 		}
 	}
 	if a.bulk {
+		if a.resume {
+			if err := a.checkResume(); err != nil {
+				return err
+			}
+			if a.resumeSkip {
+				return nil
+			}
+		}
 		lockStat, _ := os.Stat(filepath.Join(a.output, "analysis.log"))
 		if lockStat != nil {
 			return fmt.Errorf("output dir %v already contains 'analysis.log', it was used for single application analysis or there is running --bulk analysis, try another output dir", a.output)
@@ -542,6 +1117,45 @@ func (a *analyzeCommand) CheckOverwriteOutput() error {
 	return nil
 }
 
+// detectProviders returns the providers to run for the input, from --provider
+// or the detected languages, falling back to build and source files
+func (a *analyzeCommand) detectProviders(languages []model.Language) ([]string, error) {
+	foundProviders := []string{}
+	// file input means a binary was given which only the java provider can use
+	if a.isFileInput {
+		for _, prov := range a.provider {
+			if prov != util.JavaProvider {
+				return nil, fmt.Errorf("binary input %s can only be analyzed by the %s provider, not %s", a.input, util.JavaProvider, prov)
+			}
+		}
+		return append(foundProviders, util.JavaProvider), nil
+	}
+	foundProviders, err := a.setProviders(a.provider, languages, foundProviders)
+	if err != nil {
+		a.log.Error(err, "failed to set provider info")
+		return nil, err
+	}
+	// alizer only recognizes languages with project files,
+	// fall back to build files and source file extensions
+	if len(foundProviders) == 0 {
+		foundProviders, err = a.detectProvidersFallback()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(foundProviders) == 0 {
+		// without rules for the input there is nothing to find
+		if len(a.rules) == 0 {
+			return nil, noLanguageDetectedError(a.input)
+		}
+		a.log.Info(noLanguageDetectedError(a.input).Error())
+	}
+	if err := a.validateProviders(foundProviders); err != nil {
+		return nil, err
+	}
+	return foundProviders, nil
+}
+
 func (a *analyzeCommand) validateProviders(providers []string) error {
 	validProvs := []string{
 		util.JavaProvider,
@@ -560,6 +1174,99 @@ func (a *analyzeCommand) validateProviders(providers []string) error {
 	return nil
 }
 
+// parseProviderModes parses --provider-mode values of the form provider=mode
+// into a map of provider name to analysis mode.
+func parseProviderModes(values []string) (map[string]provider.AnalysisMode, error) {
+	knownProviders := []string{
+		"builtin",
+		util.JavaProvider,
+		util.PythonProvider,
+		util.GoProvider,
+		util.NodeJSProvider,
+		util.DotnetProvider,
+		util.DotnetFrameworkProvider,
+	}
+	overrides := map[string]provider.AnalysisMode{}
+	for _, value := range values {
+		name, mode, found := strings.Cut(value, "=")
+		if !found || name == "" || mode == "" {
+			return nil, fmt.Errorf("invalid provider mode %q, must be of the form provider=mode", value)
+		}
+		if !slices.Contains(knownProviders, name) {
+			return nil, fmt.Errorf("unknown provider %q in provider mode %q", name, value)
+		}
+		if mode != string(provider.FullAnalysisMode) &&
+			mode != string(provider.SourceOnlyAnalysisMode) {
+			return nil, fmt.Errorf("mode for provider %s must be one of 'full' or 'source-only'", name)
+		}
+		overrides[name] = provider.AnalysisMode(mode)
+	}
+	return overrides, nil
+}
+
+// modeForProvider returns the analysis mode for the named provider,
+// honoring --provider-mode overrides over the global --mode.
+func (a *analyzeCommand) modeForProvider(name string) provider.AnalysisMode {
+	if mode, ok := a.providerModeOverrides[name]; ok {
+		return mode
+	}
+	return provider.AnalysisMode(a.mode)
+}
+
+// splitJvmArgs returns the max heap size given with -Xmx, which is passed
+// to the java provider as jvmMaxMem, and the remaining JVM options
+func splitJvmArgs(jvmArgs []string) (string, []string) {
+	maxMem := ""
+	others := []string{}
+	for _, arg := range jvmArgs {
+		if strings.HasPrefix(arg, "-Xmx") {
+			maxMem = strings.TrimPrefix(arg, "-Xmx")
+			continue
+		}
+		others = append(others, arg)
+	}
+	return maxMem, others
+}
+
+// javaToolOptions returns the value for JDK_JAVA_OPTIONS used to pass extra
+// JVM options to the java language server launch
+func (a *analyzeCommand) javaToolOptions() string {
+	_, others := splitJvmArgs(a.jvmArgs)
+	if len(others) == 0 {
+		return ""
+	}
+	options := strings.Join(others, " ")
+	if existing := os.Getenv("JDK_JAVA_OPTIONS"); existing != "" {
+		options = fmt.Sprintf("%s %s", existing, options)
+	}
+	return options
+}
+
+// withJavaToolOptions runs start with JDK_JAVA_OPTIONS set to the extra
+// --jvm-args options and restores the previous value afterwards. The java
+// provider runs in process and starts jdtls with the kantra environment,
+// its config only takes the max heap, so the options are set while jdtls
+// starts instead of for the whole analysis.
+func (a *analyzeCommand) withJavaToolOptions(log logr.Logger, start func() error) error {
+	options := a.javaToolOptions()
+	if options == "" {
+		return start()
+	}
+	previous, wasSet := os.LookupEnv("JDK_JAVA_OPTIONS")
+	log.Info("setting extra JVM options for java provider", "options", options)
+	if err := os.Setenv("JDK_JAVA_OPTIONS", options); err != nil {
+		return fmt.Errorf("failed to set JVM options: %w", err)
+	}
+	defer func() {
+		if wasSet {
+			os.Setenv("JDK_JAVA_OPTIONS", previous)
+		} else {
+			os.Unsetenv("JDK_JAVA_OPTIONS")
+		}
+	}()
+	return start()
+}
+
 func (a *analyzeCommand) validateRulesPath(rulePath string) error {
 	stat, err := os.Stat(rulePath)
 	if err != nil {
@@ -599,27 +1306,6 @@ func (a *analyzeCommand) needDefaultRules() {
 	}
 }
 
-func (a *analyzeCommand) ListAllProviders() {
-	supportedProvsContainer := []string{
-		"java",
-		"python",
-		"go",
-		"dotnet",
-		"nodejs",
-	}
-	supportedProvsContainerless := []string{
-		"java",
-	}
-	fmt.Println("container analysis supported providers:")
-	for _, prov := range supportedProvsContainer {
-		fmt.Fprintln(os.Stdout, prov)
-	}
-	fmt.Println("containerless analysis supported providers (default):")
-	for _, prov := range supportedProvsContainerless {
-		fmt.Fprintln(os.Stdout, prov)
-	}
-}
-
 func (a *analyzeCommand) ListLabels(ctx context.Context) error {
 	return a.fetchLabels(ctx, a.listSources, a.listTargets, os.Stdout)
 }
@@ -670,7 +1356,7 @@ func (a *analyzeCommand) fetchLabels(ctx context.Context, listSources, listTarge
 		}
 		err = container.NewContainer().Run(
 			ctx,
-			container.WithImage(Settings.RunnerImage),
+			container.WithImage(a.runnerImage()),
 			container.WithLog(a.log.V(1)),
 			container.WithEnv(runMode, runModeContainer),
 			container.WithEnv(rulePath, customRulePath),
@@ -767,6 +1453,7 @@ func (a *analyzeCommand) getConfigVolumes() (map[string]string, error) {
 		}
 		for provName, provInfo := range a.providersMap {
 			configInput.Port = a.providersMap[provName].port
+			configInput.Mode = string(a.modeForProvider(provName))
 			var volConfig, err = provInfo.provider.GetConfigVolume(configInput)
 			if err != nil {
 				a.log.V(1).Error(err, "failed creating volume configs")
@@ -776,14 +1463,9 @@ func (a *analyzeCommand) getConfigVolumes() (map[string]string, error) {
 		}
 
 		// Set proxy to providers
-		if a.httpProxy != "" || a.httpsProxy != "" {
-			proxy := provider.Proxy{
-				HTTPProxy:  a.httpProxy,
-				HTTPSProxy: a.httpsProxy,
-				NoProxy:    a.noProxy,
-			}
+		if proxy := a.providerProxy(); proxy != nil {
 			for i := range provConfig {
-				provConfig[i].Proxy = &proxy
+				provConfig[i].Proxy = proxy
 			}
 		}
 
@@ -838,6 +1520,10 @@ func (a *analyzeCommand) getRulesVolumes() (map[string]string, error) {
 	a.log.V(1).Info("created directory for rules", "dir", tempDir)
 	a.tempDirs = append(a.tempDirs, tempDir)
 	for i, r := range a.rules {
+		r, err := a.resolveRuleIncludes(r)
+		if err != nil {
+			return nil, err
+		}
 		stat, err := os.Stat(r)
 		if err != nil {
 			a.log.V(1).Error(err, "failed to stat rules", "path", r)
@@ -926,7 +1612,7 @@ func (a *analyzeCommand) extractDefaultRulesets(ctx context.Context, containerLo
 		// Create temp container to extract rulesets
 		tempName := fmt.Sprintf("ruleset-extract-%v", container.RandomName())
 		createCmd := exec.CommandContext(ctx, Settings.ContainerBinary,
-			"create", "--name", tempName, Settings.RunnerImage)
+			"create", "--name", tempName, a.runnerImage())
 		// Send container output to log file instead of console
 		createCmd.Stdout = containerLogWriter
 		createCmd.Stderr = containerLogWriter
@@ -1023,9 +1709,7 @@ func (a *analyzeCommand) RunProvidersHostNetwork(ctx context.Context, volName st
 		portMapping := fmt.Sprintf("%d:%d", init.port, init.port)
 
 		a.log.Info("starting provider with port publishing", "provider", prov, "port", init.port)
-		con := container.NewContainer()
-		err := con.Run(
-			ctx,
+		runOptions := []container.Option{
 			container.WithImage(init.image),
 			container.WithLog(a.log.V(1)),
 			container.WithVolumes(volumes),
@@ -1038,7 +1722,13 @@ func (a *analyzeCommand) RunProvidersHostNetwork(ctx context.Context, volName st
 			container.WithProxy(a.httpProxy, a.httpsProxy, a.noProxy),
 			container.WithStdout(containerLogWriter),
 			container.WithStderr(containerLogWriter),
-		)
+		}
+		// extra JVM options reach the jdtls launch through the environment
+		if options := a.javaToolOptions(); prov == util.JavaProvider && options != "" {
+			runOptions = append(runOptions, container.WithEnv("JDK_JAVA_OPTIONS", options))
+		}
+		con := container.NewContainer()
+		err := con.Run(ctx, runOptions...)
 		if err != nil {
 			return fmt.Errorf("failed to start provider %s: %w", prov, err)
 		}
@@ -1081,31 +1771,16 @@ func (a *analyzeCommand) RunProvidersHostNetwork(ctx context.Context, volName st
 // Returns:
 //   - error: Any error encountered during analysis, with context about which step failed
 
-func (a *analyzeCommand) CreateJSONOutput() error {
+func (a *analyzeCommand) CreateJSONOutput(rulesets []outputv1.RuleSet) error {
 	if !a.jsonOutput {
 		return nil
 	}
 	a.log.Info("writing analysis results as json output", "output", a.output)
-	outputPath := filepath.Join(a.output, "output.yaml")
 	depPath := filepath.Join(a.output, "dependencies.yaml")
 
-	data, err := os.ReadFile(outputPath)
-	if err != nil {
-		return err
-	}
-	ruleOutput := &[]outputv1.RuleSet{}
-	err = yaml.Unmarshal(data, ruleOutput)
-	if err != nil {
-		a.log.V(1).Error(err, "failed to unmarshal output yaml")
-		return err
-	}
-
-	jsonData, err := json.MarshalIndent(ruleOutput, "", "	")
-	if err != nil {
-		a.log.V(1).Error(err, "failed to marshal output file to json")
-		return err
-	}
-	err = os.WriteFile(filepath.Join(a.output, "output.json"), jsonData, os.ModePerm)
+	err := a.streamOutputFile("output.json", os.ModePerm, func(w io.Writer) error {
+		return writeRulesetsJSON(w, rulesets)
+	})
 	if err != nil {
 		a.log.V(1).Error(err, "failed to write json output", "dir", a.output, "file", "output.json")
 		return err
@@ -1115,7 +1790,7 @@ func (a *analyzeCommand) CreateJSONOutput() error {
 	_, noDepFileErr := os.Stat(filepath.Join(a.output, "dependencies.yaml"))
 	if errors.Is(noDepFileErr, os.ErrNotExist) || a.mode == string(provider.SourceOnlyAnalysisMode) {
 		a.log.Info("skipping dependency output for json output")
-		return nil
+		return a.createDependencyTreeJSONOutput()
 	}
 	depData, err := os.ReadFile(depPath)
 	if err != nil {
@@ -1139,6 +1814,37 @@ func (a *analyzeCommand) CreateJSONOutput() error {
 		return err
 	}
 
+	return a.createDependencyTreeJSONOutput()
+}
+
+// createDependencyTreeJSONOutput converts dependencies-tree.yaml to json when present
+func (a *analyzeCommand) createDependencyTreeJSONOutput() error {
+	treePath := filepath.Join(a.output, dependencyTreeOutputFile("dependencies.yaml"))
+	treeData, err := os.ReadFile(treePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	treeOutput := &[]outputv1.DepsTreeItem{}
+	err = yaml.Unmarshal(treeData, treeOutput)
+	if err != nil {
+		a.log.V(1).Error(err, "failed to unmarshal dependency tree yaml")
+		return err
+	}
+	jsonDataTree, err := json.MarshalIndent(treeOutput, "", "	")
+	if err != nil {
+		a.log.V(1).Error(err, "failed to marshal dependency tree file to json")
+		return err
+	}
+	treeJSONFile := dependencyTreeOutputFile("dependencies.json")
+	err = os.WriteFile(filepath.Join(a.output, treeJSONFile), jsonDataTree, os.ModePerm)
+	if err != nil {
+		a.log.V(1).Error(err, "failed to write json dependency tree output", "dir", a.output, "file", treeJSONFile)
+		return err
+	}
+
 	return nil
 }
 
@@ -1164,7 +1870,7 @@ func (a *analyzeCommand) GenerateStaticReport(ctx context.Context, operationalLo
 	staticReportArgs := []string{"/usr/local/bin/js-bundle-generator",
 		fmt.Sprintf("--output-path=%s", path.Join("/usr/local/static-report/output.js"))}
 	// Prepare report args list with single input analysis
-	applicationNames := []string{filepath.Base(a.input)}
+	applicationNames := []string{a.reportAppName()}
 	outputAnalyses := []string{util.AnalysisOutputMountPath}
 	outputDeps := []string{util.DepsOutputMountPath}
 
@@ -1229,7 +1935,7 @@ func (a *analyzeCommand) GenerateStaticReport(ctx context.Context, operationalLo
 		"output", a.output, "args", strings.Join(staticReportCmd, " "))
 	err := c.Run(
 		ctx,
-		container.WithImage(Settings.RunnerImage),
+		container.WithImage(a.runnerImage()),
 		container.WithLog(a.log.V(1)),
 		container.WithEntrypointBin("/bin/sh"),
 		container.WithContainerToolBin(Settings.ContainerBinary),
@@ -1243,12 +1949,63 @@ func (a *analyzeCommand) GenerateStaticReport(ctx context.Context, operationalLo
 	if err != nil {
 		return err
 	}
+	// the report files are written by the container and may not be writable
+	if err := a.customizeStaticReport(filepath.Join(a.output, "static-report"), true); err != nil {
+		a.log.Info("WARNING: failed to apply the static report title, description and theme", "error", err.Error())
+	}
 	uri := uri.File(filepath.Join(a.output, "static-report", "index.html"))
 	operationalLog.Info("Static report created. Access it at this URL:", "URL", string(uri))
 
 	return nil
 }
 
+// reportAppName is the application name shown in the static report
+func (a *analyzeCommand) reportAppName() string {
+	if a.reportTitle != "" {
+		return a.reportTitle
+	}
+	return filepath.Base(a.input)
+}
+
+// reportInfo is the static report heading and theme, the light theme is the
+// report default and is left out
+func (a *analyzeCommand) reportInfo() staticReportInfo {
+	info := staticReportInfo{Title: a.reportTitle, Description: a.reportDescription}
+	if a.reportTheme != reportThemeLight {
+		info.Theme = a.reportTheme
+	}
+	return info
+}
+
+// customizeStaticReport applies --report-title, --report-description and
+// --report-theme to the static report in reportDir. The bundle generated in
+// the hybrid report container doesn't include them, appendInfo adds them to
+// its output.js.
+func (a *analyzeCommand) customizeStaticReport(reportDir string, appendInfo bool) error {
+	info := a.reportInfo()
+	if info == (staticReportInfo{}) {
+		return nil
+	}
+	if appendInfo {
+		file, err := os.OpenFile(filepath.Join(reportDir, "output.js"), os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("failed to open static report bundle: %w", err)
+		}
+		defer file.Close()
+		err = writeStaticReportInfo(file, info)
+		if err != nil {
+			return fmt.Errorf("failed to write static report bundle: %w", err)
+		}
+	}
+	if err := setStaticReportTitle(reportDir, a.reportTitle); err != nil {
+		return fmt.Errorf("failed to set static report title: %w", err)
+	}
+	if err := setStaticReportTheme(reportDir, info.Theme); err != nil {
+		return fmt.Errorf("failed to set static report theme: %w", err)
+	}
+	return nil
+}
+
 func (a *analyzeCommand) moveResults() error {
 	outputPath := filepath.Join(a.output, "output.yaml")
 	analysisLogFilePath := filepath.Join(a.output, "analysis.log")
@@ -1284,7 +2041,7 @@ func (a *analyzeCommand) moveResults() error {
 			return err
 		}
 	}
-	return nil
+	return a.markInputComplete()
 }
 
 func (a *analyzeCommand) inputShortName() string {
@@ -1300,7 +2057,7 @@ func (a *analyzeCommand) getLabelSelector() string {
 		return ""
 	}
 	// default labels are applied everytime either a source or target is specified
-	defaultLabels := []string{"discovery"}
+	defaultLabels := []string{discoveryLabel}
 	targets := []string{}
 	for _, target := range a.targets {
 		targets = append(targets,
@@ -1615,8 +2372,9 @@ func setupProgressReporter(ctx context.Context, noProgress bool) (
 			}
 		}()
 	} else {
-		// Use noop reporter when progress is disabled
-		reporter = progress.NewNoopReporter()
+		// Record rule execution progress so it can be logged periodically
+		// when the progress bar is disabled
+		reporter = &ruleProgressReporter{}
 	}
 
 	return reporter, progressDone, progressCancel
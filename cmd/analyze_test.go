@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -1261,3 +1262,452 @@ func Test_JavaProvider_GetConfigVolume_disableMavenSearch(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateMavenSettingsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	validPath := filepath.Join(tmpDir, "settings.xml")
+	if err := os.WriteFile(validPath, []byte(`<?xml version="1.0"?><settings><localRepository>/tmp/m2</localRepository></settings>`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	malformedPath := filepath.Join(tmpDir, "malformed.xml")
+	if err := os.WriteFile(malformedPath, []byte(`<settings><mirrors></settings>`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	emptyPath := filepath.Join(tmpDir, "empty.xml")
+	if err := os.WriteFile(emptyPath, []byte(""), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid settings file",
+			path: validPath,
+		},
+		{
+			name:    "missing settings file",
+			path:    filepath.Join(tmpDir, "missing.xml"),
+			wantErr: true,
+			errMsg:  "failed to stat maven settings file",
+		},
+		{
+			name:    "settings path is a directory",
+			path:    tmpDir,
+			wantErr: true,
+			errMsg:  "is a directory",
+		},
+		{
+			name:    "malformed xml",
+			path:    malformedPath,
+			wantErr: true,
+			errMsg:  "is not well-formed XML",
+		},
+		{
+			name:    "empty file",
+			path:    emptyPath,
+			wantErr: true,
+			errMsg:  "does not contain any XML elements",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMavenSettingsFile(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateMavenSettingsFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), tt.path) {
+				t.Errorf("validateMavenSettingsFile() error = %v, expected to name path %v", err, tt.path)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("validateMavenSettingsFile() error = %v, expected to contain %v", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func Test_parseProviderModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "no overrides",
+			values: []string{},
+			want:   map[string]string{},
+		},
+		{
+			name:   "java full and builtin source-only",
+			values: []string{"java=full", "builtin=source-only"},
+			want:   map[string]string{"java": "full", "builtin": "source-only"},
+		},
+		{
+			name:    "missing separator",
+			values:  []string{"java"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown provider",
+			values:  []string{"cobol=full"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			values:  []string{"java=partial"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProviderModes(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseProviderModes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseProviderModes() = %v, want %v", got, tt.want)
+			}
+			for name, mode := range tt.want {
+				if string(got[name]) != mode {
+					t.Errorf("parseProviderModes()[%s] = %v, want %v", name, got[name], mode)
+				}
+			}
+		})
+	}
+}
+
+func Test_analyzeCommand_modeForProvider(t *testing.T) {
+	a := &analyzeCommand{mode: "source-only"}
+	if got := a.modeForProvider("java"); got != "source-only" {
+		t.Errorf("modeForProvider() = %v, want global mode source-only", got)
+	}
+
+	overrides, err := parseProviderModes([]string{"java=full"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.providerModeOverrides = overrides
+	if got := a.modeForProvider("java"); got != "full" {
+		t.Errorf("modeForProvider(java) = %v, want full", got)
+	}
+	if got := a.modeForProvider("builtin"); got != "source-only" {
+		t.Errorf("modeForProvider(builtin) = %v, want source-only", got)
+	}
+}
+
+func Test_analyzeCommand_Validate_depsOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		bulk    bool
+		wantErr string
+	}{
+		{
+			name: "full mode is allowed",
+			mode: "full",
+		},
+		{
+			name:    "source-only mode is rejected",
+			mode:    "source-only",
+			wantErr: "--deps-only requires 'full' analysis mode",
+		},
+		{
+			name:    "bulk is rejected",
+			mode:    "full",
+			bulk:    true,
+			wantErr: "--deps-only cannot be used with --bulk",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			a := &analyzeCommand{
+				input:                 tmpDir,
+				output:                filepath.Join(tmpDir, "output"),
+				mode:                  tt.mode,
+				bulk:                  tt.bulk,
+				depsOnly:              true,
+				enableDefaultRulesets: true,
+				AnalyzeCommandContext: AnalyzeCommandContext{
+					log: logr.Discard(),
+				},
+			}
+			err := a.Validate(context.Background(), nil)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, expected to contain %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_analyzeCommand_Validate_dependenciesOnlyMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	a := &analyzeCommand{
+		input:                 tmpDir,
+		output:                filepath.Join(tmpDir, "output"),
+		mode:                  dependenciesOnlyMode,
+		enableDefaultRulesets: true,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	if err := a.Validate(context.Background(), nil); err != nil {
+		t.Fatalf("Validate() unexpected error = %v", err)
+	}
+	if !a.depsOnly {
+		t.Errorf("depsOnly = false, want true for --mode %s", dependenciesOnlyMode)
+	}
+	if a.mode != "full" {
+		t.Errorf("mode = %q, want providers to run in full mode", a.mode)
+	}
+}
+
+func TestSplitJvmArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		jvmArgs    []string
+		wantMaxMem string
+		wantOthers []string
+	}{
+		{
+			name:       "no args",
+			jvmArgs:    nil,
+			wantMaxMem: "",
+			wantOthers: []string{},
+		},
+		{
+			name:       "max heap only",
+			jvmArgs:    []string{"-Xmx6g"},
+			wantMaxMem: "6g",
+			wantOthers: []string{},
+		},
+		{
+			name:       "max heap and other options",
+			jvmArgs:    []string{"-XX:+UseG1GC", "-Xmx2g", "-Xss4m"},
+			wantMaxMem: "2g",
+			wantOthers: []string{"-XX:+UseG1GC", "-Xss4m"},
+		},
+		{
+			name:       "last max heap wins",
+			jvmArgs:    []string{"-Xmx2g", "-Xmx8g"},
+			wantMaxMem: "8g",
+			wantOthers: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxMem, others := splitJvmArgs(tt.jvmArgs)
+			if maxMem != tt.wantMaxMem {
+				t.Errorf("splitJvmArgs() maxMem = %v, want %v", maxMem, tt.wantMaxMem)
+			}
+			if !reflect.DeepEqual(others, tt.wantOthers) {
+				t.Errorf("splitJvmArgs() others = %v, want %v", others, tt.wantOthers)
+			}
+		})
+	}
+}
+
+func TestJavaToolOptions(t *testing.T) {
+	t.Setenv("JDK_JAVA_OPTIONS", "")
+	a := &analyzeCommand{jvmArgs: []string{"-Xmx6g"}}
+	if got := a.javaToolOptions(); got != "" {
+		t.Errorf("javaToolOptions() = %q, want empty", got)
+	}
+
+	a.jvmArgs = []string{"-Xmx6g", "-XX:+UseG1GC", "-Xss4m"}
+	if got := a.javaToolOptions(); got != "-XX:+UseG1GC -Xss4m" {
+		t.Errorf("javaToolOptions() = %q, want %q", got, "-XX:+UseG1GC -Xss4m")
+	}
+
+	t.Setenv("JDK_JAVA_OPTIONS", "-Dfoo=bar")
+	if got := a.javaToolOptions(); got != "-Dfoo=bar -XX:+UseG1GC -Xss4m" {
+		t.Errorf("javaToolOptions() = %q, want existing options kept", got)
+	}
+}
+
+func TestWithJavaToolOptions(t *testing.T) {
+	t.Setenv("JDK_JAVA_OPTIONS", "-Dfoo=bar")
+	a := &analyzeCommand{jvmArgs: []string{"-Xmx6g", "-Xss4m"}}
+	err := a.withJavaToolOptions(logr.Discard(), func() error {
+		if got := os.Getenv("JDK_JAVA_OPTIONS"); got != "-Dfoo=bar -Xss4m" {
+			t.Errorf("JDK_JAVA_OPTIONS = %q while starting, want %q", got, "-Dfoo=bar -Xss4m")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withJavaToolOptions() error = %v", err)
+	}
+	if got := os.Getenv("JDK_JAVA_OPTIONS"); got != "-Dfoo=bar" {
+		t.Errorf("JDK_JAVA_OPTIONS = %q after starting, want it restored", got)
+	}
+
+	os.Unsetenv("JDK_JAVA_OPTIONS")
+	a.withJavaToolOptions(logr.Discard(), func() error { return nil })
+	if _, ok := os.LookupEnv("JDK_JAVA_OPTIONS"); ok {
+		t.Error("JDK_JAVA_OPTIONS is set after starting, want it unset")
+	}
+}
+
+func TestAnalyzeCommand_Validate_labelSelector(t *testing.T) {
+	tests := []struct {
+		name          string
+		labelSelector string
+		wantErr       bool
+	}{
+		{
+			name:          "valid expression",
+			labelSelector: "(konveyor.io/target=quarkus || konveyor.io/target=jakarta-ee) && konveyor.io/include=always",
+		},
+		{
+			name:          "unbalanced parentheses",
+			labelSelector: "(konveyor.io/target=quarkus",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			a := &analyzeCommand{
+				input:                 tmpDir,
+				output:                filepath.Join(tmpDir, "output"),
+				mode:                  "full",
+				labelSelector:         tt.labelSelector,
+				enableDefaultRulesets: true,
+				AnalyzeCommandContext: AnalyzeCommandContext{
+					log: logr.Discard(),
+				},
+			}
+			err := a.Validate(context.Background(), nil)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "invalid label selector expression") {
+					t.Errorf("Validate() error = %v, expected invalid label selector error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Validate() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCommand_validateContextLines(t *testing.T) {
+	tests := []struct {
+		name         string
+		contextLines int
+		want         int
+		wantErr      bool
+	}{
+		{name: "zero", contextLines: 0, want: 0},
+		{name: "default", contextLines: 100, want: 100},
+		{name: "at max", contextLines: maxContextLines, want: maxContextLines},
+		{name: "over max is capped", contextLines: maxContextLines + 1, want: maxContextLines},
+		{name: "negative", contextLines: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzeCommand{
+				contextLines: tt.contextLines,
+				AnalyzeCommandContext: AnalyzeCommandContext{
+					log: logr.Discard(),
+				},
+			}
+			err := a.validateContextLines()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validateContextLines() expected error for %d", tt.contextLines)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("validateContextLines() unexpected error = %v", err)
+			}
+			if a.contextLines != tt.want {
+				t.Errorf("contextLines = %d, want %d", a.contextLines, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCommand_validateWorkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		want    int
+		wantErr bool
+	}{
+		{name: "default", workers: defaultWorkers, want: defaultWorkers},
+		{name: "one", workers: 1, want: 1},
+		{name: "zero uses the number of CPUs", workers: 0, want: runtime.NumCPU()},
+		{name: "negative", workers: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzeCommand{
+				workers: tt.workers,
+				AnalyzeCommandContext: AnalyzeCommandContext{
+					log: logr.Discard(),
+				},
+			}
+			err := a.validateWorkers()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validateWorkers() expected error for %d", tt.workers)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("validateWorkers() unexpected error = %v", err)
+			}
+			if a.workers != tt.want {
+				t.Errorf("workers = %d, want %d", a.workers, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCommand_expandPathEnv(t *testing.T) {
+	t.Setenv("RULES_HOME", "/opt/rules")
+	t.Setenv("APP_DIR", "/src/app")
+	a := &analyzeCommand{
+		input:  "${APP_DIR}/service",
+		output: "$APP_DIR-output",
+		rules:  []string{"$RULES_HOME/custom", "${RULES_HOME}/extra.yaml", "-", "/plain/rules"},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	a.expandPathEnv()
+
+	if a.input != "/src/app/service" {
+		t.Errorf("input = %q, want /src/app/service", a.input)
+	}
+	if a.output != "/src/app-output" {
+		t.Errorf("output = %q, want /src/app-output", a.output)
+	}
+	wantRules := []string{"/opt/rules/custom", "/opt/rules/extra.yaml", "-", "/plain/rules"}
+	if !reflect.DeepEqual(a.rules, wantRules) {
+		t.Errorf("rules = %v, want %v", a.rules, wantRules)
+	}
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// imageReference matches an image reference, [registry[:port]/]name[:tag][@digest]
+var imageReference = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?/)?[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?(?:@[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,})?$`)
+
+// validateImageReference checks that image is a valid image reference
+func validateImageReference(image string) error {
+	if len(image) > 255 || !imageReference.MatchString(image) {
+		return fmt.Errorf("invalid image reference %q", image)
+	}
+	return nil
+}
+
+// runnerImage returns the image of the containers run by analyze, the
+// --analyzer-image when set
+func (a *analyzeCommand) runnerImage() string {
+	if a.analyzerImage != "" {
+		return a.analyzerImage
+	}
+	return Settings.RunnerImage
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateImageReference(t *testing.T) {
+	tests := []struct {
+		image   string
+		wantErr bool
+	}{
+		{image: "quay.io/konveyor/kantra"},
+		{image: "quay.io/konveyor/kantra:v0.8.0"},
+		{image: "registry.internal:5000/mirror/konveyor/kantra:latest"},
+		{image: "kantra"},
+		{image: "localhost/kantra@sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+		{image: "", wantErr: true},
+		{image: "Quay.io/Konveyor/Kantra", wantErr: true},
+		{image: "quay.io/konveyor/kantra:", wantErr: true},
+		{image: "quay.io/konveyor/kantra latest", wantErr: true},
+		{image: "https://quay.io/konveyor/kantra", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := validateImageReference(tt.image)
+		if tt.wantErr {
+			assert.Error(t, err, tt.image)
+		} else {
+			assert.NoError(t, err, tt.image)
+		}
+	}
+}
+
+func TestRunnerImage(t *testing.T) {
+	a := &analyzeCommand{}
+	assert.Equal(t, Settings.RunnerImage, a.runnerImage())
+	a.analyzerImage = "registry.internal/konveyor/kantra:v0.8.0"
+	assert.Equal(t, "registry.internal/konveyor/kantra:v0.8.0", a.runnerImage())
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"gopkg.in/yaml.v2"
+)
+
+// incidentBaseline lists accepted incidents, matching incidents are left out
+// of the analysis output with --baseline
+type incidentBaseline struct {
+	Incidents []baselineIncident `yaml:"incidents"`
+}
+
+type baselineIncident struct {
+	RuleID      string `yaml:"ruleID"`
+	File        string `yaml:"file"`
+	Fingerprint string `yaml:"fingerprint"`
+}
+
+func (a *analyzeCommand) validateBaseline() error {
+	if a.writeBaseline && a.baseline == "" {
+		return fmt.Errorf("--write-baseline requires --baseline to set the file to write")
+	}
+	if a.baseline == "" {
+		return nil
+	}
+	absPath, err := filepath.Abs(a.baseline)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for baseline %s: %w", a.baseline, err)
+	}
+	a.baseline = absPath
+	if a.writeBaseline {
+		return nil
+	}
+	if _, err := loadBaseline(a.baseline); err != nil {
+		return err
+	}
+	return nil
+}
+
+func loadBaseline(path string) (map[string]bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline %s, create it with --write-baseline: %w", path, err)
+	}
+	baseline := incidentBaseline{}
+	if err := yaml.Unmarshal(content, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	fingerprints := map[string]bool{}
+	for _, incident := range baseline.Incidents {
+		fingerprints[incident.Fingerprint] = true
+	}
+	return fingerprints, nil
+}
+
+// incidentFingerprint identifies an incident by rule, file relative to the
+// input and message, leaving out the line so edits elsewhere in the file
+// don't change it
+func incidentFingerprint(ruleID string, file string, message string) string {
+	normalized := strings.Join(strings.Fields(message), " ")
+	sum := sha256.Sum256([]byte(ruleID + "\x00" + file + "\x00" + normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// incidentFile returns the incident file relative to the input, so baselines
+// can be shared between checkouts and annotations point at repository files
+func (a *analyzeCommand) incidentFile(incident outputv1.Incident) string {
+	return relativeIncidentFile(incident, a.incidentRoots())
+}
+
+// relativeIncidentFile returns the incident file relative to the first root
+// containing it, or the incident URI when none does
+func relativeIncidentFile(incident outputv1.Incident, roots []string) string {
+	if !strings.HasPrefix(string(incident.URI), "file:") {
+		return string(incident.URI)
+	}
+	incidentPath := incident.URI.Filename()
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, incidentPath)
+		if err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return string(incident.URI)
+}
+
+// applyBaseline writes the incidents to the --baseline file with
+// --write-baseline, otherwise drops the incidents listed in it
+func (a *analyzeCommand) applyBaseline(rulesets []outputv1.RuleSet) ([]outputv1.RuleSet, error) {
+	if a.baseline == "" {
+		return rulesets, nil
+	}
+	if a.writeBaseline {
+		return rulesets, a.writeBaselineFile(rulesets)
+	}
+	fingerprints, err := loadBaseline(a.baseline)
+	if err != nil {
+		return nil, err
+	}
+	suppressed := 0
+	for i := range rulesets {
+		for ruleID, violation := range rulesets[i].Violations {
+			if len(violation.Incidents) == 0 {
+				continue
+			}
+			incidents := []outputv1.Incident{}
+			for _, incident := range violation.Incidents {
+				// baselines written before --stable-incident-ids still match
+				stableID := incidentStableID(incident)
+				if (stableID != "" && fingerprints[stableID]) || fingerprints[incidentFingerprint(ruleID, a.incidentFile(incident), incident.Message)] {
+					suppressed++
+					continue
+				}
+				incidents = append(incidents, incident)
+			}
+			if len(incidents) == 0 {
+				delete(rulesets[i].Violations, ruleID)
+				continue
+			}
+			violation.Incidents = incidents
+			rulesets[i].Violations[ruleID] = violation
+		}
+	}
+	a.log.Info("suppressed incidents found in baseline", "baseline", a.baseline, "incidents", suppressed)
+	return rulesets, nil
+}
+
+func (a *analyzeCommand) writeBaselineFile(rulesets []outputv1.RuleSet) error {
+	baseline := incidentBaseline{Incidents: []baselineIncident{}}
+	seen := map[string]bool{}
+	for _, ruleset := range rulesets {
+		for ruleID, violation := range ruleset.Violations {
+			for _, incident := range violation.Incidents {
+				file := a.incidentFile(incident)
+				fingerprint := incidentStableID(incident)
+				if fingerprint == "" {
+					fingerprint = incidentFingerprint(ruleID, file, incident.Message)
+				}
+				if seen[fingerprint] {
+					continue
+				}
+				seen[fingerprint] = true
+				baseline.Incidents = append(baseline.Incidents, baselineIncident{
+					RuleID:      ruleID,
+					File:        file,
+					Fingerprint: fingerprint,
+				})
+			}
+		}
+	}
+	sort.Slice(baseline.Incidents, func(i, j int) bool {
+		x, y := baseline.Incidents[i], baseline.Incidents[j]
+		if x.RuleID != y.RuleID {
+			return x.RuleID < y.RuleID
+		}
+		if x.File != y.File {
+			return x.File < y.File
+		}
+		return x.Fingerprint < y.Fingerprint
+	})
+	content, err := yaml.Marshal(baseline)
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+	if err := os.WriteFile(a.baseline, content, 0644); err != nil {
+		return fmt.Errorf("failed to write baseline %s: %w", a.baseline, err)
+	}
+	a.log.Info("wrote incident baseline", "baseline", a.baseline, "incidents", len(baseline.Incidents))
+	return nil
+}
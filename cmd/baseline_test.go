@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+)
+
+func TestIncidentFingerprint(t *testing.T) {
+	fingerprint := incidentFingerprint("rule-001", "src/Main.java", "Replace  javax\nwith jakarta")
+	assert.Equal(t, fingerprint, incidentFingerprint("rule-001", "src/Main.java", "Replace javax with jakarta "))
+	assert.NotEqual(t, fingerprint, incidentFingerprint("rule-002", "src/Main.java", "Replace javax with jakarta"))
+	assert.NotEqual(t, fingerprint, incidentFingerprint("rule-001", "src/Other.java", "Replace javax with jakarta"))
+}
+
+func TestApplyBaseline(t *testing.T) {
+	input := t.TempDir()
+	baselinePath := filepath.Join(t.TempDir(), "baseline.yaml")
+	a := &analyzeCommand{
+		input:         input,
+		baseline:      baselinePath,
+		writeBaseline: true,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	line := func(n int) *int { return &n }
+	fileURI := func(rel string) uri.URI {
+		return uri.File(filepath.Join(input, rel))
+	}
+	rulesets := func(incidents ...outputv1.Incident) []outputv1.RuleSet {
+		return []outputv1.RuleSet{
+			{
+				Name: "test",
+				Violations: map[string]outputv1.Violation{
+					"rule-001": {Incidents: incidents},
+				},
+			},
+		}
+	}
+
+	accepted := outputv1.Incident{URI: fileURI("src/Main.java"), LineNumber: line(10), Message: "replace javax"}
+	got, err := a.applyBaseline(rulesets(accepted))
+	require.NoError(t, err)
+	assert.Len(t, got[0].Violations["rule-001"].Incidents, 1, "writing the baseline keeps the incidents")
+	content, err := os.ReadFile(baselinePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "file: src/Main.java")
+
+	a.writeBaseline = false
+	require.NoError(t, a.validateBaseline())
+	// the accepted incident moved lines, a new one was added
+	moved := outputv1.Incident{URI: fileURI("src/Main.java"), LineNumber: line(12), Message: "replace javax"}
+	added := outputv1.Incident{URI: fileURI("src/New.java"), LineNumber: line(3), Message: "replace javax"}
+	got, err = a.applyBaseline(rulesets(moved, added))
+	require.NoError(t, err)
+	assert.Equal(t, []outputv1.Incident{added}, got[0].Violations["rule-001"].Incidents)
+
+	got, err = a.applyBaseline(rulesets(moved))
+	require.NoError(t, err)
+	assert.NotContains(t, got[0].Violations, "rule-001")
+}
+
+func TestValidateBaseline(t *testing.T) {
+	a := &analyzeCommand{writeBaseline: true}
+	assert.Error(t, a.validateBaseline(), "write-baseline needs a file")
+
+	a.baseline = filepath.Join(t.TempDir(), "missing.yaml")
+	assert.NoError(t, a.validateBaseline(), "the file is created when writing")
+
+	a.writeBaseline = false
+	assert.Error(t, a.validateBaseline())
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+// writeProviderCheck prints whether every provider started, used with
+// --check-providers. An error is returned when any provider failed so the
+// check can gate a pipeline.
+func (a *analyzeCommand) writeProviderCheck(out io.Writer, started []string) error {
+	names := append(append([]string{}, started...), a.unavailableProviders...)
+	sort.Strings(names)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tSTATUS\tERROR")
+	for _, name := range names {
+		if err, failed := a.providerErrors[name]; failed {
+			fmt.Fprintf(w, "%s\tfailed\t%v\n", name, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\tok\t\n", name)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(a.unavailableProviders) > 0 {
+		return fmt.Errorf("%d of %d providers failed to start", len(a.unavailableProviders), len(names))
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProviderCheck(t *testing.T) {
+	a := &analyzeCommand{
+		checkProviders: true,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	out := &bytes.Buffer{}
+	require.NoError(t, a.writeProviderCheck(out, []string{"java", "builtin"}))
+	assert.Equal(t, "PROVIDER  STATUS  ERROR\nbuiltin   ok      \njava      ok      \n", out.String())
+
+	a.markProviderUnavailable("python", errors.New("pylsp not found"))
+	out.Reset()
+	err := a.writeProviderCheck(out, []string{"java", "builtin"})
+	assert.EqualError(t, err, "1 of 3 providers failed to start")
+	assert.Contains(t, out.String(), "python    failed  pylsp not found\n")
+	assert.True(t, a.continueWithoutProviders())
+}
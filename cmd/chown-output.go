@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// parseOwner parses a --chown uid:gid value
+func parseOwner(owner string) (int, int, error) {
+	uidValue, gidValue, found := strings.Cut(owner, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("--chown %q must be uid:gid, e.g. 1000:1000", owner)
+	}
+	uid, err := strconv.Atoi(uidValue)
+	if err != nil || uid < 0 {
+		return 0, 0, fmt.Errorf("--chown %q must be uid:gid, e.g. 1000:1000", owner)
+	}
+	gid, err := strconv.Atoi(gidValue)
+	if err != nil || gid < 0 {
+		return 0, 0, fmt.Errorf("--chown %q must be uid:gid, e.g. 1000:1000", owner)
+	}
+	return uid, gid, nil
+}
+
+// validateChown checks the --chown value
+func (a *analyzeCommand) validateChown() error {
+	if a.chown == "" {
+		return nil
+	}
+	_, _, err := parseOwner(a.chown)
+	return err
+}
+
+// chownOutput hands the output dir and archive over to the --chown owner,
+// e.g. the invoking user when kantra runs as root in a container. Skipped
+// on Windows.
+func (a *analyzeCommand) chownOutput() error {
+	if a.chown == "" || runtime.GOOS == "windows" {
+		return nil
+	}
+	uid, gid, err := parseOwner(a.chown)
+	if err != nil {
+		return err
+	}
+	err = filepath.WalkDir(a.output, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(path, uid, gid)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to change the owner of %s: %w", a.output, err)
+	}
+	if a.outputArchive != "" {
+		if err := os.Lchown(a.outputArchive, uid, gid); err != nil {
+			return fmt.Errorf("failed to change the owner of %s: %w", a.outputArchive, err)
+		}
+	}
+	return nil
+}
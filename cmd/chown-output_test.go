@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOwner(t *testing.T) {
+	tests := []struct {
+		owner   string
+		uid     int
+		gid     int
+		wantErr bool
+	}{
+		{owner: "1000:1000", uid: 1000, gid: 1000},
+		{owner: "0:100", uid: 0, gid: 100},
+		{owner: "1000", wantErr: true},
+		{owner: "user:group", wantErr: true},
+		{owner: "1000:", wantErr: true},
+		{owner: "-1:1000", wantErr: true},
+	}
+	for _, tt := range tests {
+		uid, gid, err := parseOwner(tt.owner)
+		if tt.wantErr {
+			assert.Error(t, err, tt.owner)
+			continue
+		}
+		require.NoError(t, err, tt.owner)
+		assert.Equal(t, tt.uid, uid)
+		assert.Equal(t, tt.gid, gid)
+	}
+}
+
+func TestChownOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("--chown is ignored on Windows")
+	}
+	output := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(output, "static-report"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(output, "static-report", "index.html"), []byte("<html/>"), 0644))
+	a := &analyzeCommand{output: output, chown: fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())}
+	require.NoError(t, a.chownOutput())
+
+	a.output = filepath.Join(output, "missing")
+	assert.Error(t, a.chownOutput())
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+)
+
+// jdtlsWorkspaceDir holds the jdtls workspace metadata, including the
+// java search indices
+const jdtlsWorkspaceDir = ".metadata"
+
+type cleanCommand struct {
+	kantraDir string
+	dryRun    bool
+	log       logr.Logger
+}
+
+func NewCleanCommand(log logr.Logger) *cobra.Command {
+	cleanCmd := &cleanCommand{
+		log: log,
+	}
+
+	cleanCommand := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove the cached language server state of containerless analysis",
+		Long: "Remove the language server working dirs and jdtls workspace metadata left in the kantra dir. " +
+			"Use it to recover from stale or corrupted provider state causing wrong analysis results.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cleanCmd.Validate(); err != nil {
+				log.Error(err, "failed to validate flags")
+				return err
+			}
+			if err := cleanCmd.Run(os.Stdout); err != nil {
+				log.Error(err, "failed to clean language server state")
+				return err
+			}
+			return nil
+		},
+	}
+	cleanCommand.Flags().BoolVar(&cleanCmd.dryRun, "dry-run", false, "only print the paths that would be removed")
+	return cleanCommand
+}
+
+func (c *cleanCommand) Validate() error {
+	if c.kantraDir == "" {
+		dir, err := findKantraDir(c.log)
+		if err != nil {
+			return err
+		}
+		c.kantraDir = dir
+	}
+	return nil
+}
+
+// cachePaths returns the language server state paths that may exist in the
+// kantra dir. Only the kantra dir is cleaned, the same names in other dirs,
+// e.g. an eclipse workspace, may belong to the user.
+func (c *cleanCommand) cachePaths() []string {
+	if c.kantraDir == "" {
+		return []string{}
+	}
+	paths := []string{}
+	for _, name := range languageServerDirs {
+		paths = append(paths, filepath.Join(c.kantraDir, name))
+	}
+	return append(paths, filepath.Join(c.kantraDir, jdtlsWorkspaceDir))
+}
+
+func (c *cleanCommand) Run(out io.Writer) error {
+	removed := 0
+	for _, path := range c.cachePaths() {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		removed++
+		if c.dryRun {
+			fmt.Fprintf(out, "would remove %s\n", path)
+			continue
+		}
+		c.log.V(1).Info("removing language server state", "path", path)
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		fmt.Fprintf(out, "removed %s\n", path)
+	}
+	if removed == 0 {
+		fmt.Fprintln(out, "no language server state to remove")
+	}
+	return nil
+}
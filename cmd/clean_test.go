@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanCommandRun(t *testing.T) {
+	workDir := t.TempDir()
+	t.Chdir(workDir)
+	kantraDir := t.TempDir()
+
+	stale := []string{
+		filepath.Join(kantraDir, "org.eclipse.osgi"),
+		filepath.Join(kantraDir, "org.eclipse.equinox.app"),
+		filepath.Join(kantraDir, jdtlsWorkspaceDir, ".plugins", "org.eclipse.jdt.core"),
+	}
+	for _, dir := range stale {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+	}
+	kept := []string{
+		filepath.Join(kantraDir, "jdtls", "bin"),
+		// the working dir may be an eclipse workspace
+		filepath.Join(workDir, "org.eclipse.osgi"),
+		filepath.Join(workDir, jdtlsWorkspaceDir),
+	}
+	for _, dir := range kept {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+	}
+
+	c := &cleanCommand{kantraDir: kantraDir, dryRun: true, log: logr.Discard()}
+	var out bytes.Buffer
+	require.NoError(t, c.Run(&out))
+	for _, dir := range stale {
+		assert.DirExists(t, dir)
+	}
+	assert.Contains(t, out.String(), "would remove "+filepath.Join(kantraDir, "org.eclipse.osgi"))
+
+	c.dryRun = false
+	out.Reset()
+	require.NoError(t, c.Run(&out))
+	for _, dir := range stale {
+		assert.NoDirExists(t, dir)
+	}
+	for _, dir := range kept {
+		assert.DirExists(t, dir)
+	}
+	assert.Contains(t, out.String(), "removed "+filepath.Join(kantraDir, "org.eclipse.osgi"))
+	assert.Contains(t, out.String(), "removed "+filepath.Join(kantraDir, jdtlsWorkspaceDir))
+
+	out.Reset()
+	require.NoError(t, c.Run(&out))
+	assert.Equal(t, "no language server state to remove\n", out.String())
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"os"
 	"os/exec"
+	"path/filepath"
 )
 
 func (a *analyzeCommand) CleanAnalysisResources(ctx context.Context) error {
-	if !a.cleanup || a.needsBuiltin {
+	if !a.cleanup {
+		a.logSkippedCleanup()
+		return nil
+	}
+	if a.needsBuiltin {
 		return nil
 	}
 	a.log.V(1).Info("removing temp dirs")
@@ -109,16 +114,52 @@ func (c *AnalyzeCommandContext) RmProviderContainers(ctx context.Context) error
 	return nil
 }
 
+// languageServerDirs are created by jdtls in the working dir
+var languageServerDirs = []string{
+	"org.eclipse.core.runtime",
+	"org.eclipse.equinox.app",
+	"org.eclipse.equinox.launcher",
+	"org.eclipse.osgi",
+}
+
+// logSkippedCleanup logs the temporary resources left behind with
+// --no-cleanup so they can be inspected or attached to bug reports
+func (a *analyzeCommand) logSkippedCleanup() {
+	for _, path := range a.tempDirs {
+		a.log.Info("--no-cleanup set, temporary dir preserved", "dir", path)
+	}
+	for _, con := range a.providerContainerNames {
+		a.log.Info("--no-cleanup set, provider container preserved", "container", con)
+	}
+	if a.networkName != "" {
+		a.log.Info("--no-cleanup set, container network preserved", "network", a.networkName)
+	}
+	if a.volumeName != "" {
+		a.log.Info("--no-cleanup set, container volume preserved", "volume", a.volumeName)
+	}
+}
+
+// logSkippedContainerlessCleanup logs the provider settings and language
+// server dirs left behind by containerless analysis with --no-cleanup
+func (a *analyzeCommand) logSkippedContainerlessCleanup(settingsPath string) {
+	if _, err := os.Stat(settingsPath); err == nil {
+		a.log.Info("--no-cleanup set, provider settings preserved", "file", settingsPath)
+	}
+	for _, path := range languageServerDirs {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if absPath, err := filepath.Abs(path); err == nil {
+			path = absPath
+		}
+		a.log.Info("--no-cleanup set, language server dir preserved", "dir", path)
+	}
+}
+
 func (a *analyzeCommand) cleanlsDirs() error {
 	a.log.V(7).Info("removing language server dirs")
 	// this assumes dirs created in wd
-	lsDirs := []string{
-		"org.eclipse.core.runtime",
-		"org.eclipse.equinox.app",
-		"org.eclipse.equinox.launcher",
-		"org.eclipse.osgi",
-	}
-	for _, path := range lsDirs {
+	for _, path := range languageServerDirs {
 		err := os.RemoveAll(path)
 		if err != nil {
 			a.log.Error(err, "failed to delete temporary dir", "dir", path)
@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/bombsimon/logrusr/v3"
@@ -234,4 +237,56 @@ func TestAnalyzeCommandContext_Structure(t *testing.T) {
 	if len(c.providerContainerNames) != 1 {
 		t.Errorf("Expected 1 container name, got %d", len(c.providerContainerNames))
 	}
-}
\ No newline at end of file
+}
+func TestAnalyzeCommand_CleanAnalysisResourcesNoCleanup(t *testing.T) {
+	testLogger := logrus.New()
+	var logOutput strings.Builder
+	testLogger.SetOutput(&logOutput)
+	logger := logrusr.New(testLogger)
+
+	tempDir := t.TempDir()
+	a := &analyzeCommand{
+		cleanup: false,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log:                    logger,
+			tempDirs:               []string{tempDir},
+			providerContainerNames: []string{"provider-abc"},
+			volumeName:             "test-volume",
+		},
+	}
+
+	err := a.CleanAnalysisResources(context.Background())
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(tempDir); err != nil {
+		t.Errorf("Expected temp dir to be preserved: %v", err)
+	}
+	for _, expected := range []string{tempDir, "provider-abc", "test-volume"} {
+		if !strings.Contains(logOutput.String(), expected) {
+			t.Errorf("Expected preserved resource %s to be logged, got: %s", expected, logOutput.String())
+		}
+	}
+}
+
+func TestAnalyzeCommand_logSkippedContainerlessCleanup(t *testing.T) {
+	testLogger := logrus.New()
+	var logOutput strings.Builder
+	testLogger.SetOutput(&logOutput)
+	logger := logrusr.New(testLogger)
+
+	settingsPath := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(settingsPath, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	a := &analyzeCommand{
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logger,
+		},
+	}
+
+	a.logSkippedContainerlessCleanup(settingsPath)
+	if !strings.Contains(logOutput.String(), settingsPath) {
+		t.Errorf("Expected settings file to be logged, got: %s", logOutput.String())
+	}
+}
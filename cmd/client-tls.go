@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// loadClientTLSConfig returns the TLS config for downloads with a client
+// certificate and an extra CA, servers are still trusted by the system
+// trust store
+func loadClientTLSConfig(certFile string, keyFile string, caFile string) (*tls.Config, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, fmt.Errorf("--client-cert and --client-key must be set together")
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate %s and key %s: %w", certFile, keyFile, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		content, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate %s: %w", caFile, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(content) {
+			return nil, fmt.Errorf("no PEM certificates found in CA certificate %s", caFile)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// downloadClient returns the HTTP client for remote input downloads, using
+// the --client-cert, --client-key and --ca-cert TLS settings when set
+func (a *analyzeCommand) downloadClient() (*http.Client, error) {
+	if a.httpClient != nil {
+		return a.httpClient, nil
+	}
+	if a.clientCert == "" && a.clientKey == "" && a.caCert == "" {
+		return http.DefaultClient, nil
+	}
+	config, err := loadClientTLSConfig(a.clientCert, a.clientKey, a.caCert)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	a.httpClient = &http.Client{Transport: transport}
+	return a.httpClient, nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestKeyPair(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kantra"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestLoadClientTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestKeyPair(t, dir)
+	invalidCA := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidCA, []byte("not a certificate"), 0644))
+
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		caFile   string
+		wantErr  string
+	}{
+		{name: "client certificate", certFile: certFile, keyFile: keyFile},
+		{name: "missing key", certFile: certFile, wantErr: "must be set together"},
+		{name: "mismatched key pair", certFile: certFile, keyFile: certFile, wantErr: "failed to load client certificate"},
+		{name: "invalid CA", caFile: invalidCA, wantErr: "no PEM certificates found"},
+		{name: "missing CA", caFile: filepath.Join(dir, "missing.pem"), wantErr: "failed to read CA certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := loadClientTLSConfig(tt.certFile, tt.keyFile, tt.caFile)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, config.Certificates, 1)
+		})
+	}
+}
+
+func TestDownloadClientMutualTLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("archive"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0644))
+	certFile, keyFile := writeTestKeyPair(t, dir)
+
+	a := &analyzeCommand{caCert: caFile}
+	client, err := a.downloadClient()
+	require.NoError(t, err)
+	_, err = downloadFile(context.Background(), client, server.URL+"/app.zip", filepath.Join(dir, "without-cert.zip"))
+	assert.Error(t, err, "server requires a client certificate")
+
+	a = &analyzeCommand{caCert: caFile, clientCert: certFile, clientKey: keyFile}
+	client, err = a.downloadClient()
+	require.NoError(t, err)
+	_, err = downloadFile(context.Background(), client, server.URL+"/app.zip", filepath.Join(dir, "app.zip"))
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "app.zip"))
+	require.NoError(t, err)
+	assert.Equal(t, "archive", string(data))
+
+	a = &analyzeCommand{}
+	client, err = a.downloadClient()
+	require.NoError(t, err)
+	assert.Equal(t, http.DefaultClient, client)
+}
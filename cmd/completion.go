@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/spf13/cobra"
+)
+
+func NewCompletionCommand() *cobra.Command {
+	completionCmd := &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate the shell completion script",
+		Long: `Generate the completion script for the given shell, e.g. load it in the current bash session with
+
+  source <(kantra completion bash)
+
+Flags and the --source and --target values of the containerless rulesets are completed.`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return cmd.Root().GenBashCompletionV2(out, true)
+			case "zsh":
+				return cmd.Root().GenZshCompletion(out)
+			case "fish":
+				return cmd.Root().GenFishCompletion(out, true)
+			case "powershell":
+				return cmd.Root().GenPowerShellCompletionWithDesc(out)
+			}
+			return fmt.Errorf("unsupported shell %q", args[0])
+		},
+	}
+	return completionCmd
+}
+
+// completeTechnologies completes --source or --target with the technologies
+// of the containerless rulesets and any --rules already given. Nothing is
+// logged, the completion output is read by the shell.
+func (a *analyzeCommand) completeTechnologies(label string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		kantraDir, err := findKantraDir(logr.Discard())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		completer := &analyzeCommand{
+			rules: a.rules,
+			AnalyzeCommandContext: AnalyzeCommandContext{
+				kantraDir: kantraDir,
+				log:       logr.Discard(),
+			},
+		}
+		labels, err := completer.walkRuleFilesForLabelsContainerless(label)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return util.OptionsFromLabels(labels, label), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func (a *analyzeCommand) registerFlagCompletions(cmd *cobra.Command) {
+	cmd.RegisterFlagCompletionFunc("source", a.completeTechnologies(outputv1.SourceTechnologyLabel))
+	cmd.RegisterFlagCompletionFunc("target", a.completeTechnologies(outputv1.TargetTechnologyLabel))
+}
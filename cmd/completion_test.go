@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	root := &cobra.Command{Use: "kantra"}
+	root.AddCommand(NewCompletionCommand())
+
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			var out bytes.Buffer
+			root.SetOut(&out)
+			root.SetArgs([]string{"completion", shell})
+			require.NoError(t, root.Execute())
+			assert.Contains(t, out.String(), "kantra")
+		})
+	}
+
+	root.SetArgs([]string{"completion", "tcsh"})
+	assert.Error(t, root.Execute())
+}
+
+func TestCompleteTechnologies(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	rulesDir := filepath.Join(configDir, ".kantra", RulesetsLocation, "eap")
+	require.NoError(t, os.MkdirAll(rulesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "rules.yaml"), []byte(`- ruleID: test-01
+  labels:
+  - konveyor.io/source=eap7
+  - konveyor.io/target=quarkus
+  - konveyor.io/target=eap8+
+`), 0644))
+
+	a := &analyzeCommand{}
+	targets, directive := a.completeTechnologies(outputv1.TargetTechnologyLabel)(nil, nil, "")
+	assert.Equal(t, []string{"eap8", "quarkus"}, targets)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	sources, _ := a.completeTechnologies(outputv1.SourceTechnologyLabel)(nil, nil, "")
+	assert.Equal(t, []string{"eap7"}, sources)
+}
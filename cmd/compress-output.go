@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// gzipExtension is appended to the output files written with --compress-output
+const gzipExtension = ".gz"
+
+// outputFilePath returns the path of an analysis output file in the output
+// dir, e.g. output.yaml.gz instead of output.yaml with --compress-output
+func (a *analyzeCommand) outputFilePath(name string) string {
+	path := filepath.Join(a.output, name)
+	if a.compressOutput {
+		return path + gzipExtension
+	}
+	return path
+}
+
+// writeOutputFile writes an analysis output file to the output dir, gzipped
+// with --compress-output
+func (a *analyzeCommand) writeOutputFile(name string, data []byte, perm os.FileMode) error {
+	return a.streamOutputFile(name, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// streamOutputFile writes an analysis output file to the output dir through
+// write, gzipped with --compress-output, without holding the whole content
+// in memory
+func (a *analyzeCommand) streamOutputFile(name string, perm os.FileMode, write func(io.Writer) error) error {
+	file, err := os.OpenFile(a.outputFilePath(name), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	buffered := bufio.NewWriter(file)
+	var writer io.Writer = buffered
+	var gzipWriter *gzip.Writer
+	if a.compressOutput {
+		gzipWriter = gzip.NewWriter(buffered)
+		writer = gzipWriter
+	}
+	if err := write(writer); err != nil {
+		file.Close()
+		return err
+	}
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// readOutputFile reads an analysis output file, gzipped files are
+// decompressed in memory whatever their extension
+func readOutputFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		return data, nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+	defer reader.Close()
+	data, err = io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+	"gopkg.in/yaml.v2"
+)
+
+func TestWriteOutputFile(t *testing.T) {
+	data := []byte("- name: ruleset-a\n")
+	for _, compress := range []bool{false, true} {
+		a := &analyzeCommand{output: t.TempDir(), compressOutput: compress}
+		require.NoError(t, a.writeOutputFile("output.yaml", data, 0644))
+
+		path := a.outputFilePath("output.yaml")
+		if compress {
+			assert.Equal(t, filepath.Join(a.output, "output.yaml.gz"), path)
+			assert.NoFileExists(t, filepath.Join(a.output, "output.yaml"))
+			raw, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.NotEqual(t, data, raw)
+		}
+		content, err := readOutputFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, data, content)
+	}
+}
+
+func TestMergeCompressedOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputs := []string{}
+	for i, compress := range []bool{false, true} {
+		file := uri.URI(fmt.Sprintf("file:///svc/%c.java", 'A'+i))
+		rulesets := []outputv1.RuleSet{
+			{
+				Name: "ruleset-a",
+				Violations: map[string]outputv1.Violation{
+					"rule-1": {Incidents: []outputv1.Incident{{URI: file}}},
+				},
+			},
+		}
+		b, err := yaml.Marshal(rulesets)
+		require.NoError(t, err)
+		a := &analyzeCommand{output: filepath.Join(tmpDir, "input", string(rune('a'+i))), compressOutput: compress}
+		require.NoError(t, os.MkdirAll(a.output, 0755))
+		require.NoError(t, a.writeOutputFile("output.yaml", b, 0644))
+		inputs = append(inputs, a.outputFilePath("output.yaml"))
+	}
+
+	m := &mergeCommand{
+		inputs:           inputs,
+		output:           filepath.Join(tmpDir, "combined"),
+		skipStaticReport: true,
+		log:              logr.Discard(),
+	}
+	require.NoError(t, m.Validate())
+	require.NoError(t, m.Run())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "combined", "output.yaml"))
+	require.NoError(t, err)
+	merged := []outputv1.RuleSet{}
+	require.NoError(t, yaml.Unmarshal(content, &merged))
+	require.Len(t, merged, 1)
+	assert.Len(t, merged[0].Violations["rule-1"].Incidents, 2)
+}
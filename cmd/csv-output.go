@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+var incidentsCSVHeader = []string{"ruleset", "rule_id", "category", "effort", "file", "line", "message"}
+
+// buildIncidentsCSV returns one row per incident, sorted by ruleset, rule,
+// file and line so the output diffs cleanly between runs
+func buildIncidentsCSV(rulesets []outputv1.RuleSet) [][]string {
+	type incidentRow struct {
+		ruleset string
+		ruleID  string
+		file    string
+		line    int
+		record  []string
+	}
+	rows := []incidentRow{}
+	for _, ruleset := range rulesets {
+		for ruleID, violation := range ruleset.Violations {
+			category := outputv1.Potential
+			if violation.Category != nil {
+				category = *violation.Category
+			}
+			effort := ""
+			if violation.Effort != nil {
+				effort = strconv.Itoa(*violation.Effort)
+			}
+			for _, incident := range violation.Incidents {
+				line := 0
+				lineNumber := ""
+				if incident.LineNumber != nil {
+					line = *incident.LineNumber
+					lineNumber = strconv.Itoa(line)
+				}
+				rows = append(rows, incidentRow{
+					ruleset: ruleset.Name,
+					ruleID:  ruleID,
+					file:    string(incident.URI),
+					line:    line,
+					record:  []string{ruleset.Name, ruleID, string(category), effort, string(incident.URI), lineNumber, incident.Message},
+				})
+			}
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].ruleset != rows[j].ruleset {
+			return rows[i].ruleset < rows[j].ruleset
+		}
+		if rows[i].ruleID != rows[j].ruleID {
+			return rows[i].ruleID < rows[j].ruleID
+		}
+		if rows[i].file != rows[j].file {
+			return rows[i].file < rows[j].file
+		}
+		return rows[i].line < rows[j].line
+	})
+	records := [][]string{incidentsCSVHeader}
+	for _, row := range rows {
+		records = append(records, row.record)
+	}
+	return records
+}
+
+// writeCSVOutput writes incidents.csv to the output dir when --csv-output is set
+func (a *analyzeCommand) writeCSVOutput(rulesets []outputv1.RuleSet) error {
+	if !a.csvOutput {
+		return nil
+	}
+	a.log.Info("writing analysis results as csv output", "output", a.output)
+	var data bytes.Buffer
+	// csv.Writer quotes fields containing commas, quotes and newlines
+	writer := csv.NewWriter(&data)
+	if err := writer.WriteAll(buildIncidentsCSV(rulesets)); err != nil {
+		a.log.V(1).Error(err, "failed to marshal csv output")
+		return err
+	}
+	err := os.WriteFile(filepath.Join(a.output, "incidents.csv"), data.Bytes(), 0644)
+	if err != nil {
+		a.log.V(1).Error(err, "failed to write csv output", "dir", a.output, "file", "incidents.csv")
+		return err
+	}
+	return nil
+}
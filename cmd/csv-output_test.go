@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildIncidentsCSV(t *testing.T) {
+	mandatory := outputv1.Mandatory
+	effort := 3
+	line10, line2 := 10, 2
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "zeta",
+			Violations: map[string]outputv1.Violation{
+				"rule-001": {Incidents: []outputv1.Incident{{URI: "file:///app/A.java", Message: "potential"}}},
+			},
+		},
+		{
+			Name: "alpha",
+			Violations: map[string]outputv1.Violation{
+				"rule-002": {
+					Category: &mandatory,
+					Effort:   &effort,
+					Incidents: []outputv1.Incident{
+						{URI: "file:///app/B.java", LineNumber: &line10, Message: "second"},
+						{URI: "file:///app/B.java", LineNumber: &line2, Message: "replace a, b\nand c"},
+						{URI: "file:///app/A.java", Message: "first"},
+					},
+				},
+				"rule-001": {Incidents: []outputv1.Incident{{URI: "file:///app/C.java", Message: "rule one"}}},
+			},
+		},
+	}
+
+	assert.Equal(t, [][]string{
+		incidentsCSVHeader,
+		{"alpha", "rule-001", "potential", "", "file:///app/C.java", "", "rule one"},
+		{"alpha", "rule-002", "mandatory", "3", "file:///app/A.java", "", "first"},
+		{"alpha", "rule-002", "mandatory", "3", "file:///app/B.java", "2", "replace a, b\nand c"},
+		{"alpha", "rule-002", "mandatory", "3", "file:///app/B.java", "10", "second"},
+		{"zeta", "rule-001", "potential", "", "file:///app/A.java", "", "potential"},
+	}, buildIncidentsCSV(rulesets))
+}
+
+func TestWriteCSVOutput(t *testing.T) {
+	output := t.TempDir()
+	a := &analyzeCommand{
+		output: output,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "ruleset",
+			Violations: map[string]outputv1.Violation{
+				"rule": {Incidents: []outputv1.Incident{{URI: "file:///app/A.java", Message: "use \"jakarta\", not javax"}}},
+			},
+		},
+	}
+
+	require.NoError(t, a.writeCSVOutput(rulesets))
+	_, err := os.Stat(filepath.Join(output, "incidents.csv"))
+	assert.True(t, os.IsNotExist(err), "csv is only written with --csv-output")
+
+	a.csvOutput = true
+	require.NoError(t, a.writeCSVOutput(rulesets))
+	f, err := os.Open(filepath.Join(output, "incidents.csv"))
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "use \"jakarta\", not javax", records[1][6])
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/konveyor/analyzer-lsp/engine/labels"
+	"github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/provider"
+)
+
+// dependencyLabelExpression combines --dep-label-selector with the filter
+// leaving out open-source dependencies unless --analyze-known-libraries is set
+func (a *analyzeCommand) dependencyLabelExpression() string {
+	knownLibraries := fmt.Sprintf("!%v=open-source", provider.DepSourceLabel)
+	switch {
+	case a.depLabelSelector != "" && !a.analyzeKnownLibraries:
+		return fmt.Sprintf("(%s) && %s", a.depLabelSelector, knownLibraries)
+	case a.depLabelSelector != "":
+		return a.depLabelSelector
+	case !a.analyzeKnownLibraries:
+		return knownLibraries
+	}
+	return ""
+}
+
+// dependencyLabelSelector returns the selector filtering the dependencies,
+// their incidents and the dependency output, nil when all are kept
+func (a *analyzeCommand) dependencyLabelSelector() (*labels.LabelSelector[*konveyor.Dep], error) {
+	expr := a.dependencyLabelExpression()
+	if expr == "" {
+		return nil, nil
+	}
+	selector, err := labels.NewLabelSelector[*konveyor.Dep](expr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dependency label selector from expression %q: %w", expr, err)
+	}
+	return selector, nil
+}
+
+// filterDependencyTree drops the dependencies not matching the selector, the
+// matching dependencies they added are kept in their place
+func filterDependencyTree(selector *labels.LabelSelector[*konveyor.Dep], items []konveyor.DepDAGItem) ([]konveyor.DepDAGItem, error) {
+	filtered := []konveyor.DepDAGItem{}
+	for _, item := range items {
+		addedDeps, err := filterDependencyTree(selector, item.AddedDeps)
+		if err != nil {
+			return nil, err
+		}
+		matched, err := selector.Matches(&item.Dep)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			filtered = append(filtered, addedDeps...)
+			continue
+		}
+		item.AddedDeps = addedDeps
+		filtered = append(filtered, item)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyLabelExpression(t *testing.T) {
+	tests := []struct {
+		name                  string
+		depLabelSelector      string
+		analyzeKnownLibraries bool
+		want                  string
+	}{
+		{name: "open-source filter", want: "!konveyor.io/dep-source=open-source"},
+		{name: "known libraries", analyzeKnownLibraries: true, want: ""},
+		{name: "custom selector", depLabelSelector: "konveyor.io/dep-source=internal", want: "(konveyor.io/dep-source=internal) && !konveyor.io/dep-source=open-source"},
+		{name: "custom selector with known libraries", depLabelSelector: "konveyor.io/dep-source=internal", analyzeKnownLibraries: true, want: "konveyor.io/dep-source=internal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzeCommand{depLabelSelector: tt.depLabelSelector, analyzeKnownLibraries: tt.analyzeKnownLibraries}
+			assert.Equal(t, tt.want, a.dependencyLabelExpression())
+		})
+	}
+}
+
+func TestFilterDependencyTree(t *testing.T) {
+	openSource := []string{"konveyor.io/dep-source=open-source"}
+	tree := []konveyor.DepDAGItem{
+		{
+			Dep: konveyor.Dep{Name: "internal-api"},
+			AddedDeps: []konveyor.DepDAGItem{
+				{Dep: konveyor.Dep{Name: "commons-lang", Labels: openSource}},
+			},
+		},
+		{
+			Dep: konveyor.Dep{Name: "spring-core", Labels: openSource},
+			AddedDeps: []konveyor.DepDAGItem{
+				{Dep: konveyor.Dep{Name: "internal-util"}},
+			},
+		},
+	}
+
+	a := &analyzeCommand{}
+	selector, err := a.dependencyLabelSelector()
+	require.NoError(t, err)
+	filtered, err := filterDependencyTree(selector, tree)
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "internal-api", filtered[0].Dep.Name)
+	assert.Empty(t, filtered[0].AddedDeps)
+	assert.Equal(t, "internal-util", filtered[1].Dep.Name)
+
+	a = &analyzeCommand{analyzeKnownLibraries: true}
+	selector, err = a.dependencyLabelSelector()
+	require.NoError(t, err)
+	assert.Nil(t, selector)
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type diffCommand struct {
+	oldOutput string
+	newOutput string
+	oldInput  string
+	newInput  string
+	format    string
+	log       logr.Logger
+}
+
+// diffIncident is an incident of one of the compared analysis outputs
+type diffIncident struct {
+	RuleSet     string `json:"ruleset"`
+	RuleID      string `json:"ruleID"`
+	File        string `json:"file"`
+	Line        int    `json:"line,omitempty"`
+	Message     string `json:"message"`
+	Fingerprint string `json:"fingerprint"`
+	StableID    string `json:"stableID,omitempty"`
+}
+
+type diffSummary struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// outputDiff is the difference between two analysis outputs, written as is
+// with --format json
+type outputDiff struct {
+	Summary diffSummary    `json:"summary"`
+	Added   []diffIncident `json:"added"`
+	Removed []diffIncident `json:"removed"`
+}
+
+func NewDiffCommand(log logr.Logger) *cobra.Command {
+	diffCmd := &diffCommand{
+		log: log,
+	}
+
+	diffCommand := &cobra.Command{
+		Use:   "diff <old/output.yaml> <new/output.yaml>",
+		Short: "Compare the incidents of two analysis outputs",
+		Long: "Compare the incidents of two analysis outputs and report the added, removed and unchanged ones. " +
+			"Incidents are matched by rule, file and message like baselines, so moved lines don't show up as changes.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			diffCmd.oldOutput = args[0]
+			diffCmd.newOutput = args[1]
+			if err := diffCmd.Validate(); err != nil {
+				log.Error(err, "failed to validate flags")
+				return err
+			}
+			if err := diffCmd.Run(os.Stdout); err != nil {
+				log.Error(err, "failed to compare analysis output")
+				return err
+			}
+			return nil
+		},
+	}
+	diffCommand.Flags().StringVar(&diffCmd.format, "format", "text", "output format of the comparison, one of 'text' or 'json'")
+	diffCommand.Flags().StringVar(&diffCmd.oldInput, "old-input", "", "path of the application analyzed for the old output, used to make incident files relative")
+	diffCommand.Flags().StringVar(&diffCmd.newInput, "new-input", "", "path of the application analyzed for the new output, used to make incident files relative")
+
+	return diffCommand
+}
+
+func (d *diffCommand) Validate() error {
+	if d.format != "text" && d.format != "json" {
+		return fmt.Errorf("unsupported format %q, must be one of 'text' or 'json'", d.format)
+	}
+	for _, input := range []*string{&d.oldInput, &d.newInput} {
+		if *input == "" {
+			continue
+		}
+		absPath, err := filepath.Abs(*input)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path for %s: %w", *input, err)
+		}
+		*input = absPath
+	}
+	return nil
+}
+
+func (d *diffCommand) Run(out io.Writer) error {
+	oldIncidents, err := loadDiffIncidents(d.oldOutput, d.incidentRoots(d.oldInput))
+	if err != nil {
+		return err
+	}
+	newIncidents, err := loadDiffIncidents(d.newOutput, d.incidentRoots(d.newInput))
+	if err != nil {
+		return err
+	}
+	diff := diffIncidents(oldIncidents, newIncidents)
+	if d.format == "json" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal comparison: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+	return writeOutputDiff(out, diff)
+}
+
+// incidentRoots returns the dirs incident files are made relative to, the
+// container source mount covers outputs of container and hybrid analyses
+func (d *diffCommand) incidentRoots(input string) []string {
+	if input == "" {
+		return []string{util.SourceMountPath}
+	}
+	return []string{input, util.SourceMountPath}
+}
+
+func loadDiffIncidents(path string, roots []string) ([]diffIncident, error) {
+	content, err := readOutputFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read analysis output %s: %w", path, err)
+	}
+	rulesets := []outputv1.RuleSet{}
+	if err := yaml.Unmarshal(content, &rulesets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal analysis output %s: %w", path, err)
+	}
+	incidents := []diffIncident{}
+	for _, ruleset := range rulesets {
+		for ruleID, violation := range ruleset.Violations {
+			for _, incident := range violation.Incidents {
+				file := relativeIncidentFile(incident, roots)
+				line := 0
+				if incident.LineNumber != nil {
+					line = *incident.LineNumber
+				}
+				incidents = append(incidents, diffIncident{
+					RuleSet:     ruleset.Name,
+					RuleID:      ruleID,
+					File:        file,
+					Line:        line,
+					Message:     strings.TrimSpace(incident.Message),
+					Fingerprint: incidentFingerprint(ruleID, file, incident.Message),
+					StableID:    incidentStableID(incident),
+				})
+			}
+		}
+	}
+	sortDiffIncidents(incidents)
+	return incidents, nil
+}
+
+// diffIncidents matches the incidents by fingerprint, or by stable id when
+// both outputs were written with --stable-incident-ids. Incidents sharing a
+// fingerprint are counted, so an extra occurrence of the same issue in a
+// file is reported as added.
+func diffIncidents(oldIncidents []diffIncident, newIncidents []diffIncident) outputDiff {
+	useStableIDs := hasStableIDs(oldIncidents) && hasStableIDs(newIncidents)
+	key := func(incident diffIncident) string {
+		if useStableIDs {
+			return incident.StableID
+		}
+		return incident.Fingerprint
+	}
+	oldByFingerprint := map[string][]diffIncident{}
+	for _, incident := range oldIncidents {
+		oldByFingerprint[key(incident)] = append(oldByFingerprint[key(incident)], incident)
+	}
+	newByFingerprint := map[string][]diffIncident{}
+	for _, incident := range newIncidents {
+		newByFingerprint[key(incident)] = append(newByFingerprint[key(incident)], incident)
+	}
+	diff := outputDiff{Added: []diffIncident{}, Removed: []diffIncident{}}
+	for fingerprint, incidents := range newByFingerprint {
+		matched := min(len(incidents), len(oldByFingerprint[fingerprint]))
+		diff.Summary.Unchanged += matched
+		diff.Added = append(diff.Added, incidents[matched:]...)
+	}
+	for fingerprint, incidents := range oldByFingerprint {
+		matched := min(len(incidents), len(newByFingerprint[fingerprint]))
+		diff.Removed = append(diff.Removed, incidents[matched:]...)
+	}
+	sortDiffIncidents(diff.Added)
+	sortDiffIncidents(diff.Removed)
+	diff.Summary.Added = len(diff.Added)
+	diff.Summary.Removed = len(diff.Removed)
+	return diff
+}
+
+func hasStableIDs(incidents []diffIncident) bool {
+	for _, incident := range incidents {
+		if incident.StableID == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func sortDiffIncidents(incidents []diffIncident) {
+	sort.SliceStable(incidents, func(i, j int) bool {
+		x, y := incidents[i], incidents[j]
+		if x.RuleID != y.RuleID {
+			return x.RuleID < y.RuleID
+		}
+		if x.File != y.File {
+			return x.File < y.File
+		}
+		if x.Line != y.Line {
+			return x.Line < y.Line
+		}
+		return x.Fingerprint < y.Fingerprint
+	})
+}
+
+func writeOutputDiff(out io.Writer, diff outputDiff) error {
+	fmt.Fprintf(out, "%d added, %d removed, %d unchanged incidents\n",
+		diff.Summary.Added, diff.Summary.Removed, diff.Summary.Unchanged)
+	for _, section := range []struct {
+		title     string
+		prefix    string
+		incidents []diffIncident
+	}{
+		{"Added", "+", diff.Added},
+		{"Removed", "-", diff.Removed},
+	} {
+		if len(section.incidents) == 0 {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s:\n", section.title)
+		for _, incident := range section.incidents {
+			location := incident.File
+			if incident.Line > 0 {
+				location = fmt.Sprintf("%s:%d", location, incident.Line)
+			}
+			message, _, _ := strings.Cut(incident.Message, "\n")
+			if _, err := fmt.Fprintf(out, "  %s %s %s %s\n", section.prefix, incident.RuleID, location, message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
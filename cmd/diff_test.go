@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCommand(t *testing.T) {
+	dir := t.TempDir()
+	oldOutput := filepath.Join(dir, "old.yaml")
+	require.NoError(t, os.WriteFile(oldOutput, []byte(`- name: ruleset
+  violations:
+    rule-a:
+      incidents:
+      - uri: file:///old/src/A.java
+        message: use jakarta
+        lineNumber: 3
+      - uri: file:///old/src/B.java
+        message: use jakarta
+        lineNumber: 5
+    rule-b:
+      incidents:
+      - uri: file:///old/pom.xml
+        message: update dependency
+`), 0644))
+	newOutput := filepath.Join(dir, "new.yaml")
+	require.NoError(t, os.WriteFile(newOutput, []byte(`- name: ruleset
+  violations:
+    rule-a:
+      incidents:
+      - uri: file:///new/src/A.java
+        message: use jakarta
+        lineNumber: 10
+      - uri: file:///new/src/A.java
+        message: use jakarta
+        lineNumber: 20
+    rule-c:
+      incidents:
+      - uri: file:///new/src/C.java
+        message: |-
+          remove call
+          see docs
+        lineNumber: 7
+`), 0644))
+
+	d := &diffCommand{
+		oldOutput: oldOutput,
+		newOutput: newOutput,
+		oldInput:  "/old",
+		newInput:  "/new",
+		format:    "text",
+		log:       logr.Discard(),
+	}
+	require.NoError(t, d.Validate())
+	var out bytes.Buffer
+	require.NoError(t, d.Run(&out))
+	assert.Equal(t, `2 added, 2 removed, 1 unchanged incidents
+
+Added:
+  + rule-a src/A.java:20 use jakarta
+  + rule-c src/C.java:7 remove call
+
+Removed:
+  - rule-a src/B.java:5 use jakarta
+  - rule-b pom.xml update dependency
+`, out.String())
+
+	d.format = "json"
+	out.Reset()
+	require.NoError(t, d.Run(&out))
+	diff := outputDiff{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &diff))
+	assert.Equal(t, diffSummary{Added: 2, Removed: 2, Unchanged: 1}, diff.Summary)
+	assert.Equal(t, "rule-c", diff.Added[1].RuleID)
+	assert.Equal(t, "src/C.java", diff.Added[1].File)
+
+	d.format = "html"
+	assert.ErrorContains(t, d.Validate(), `unsupported format "html"`)
+}
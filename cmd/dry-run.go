@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/parser"
+	"github.com/konveyor/analyzer-lsp/provider"
+)
+
+// printDryRunPlan resolves provider configs, rules and the label selector
+// for the containerless analysis and prints them to out without starting
+// any provider or running rules, used with --dry-run.
+func (a *analyzeCommand) printDryRunPlan(out io.Writer, analysisLog logr.Logger) error {
+	configs, err := a.createProviderConfigsContainerless()
+	if err != nil {
+		return fmt.Errorf("failed to create provider configs: %w", err)
+	}
+	configData, err := json.MarshalIndent(configs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal provider configs: %w", err)
+	}
+	fmt.Fprintln(out, "Provider configs:")
+	fmt.Fprintln(out, string(configData))
+
+	// provider clients are only created for rule parsing, they are never started
+	providers, _, err := a.setInternalProviders(configs, analysisLog)
+	if err != nil {
+		return err
+	}
+
+	dependencyLabelSelector, err := a.dependencyLabelSelector()
+	if err != nil {
+		return err
+	}
+	ruleParser := parser.RuleParser{
+		ProviderNameToClient: providers,
+		Log:                  analysisLog.WithName("parser"),
+		NoDependencyRules:    a.noDepRules,
+		DepLabelSelector:     dependencyLabelSelector,
+	}
+
+	rules := a.rules
+	if a.enableDefaultRulesets {
+		rules = append(rules, a.defaultRulesetPaths(filepath.Join(a.kantraDir, RulesetsLocation))...)
+	}
+	fmt.Fprintln(out, "Rules:")
+	for _, rulePath := range rules {
+		fmt.Fprintf(out, "  %s\n", rulePath)
+		ruleFiles, err := findRuleFiles(rulePath)
+		if err != nil {
+			fmt.Fprintf(out, "    error: %v\n", err)
+			continue
+		}
+		for _, ruleFile := range ruleFiles {
+			fmt.Fprintf(out, "    file: %s\n", ruleFile)
+		}
+		resolvedPath, err := a.resolveRuleIncludes(rulePath)
+		if err != nil {
+			fmt.Fprintf(out, "    error: %v\n", err)
+			continue
+		}
+		ruleSets, _, _, err := ruleParser.LoadRules(resolvedPath)
+		if err != nil {
+			fmt.Fprintf(out, "    error: %v\n", err)
+		}
+		for _, ruleSet := range ruleSets {
+			fmt.Fprintf(out, "    ruleset: %s (%d rules)\n", ruleSet.Name, len(ruleSet.Rules))
+		}
+	}
+
+	labelSelector := a.getLabelSelector()
+	if labelSelector == "" {
+		labelSelector = "<none>"
+	}
+	fmt.Fprintf(out, "Label selector: %s\n", labelSelector)
+
+	fmt.Fprintln(out, "Output:")
+	fmt.Fprintf(out, "  %s\n", a.outputFilePath("output.yaml"))
+	if a.modeForProvider("java") == provider.FullAnalysisMode {
+		for _, depsFile := range a.dependencyOutputFiles() {
+			fmt.Fprintf(out, "  %s\n", filepath.Join(a.output, depsFile))
+		}
+	}
+	if !a.skipStaticReport {
+		fmt.Fprintf(out, "  %s\n", filepath.Join(a.output, "static-report", "index.html"))
+	}
+	fmt.Fprintf(out, "  %s\n", filepath.Join(a.output, "analysis.log"))
+	return nil
+}
+
+// findRuleFiles returns yaml files found at the given rules path
+func findRuleFiles(rulePath string) ([]string, error) {
+	ruleFiles := []string{}
+	err := filepath.WalkDir(rulePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext == ".yaml" || ext == ".yml" {
+			ruleFiles = append(ruleFiles, path)
+		}
+		return nil
+	})
+	return ruleFiles, err
+}
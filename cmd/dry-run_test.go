@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRuleFiles(t *testing.T) {
+	rulesDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rulesDir, "nested"), 0755))
+	for _, f := range []string{"ruleset.yaml", "rules.yml", "README.md", filepath.Join("nested", "more.YAML")} {
+		require.NoError(t, os.WriteFile(filepath.Join(rulesDir, f), []byte{}, 0644))
+	}
+
+	files, err := findRuleFiles(rulesDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(rulesDir, "ruleset.yaml"),
+		filepath.Join(rulesDir, "rules.yml"),
+		filepath.Join(rulesDir, "nested", "more.YAML"),
+	}, files)
+
+	single := filepath.Join(rulesDir, "rules.yml")
+	files, err = findRuleFiles(single)
+	require.NoError(t, err)
+	assert.Equal(t, []string{single}, files)
+
+	_, err = findRuleFiles(filepath.Join(rulesDir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// effortReportFile sums the effort of the incidents with --effort-report
+const effortReportFile = "effort.json"
+
+// uncategorized groups the effort of violations without a category
+const uncategorized = "uncategorized"
+
+// effortReport is the story points of the reported incidents, the effort of
+// a violation counts once for each of its incidents
+type effortReport struct {
+	TotalEffort   int            `json:"totalEffort"`
+	Incidents     int            `json:"incidents"`
+	EffortToDays  float64        `json:"effortToDays"`
+	EstimatedDays float64        `json:"estimatedDays"`
+	ByCategory    map[string]int `json:"byCategory"`
+	ByRuleSet     map[string]int `json:"byRuleSet"`
+}
+
+func (a *analyzeCommand) validateEffortReport() error {
+	if a.effortReport && a.effortToDays <= 0 {
+		return fmt.Errorf("--effort-to-days must be greater than 0, got %v", a.effortToDays)
+	}
+	return nil
+}
+
+// sumEffort totals the effort of the violations of rulesets, insights have
+// no effort
+func sumEffort(rulesets []outputv1.RuleSet, effortToDays float64) effortReport {
+	report := effortReport{
+		EffortToDays: effortToDays,
+		ByCategory:   map[string]int{},
+		ByRuleSet:    map[string]int{},
+	}
+	for _, ruleset := range rulesets {
+		for _, violation := range ruleset.Violations {
+			if violation.Effort == nil || len(violation.Incidents) == 0 {
+				continue
+			}
+			effort := *violation.Effort * len(violation.Incidents)
+			category := uncategorized
+			if violation.Category != nil {
+				category = string(*violation.Category)
+			}
+			report.TotalEffort += effort
+			report.Incidents += len(violation.Incidents)
+			report.ByCategory[category] += effort
+			report.ByRuleSet[ruleset.Name] += effort
+		}
+	}
+	report.EstimatedDays = float64(report.TotalEffort) * effortToDays
+	return report
+}
+
+// writeEffortReport writes effort.json to the output dir and logs the
+// estimate with --effort-report. Run before --incident-limit drops
+// incidents so the estimate covers all of them.
+func (a *analyzeCommand) writeEffortReport(rulesets []outputv1.RuleSet) error {
+	if !a.effortReport {
+		return nil
+	}
+	report := sumEffort(rulesets, a.effortToDays)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(a.output, effortReportFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", effortReportFile, err)
+	}
+	a.log.Info("migration effort", "storyPoints", report.TotalEffort, "incidents", report.Incidents,
+		"estimatedDays", report.EstimatedDays, "byCategory", report.ByCategory)
+	return nil
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func effortRulesets() []outputv1.RuleSet {
+	mandatory := outputv1.Mandatory
+	optional := outputv1.Optional
+	one, three := 1, 3
+	return []outputv1.RuleSet{
+		{
+			Name: "eap8",
+			Violations: map[string]outputv1.Violation{
+				"eap8-01": {
+					Category:  &mandatory,
+					Effort:    &three,
+					Incidents: []outputv1.Incident{{Message: "a"}, {Message: "b"}},
+				},
+				"eap8-02": {
+					Category:  &optional,
+					Effort:    &one,
+					Incidents: []outputv1.Incident{{Message: "c"}},
+				},
+			},
+		},
+		{
+			Name: "quarkus",
+			Violations: map[string]outputv1.Violation{
+				"quarkus-01": {
+					Effort:    &one,
+					Incidents: []outputv1.Incident{{Message: "d"}},
+				},
+				"quarkus-02": {
+					Incidents: []outputv1.Incident{{Message: "no effort"}},
+				},
+			},
+		},
+	}
+}
+
+func TestSumEffort(t *testing.T) {
+	report := sumEffort(effortRulesets(), 0.5)
+	assert.Equal(t, 8, report.TotalEffort)
+	assert.Equal(t, 4, report.Incidents)
+	assert.Equal(t, 4.0, report.EstimatedDays)
+	assert.Equal(t, map[string]int{"mandatory": 6, "optional": 1, uncategorized: 1}, report.ByCategory)
+	assert.Equal(t, map[string]int{"eap8": 7, "quarkus": 1}, report.ByRuleSet)
+}
+
+func TestWriteEffortReport(t *testing.T) {
+	output := t.TempDir()
+	a := &analyzeCommand{
+		output:       output,
+		effortReport: true,
+		effortToDays: 2,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	require.NoError(t, a.validateEffortReport())
+	require.NoError(t, a.writeEffortReport(effortRulesets()))
+
+	data, err := os.ReadFile(filepath.Join(output, effortReportFile))
+	require.NoError(t, err)
+	report := effortReport{}
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, 8, report.TotalEffort)
+	assert.Equal(t, 16.0, report.EstimatedDays)
+
+	a.effortToDays = 0
+	assert.Error(t, a.validateEffortReport())
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/engine"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/progress"
+)
+
+// partialResults records the rules as they match, the engine only returns
+// results once every rule ran. Only the rule and its number of incidents are
+// kept, not a second copy of every incident.
+type partialResults struct {
+	mu       sync.Mutex
+	rulesets []*partialRuleSet
+	byName   map[string]*partialRuleSet
+}
+
+type partialRuleSet struct {
+	name  string
+	rules []partialRule
+}
+
+type partialRule struct {
+	rule      engine.Rule
+	incidents int
+}
+
+func (p *partialResults) add(ruleSet string, rule engine.Rule, incidents int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.byName == nil {
+		p.byName = map[string]*partialRuleSet{}
+	}
+	rs, ok := p.byName[ruleSet]
+	if !ok {
+		rs = &partialRuleSet{name: ruleSet}
+		p.byName[ruleSet] = rs
+		p.rulesets = append(p.rulesets, rs)
+	}
+	rs.rules = append(rs.rules, partialRule{rule: rule, incidents: incidents})
+}
+
+// snapshot returns the rules matched so far as violations without
+// incidents, and the number of incidents of each rule ID
+func (p *partialResults) snapshot() ([]outputv1.RuleSet, map[string]int) {
+	rulesets := []outputv1.RuleSet{}
+	counts := map[string]int{}
+	if p == nil {
+		return rulesets, counts
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, rs := range p.rulesets {
+		ruleset := outputv1.RuleSet{
+			Name:       rs.name,
+			Violations: map[string]outputv1.Violation{},
+			Insights:   map[string]outputv1.Violation{},
+		}
+		for _, matched := range rs.rules {
+			violation := outputv1.Violation{
+				Description: matched.rule.Description,
+				Category:    matched.rule.Category,
+				Labels:      matched.rule.Labels,
+				Effort:      matched.rule.Effort,
+				Incidents:   []outputv1.Incident{},
+			}
+			// as the engine, rules without effort are insights
+			if matched.rule.Effort == nil || *matched.rule.Effort == 0 {
+				ruleset.Insights[matched.rule.RuleID] = violation
+			} else {
+				ruleset.Violations[matched.rule.RuleID] = violation
+			}
+			counts[matched.rule.RuleID] = matched.incidents
+		}
+		rulesets = append(rulesets, ruleset)
+	}
+	return rulesets, counts
+}
+
+// partialResultsConditional records the incidents of a rule when its
+// conditions match
+type partialResultsConditional struct {
+	when    engine.Conditional
+	ruleSet string
+	rule    engine.Rule
+	results *partialResults
+}
+
+func (c *partialResultsConditional) Evaluate(ctx context.Context, log logr.Logger, condCtx engine.ConditionContext) (engine.ConditionResponse, error) {
+	response, err := c.when.Evaluate(ctx, log, condCtx)
+	if err == nil && response.Matched && len(response.Incidents) > 0 {
+		c.results.add(c.ruleSet, c.rule, len(response.Incidents))
+	}
+	return response, err
+}
+
+// collectPartialResults wraps the conditions of every rule to record it as
+// it matches, written by flushOnPanic when the engine panics before
+// returning any result. It wraps the --rule-timeout conditions so rules that
+// time out aren't recorded.
+func (a *analyzeCommand) collectPartialResults(ruleSets []engine.RuleSet) {
+	a.partialResults = &partialResults{}
+	for i := range ruleSets {
+		for j, rule := range ruleSets[i].Rules {
+			if rule.When == nil {
+				continue
+			}
+			ruleSets[i].Rules[j].When = &partialResultsConditional{
+				when:    rule.When,
+				ruleSet: ruleSets[i].Name,
+				rule:    rule,
+				results: a.partialResults,
+			}
+		}
+	}
+}
+
+// flushOnPanic is deferred by the analysis before running the rules. On a
+// panic of the engine, e.g. on a malformed rule, or of the processing of its
+// results, the results collected until then are written to output.yaml and
+// err is set to an EnginePanicError instead of losing every result. When the
+// engine didn't return, the rules recorded by collectPartialResults are
+// written without their incidents, the number of incidents of each rule is
+// logged.
+//
+// Only panics on the analysis goroutine are recovered. A panic in an engine
+// worker goroutine evaluating rule conditions can't be recovered here, it
+// still crashes kantra without writing any result.
+func (a *analyzeCommand) flushOnPanic(rulesets *[]outputv1.RuleSet, reporter progress.ProgressReporter, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	panicErr := &EnginePanicError{Err: fmt.Errorf("rule engine panicked: %v", r)}
+	results := *rulesets
+	keysAndValues := []interface{}{}
+	if len(results) == 0 {
+		var incidents map[string]int
+		results, incidents = a.partialResults.snapshot()
+		keysAndValues = append(keysAndValues, "incidents", incidents)
+	}
+	keysAndValues = append(keysAndValues, "rulesets", len(results), "stack", string(debug.Stack()))
+	if progress, ok := reporter.(*ruleProgressReporter); ok {
+		current, total := progress.snapshot()
+		keysAndValues = append(keysAndValues, "rulesEvaluated", current, "rulesTotal", total)
+	}
+	a.log.Error(panicErr, "writing partial results", keysAndValues...)
+	if writeErr := a.writePartialOutput(results); writeErr != nil {
+		a.log.Error(writeErr, "failed to write partial results")
+	}
+	*err = panicErr
+}
+
+// writePartialOutput writes the rulesets collected before a panic to
+// output.yaml, redacted with --redact
+func (a *analyzeCommand) writePartialOutput(rulesets []outputv1.RuleSet) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panicked writing partial results: %v", r)
+		}
+	}()
+	return a.writeYAMLOutput(a.redactIncidents(rulesets))
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/engine"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+// panickingConditional panics like the condition of a malformed rule
+type panickingConditional struct{}
+
+func (panickingConditional) Evaluate(context.Context, logr.Logger, engine.ConditionContext) (engine.ConditionResponse, error) {
+	panic("malformed rule")
+}
+
+func TestFlushOnPanic(t *testing.T) {
+	output := t.TempDir()
+	a := &analyzeCommand{
+		output: output,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	message := "found java"
+	// tagging rules run on the calling goroutine, the rules using tags run
+	// after the others
+	ruleSets := []engine.RuleSet{
+		{
+			Name: "custom",
+			Rules: []engine.Rule{
+				{
+					RuleMeta: engine.RuleMeta{RuleID: "custom-00001"},
+					Perform:  engine.Perform{Message: engine.Message{Text: &message}, Tag: []string{"Java"}},
+					When:     matchingConditional{},
+				},
+				{
+					RuleMeta: engine.RuleMeta{RuleID: "malformed-00001", UsesHasTags: true},
+					Perform:  engine.Perform{Tag: []string{"Malformed"}},
+					When:     panickingConditional{},
+				},
+			},
+		},
+	}
+
+	run := func() (err error) {
+		eng := engine.CreateRuleEngine(context.Background(), 1, logr.Discard())
+		defer eng.Stop()
+		rulesets := []outputv1.RuleSet{}
+		defer a.flushOnPanic(&rulesets, &ruleProgressReporter{}, &err)
+		a.collectPartialResults(ruleSets)
+		rulesets = eng.RunRulesWithOptions(context.Background(), ruleSets, nil)
+		return nil
+	}
+	err := run()
+	var panicErr *EnginePanicError
+	require.True(t, errors.As(err, &panicErr), "expected an EnginePanicError, got %v", err)
+	assert.Contains(t, err.Error(), "malformed rule")
+
+	content, err := os.ReadFile(filepath.Join(output, "output.yaml"))
+	require.NoError(t, err)
+	partial := []outputv1.RuleSet{}
+	require.NoError(t, yaml.Unmarshal(content, &partial))
+	require.Len(t, partial, 1)
+	assert.Equal(t, "custom", partial[0].Name)
+	require.Contains(t, partial[0].Insights, "custom-00001")
+	assert.Empty(t, partial[0].Insights["custom-00001"].Incidents, "only the incident counts are kept")
+}
+
+func TestPartialResultsSnapshot(t *testing.T) {
+	effort := 3
+	p := &partialResults{}
+	p.add("custom", engine.Rule{RuleMeta: engine.RuleMeta{RuleID: "custom-00001", Effort: &effort}}, 2)
+	p.add("custom", engine.Rule{RuleMeta: engine.RuleMeta{RuleID: "custom-00002"}}, 1)
+	rulesets, incidents := p.snapshot()
+	require.Len(t, rulesets, 1)
+	assert.Contains(t, rulesets[0].Violations, "custom-00001")
+	assert.Contains(t, rulesets[0].Insights, "custom-00002")
+	assert.Equal(t, map[string]int{"custom-00001": 2, "custom-00002": 1}, incidents)
+
+	rulesets, incidents = (*partialResults)(nil).snapshot()
+	assert.Empty(t, rulesets)
+	assert.Empty(t, incidents)
+}
+
+func TestFlushOnPanicWithoutPanic(t *testing.T) {
+	output := t.TempDir()
+	a := &analyzeCommand{
+		output: output,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	run := func() (err error) {
+		rulesets := []outputv1.RuleSet{{Name: "custom"}}
+		defer a.flushOnPanic(&rulesets, nil, &err)
+		return nil
+	}
+	assert.NoError(t, run())
+	assert.NoFileExists(t, filepath.Join(output, "output.yaml"))
+}
@@ -0,0 +1,76 @@
+package cmd
+
+// The errors below let callers of Analyze tell failures apart with
+// errors.As, their messages are the ones of the wrapped errors.
+
+// ValidationError is returned when the analysis options or the analysis
+// requirements, e.g. java and maven in containerless mode, are invalid
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ProviderInitError is returned when a provider fails to start
+type ProviderInitError struct {
+	Provider string
+	Err      error
+}
+
+func (e *ProviderInitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ProviderInitError) Unwrap() error {
+	return e.Err
+}
+
+// RuleParseError is returned when rules fail to load, with --strict-rules
+// or when their includes can't be resolved
+type RuleParseError struct {
+	Path string
+	Err  error
+}
+
+func (e *RuleParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RuleParseError) Unwrap() error {
+	return e.Err
+}
+
+// OutputWriteError is returned when an analysis output file can't be written
+type OutputWriteError struct {
+	Path string
+	Err  error
+}
+
+func (e *OutputWriteError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *OutputWriteError) Unwrap() error {
+	return e.Err
+}
+
+// EnginePanicError is returned when the rule engine, or the processing of its
+// results, panics. The results collected until then are written to
+// output.yaml.
+type EnginePanicError struct {
+	Err error
+}
+
+func (e *EnginePanicError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *EnginePanicError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/parser"
+	"github.com/konveyor/analyzer-lsp/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingProvider fails to start, the other provider methods are not called
+type failingProvider struct {
+	provider.InternalProviderClient
+}
+
+func (p *failingProvider) ProviderInit(context.Context, []provider.InitConfig) ([]provider.InitConfig, error) {
+	return nil, errors.New("language server not found")
+}
+
+func TestValidateReturnsValidationError(t *testing.T) {
+	tmpDir := t.TempDir()
+	a := &analyzeCommand{
+		input:                 tmpDir,
+		output:                filepath.Join(tmpDir, "output"),
+		mode:                  "source-only",
+		depsOnly:              true,
+		enableDefaultRulesets: true,
+		AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+	}
+	err := a.Validate(context.Background(), nil)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.EqualError(t, err, "--deps-only requires 'full' analysis mode")
+}
+
+func TestRunAnalysisContainerlessReturnsValidationError(t *testing.T) {
+	currentDir, err := os.Getwd()
+	require.NoError(t, err)
+	a := &analyzeCommand{
+		input:                 currentDir,
+		output:                t.TempDir(),
+		noProgress:            true,
+		AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+	}
+	err = a.RunAnalysisContainerless(context.Background())
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.EqualError(t, err, "input path "+currentDir+" cannot be the current directory")
+}
+
+func TestStartProvidersReturnsProviderInitError(t *testing.T) {
+	a := &analyzeCommand{AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+	err := a.startProvidersContainerless(context.Background(), map[string]provider.InternalProviderClient{
+		"java": &failingProvider{},
+	})
+	var initErr *ProviderInitError
+	require.True(t, errors.As(err, &initErr))
+	assert.Equal(t, "java", initErr.Provider)
+	assert.EqualError(t, err, "unable to init provider java: language server not found")
+
+	err = a.startProvidersContainerless(context.Background(), map[string]provider.InternalProviderClient{
+		"builtin": &failingProvider{},
+	})
+	require.True(t, errors.As(err, &initErr))
+	assert.Equal(t, "builtin", initErr.Provider)
+}
+
+func TestLoadRulesReturnsRuleParseError(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing")
+	brokenInclude := filepath.Join(dir, "rules.yaml")
+	require.NoError(t, os.WriteFile(brokenInclude, []byte("- ruleID: rule-00001\n  when: !include when.yaml\n"), 0644))
+	ruleParser := parser.RuleParser{Log: logr.Discard()}
+
+	a := &analyzeCommand{rules: []string{missing}, AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+	_, _, _, err := a.loadRulesContainerless(ruleParser, logr.Discard())
+	assert.NoError(t, err, "rules failing to parse are skipped without --strict-rules")
+
+	a.strictRules = true
+	_, _, _, err = a.loadRulesContainerless(ruleParser, logr.Discard())
+	var parseErr *RuleParseError
+	require.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, missing, parseErr.Path)
+
+	a.rules = []string{brokenInclude}
+	_, _, _, err = a.loadRulesContainerless(ruleParser, logr.Discard())
+	require.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, brokenInclude, parseErr.Path)
+}
+
+func TestWriteAnalysisOutputReturnsOutputWriteError(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "missing")
+	a := &analyzeCommand{output: output, AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+	err := a.writeAnalysisOutput([]outputv1.RuleSet{{Name: "ruleset"}})
+	var writeErr *OutputWriteError
+	require.True(t, errors.As(err, &writeErr))
+	assert.Equal(t, filepath.Join(output, "output.yaml"), writeErr.Path)
+	assert.ErrorContains(t, err, "failed to write output.yaml")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
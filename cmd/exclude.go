@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
+)
+
+// compileExcludePatterns compiles --exclude glob patterns
+func compileExcludePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		re, err := util.GlobToRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// excludedDirs returns the excludedDirs provider config value for the given
+// input location. It contains the profiles dir, if present, the user given
+// --exclude patterns, the files above --max-file-size and the directories
+// below --max-depth. Relative paths and patterns are made absolute so the
+// providers can skip them while walking the input; glob patterns are then
+// converted to regexps as the providers match patterns as Go regexps.
+func (a *analyzeCommand) excludedDirs(location string, useContainerPath bool) []interface{} {
+	excluded := []interface{}{}
+	if excludedDir := util.GetProfilesExcludedDir(a.input, useContainerPath); excludedDir != "" {
+		excluded = append(excluded, excludedDir)
+	}
+	for _, pattern := range a.excludePatterns {
+		if !filepath.IsAbs(pattern) {
+			if useContainerPath {
+				pattern = path.Join(location, filepath.ToSlash(pattern))
+			} else {
+				pattern = filepath.Join(location, pattern)
+			}
+		}
+		if !util.IsGlobPattern(pattern) {
+			excluded = append(excluded, pattern)
+			continue
+		}
+		re, err := util.GlobToRegexp(pattern)
+		if err != nil {
+			// patterns are validated before analysis starts
+			continue
+		}
+		excluded = append(excluded, re.String())
+	}
+	excluded = append(excluded, a.oversizedFilePaths(location, useContainerPath)...)
+	return append(excluded, a.tooDeepDirPaths(location, useContainerPath)...)
+}
+
+// filterExcludedIncidents drops incidents under paths matching --exclude
+// patterns. Violations left without incidents are removed.
+func (a *analyzeCommand) filterExcludedIncidents(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if len(a.excludePatterns) == 0 {
+		return rulesets
+	}
+	patterns, err := compileExcludePatterns(a.excludePatterns)
+	if err != nil {
+		// patterns are validated before analysis starts
+		a.log.Error(err, "failed to compile exclude patterns")
+		return rulesets
+	}
+	roots := a.incidentRoots()
+	return filterIncidents(rulesets, func(incidentURI uri.URI) bool {
+		return !uriMatchesAny(incidentURI, roots, patterns)
+	})
+}
+
+// incidentRoots returns the directories incident paths are made relative to
+// before matching patterns, on the host and in the provider containers
+func (a *analyzeCommand) incidentRoots() []string {
+	if a.isFileInput {
+		return []string{filepath.Dir(a.input), filepath.Dir(util.SourceMountPath)}
+	}
+	return []string{a.input, util.SourceMountPath}
+}
+
+// filterIncidents keeps the incidents for which keep returns true.
+// Violations left without incidents are removed.
+func filterIncidents(rulesets []outputv1.RuleSet, keep func(uri.URI) bool) []outputv1.RuleSet {
+	for i := range rulesets {
+		for ruleID, violation := range rulesets[i].Violations {
+			incidents := []outputv1.Incident{}
+			for _, incident := range violation.Incidents {
+				if !keep(incident.URI) {
+					continue
+				}
+				incidents = append(incidents, incident)
+			}
+			if len(incidents) == 0 && len(violation.Incidents) > 0 {
+				delete(rulesets[i].Violations, ruleID)
+				continue
+			}
+			violation.Incidents = incidents
+			rulesets[i].Violations[ruleID] = violation
+		}
+	}
+	return rulesets
+}
+
+// uriMatchesAny reports whether the path of a file URI, relative to one of
+// roots, matches any of patterns. Non file URIs never match.
+func uriMatchesAny(incidentURI uri.URI, roots []string, patterns []*regexp.Regexp) bool {
+	if !strings.HasPrefix(string(incidentURI), "file:") {
+		return false
+	}
+	incidentPath := incidentURI.Filename()
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, incidentPath)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if util.MatchesAnyGlob(patterns, rel) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+)
+
+func TestFilterExcludedIncidents(t *testing.T) {
+	input := t.TempDir()
+	a := &analyzeCommand{
+		input:           input,
+		excludePatterns: []string{"vendor", "**/node_modules"},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+
+	fileURI := func(rel string) uri.URI {
+		return uri.File(filepath.Join(input, rel))
+	}
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "test",
+			Violations: map[string]outputv1.Violation{
+				"mixed": {
+					Incidents: []outputv1.Incident{
+						{URI: fileURI("src/Main.java")},
+						{URI: fileURI("vendor/lib/Lib.java")},
+						{URI: fileURI("web/node_modules/pkg/index.js")},
+					},
+				},
+				"only-excluded": {
+					Incidents: []outputv1.Incident{
+						{URI: fileURI("node_modules/pkg/index.js")},
+					},
+				},
+			},
+		},
+	}
+
+	got := a.filterExcludedIncidents(rulesets)
+	require.Len(t, got, 1)
+	require.Contains(t, got[0].Violations, "mixed")
+	assert.NotContains(t, got[0].Violations, "only-excluded")
+	incidents := got[0].Violations["mixed"].Incidents
+	require.Len(t, incidents, 1)
+	assert.Equal(t, fileURI("src/Main.java"), incidents[0].URI)
+}
+
+func TestExcludedDirs(t *testing.T) {
+	a := &analyzeCommand{
+		input:           "/app",
+		excludePatterns: []string{"vendor", "**/node_modules"},
+	}
+
+	assert.Equal(t, []interface{}{filepath.Join("/app", "vendor"), `^/app/(?:.*/)?node_modules$`}, a.excludedDirs("/app", false))
+	assert.Equal(t, []interface{}{"/opt/input/source/vendor", `^/opt/input/source/(?:.*/)?node_modules$`}, a.excludedDirs("/opt/input/source", true))
+
+	a.excludePatterns = nil
+	assert.Empty(t, a.excludedDirs("/app", false))
+}
+
+func TestExcludedDirsFileSearch(t *testing.T) {
+	input := t.TempDir()
+	for _, file := range []string{
+		"src/Main.java",
+		"src/main/Util.java",
+		"src/README.md",
+		"vendor/lib/Lib.java",
+		"web/node_modules/pkg/index.js",
+		"node_modules/pkg/index.js",
+		"web/app.js",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(input, filepath.Dir(file)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(input, file), []byte{}, 0644))
+	}
+	a := &analyzeCommand{
+		input:           input,
+		excludePatterns: []string{"vendor", "**/node_modules", "src/*.java"},
+	}
+	excluded := []string{}
+	for _, dir := range a.excludedDirs(input, false) {
+		excluded = append(excluded, dir.(string))
+	}
+
+	searcher := provider.FileSearcher{
+		BasePath: input,
+		ProviderConfigConstraints: provider.IncludeExcludeConstraints{
+			ExcludePathsOrPatterns: excluded,
+		},
+		Log: logr.Discard(),
+	}
+	files, err := searcher.Search(provider.SearchCriteria{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(input, "src/main/Util.java"),
+		filepath.Join(input, "src/README.md"),
+		filepath.Join(input, "web/app.js"),
+	}, files)
+}
+
+func TestCompileExcludePatterns(t *testing.T) {
+	_, err := compileExcludePatterns([]string{"vendor", "**/*.min.js"})
+	assert.NoError(t, err)
+	_, err = compileExcludePatterns([]string{""})
+	assert.Error(t, err)
+}
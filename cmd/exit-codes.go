@@ -0,0 +1,42 @@
+package cmd
+
+import "errors"
+
+// Exit codes of kantra, documented in the README so CI can tell failures
+// apart. Errors that aren't classified exit with exitCodeInternal.
+const (
+	exitCodeSuccess    = 0
+	exitCodeValidation = 2
+	exitCodeProvider   = 3
+	exitCodeRuleParse  = 4
+	// exitCodeFailOn is the exit code when incidents meet the --fail-on
+	// threshold
+	exitCodeFailOn   = 5
+	exitCodeInternal = 70
+)
+
+// exitCode returns the exit code for the error a command returned
+func exitCode(err error) int {
+	if err == nil {
+		return exitCodeSuccess
+	}
+	// only kantra's own errors set the exit code, a wrapped *exec.ExitError
+	// of git or a container tool must not leak its exit code
+	var failOnErr *failOnError
+	if errors.As(err, &failOnErr) {
+		return exitCodeFailOn
+	}
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return exitCodeValidation
+	}
+	var providerErr *ProviderInitError
+	if errors.As(err, &providerErr) {
+		return exitCodeProvider
+	}
+	var ruleParseErr *RuleParseError
+	if errors.As(err, &ruleParseErr) {
+		return exitCodeRuleParse
+	}
+	return exitCodeInternal
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/parser"
+	"github.com/konveyor/analyzer-lsp/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	tmpDir := t.TempDir()
+	mandatory := outputv1.Mandatory
+	invalidRules := filepath.Join(tmpDir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidRules, []byte("- ruleID: [\n"), 0644))
+
+	tests := []struct {
+		name string
+		run  func() error
+		want int
+	}{
+		{
+			name: "success",
+			run:  func() error { return nil },
+			want: exitCodeSuccess,
+		},
+		{
+			name: "validation error",
+			run: func() error {
+				a := &analyzeCommand{
+					input:                 tmpDir,
+					output:                filepath.Join(tmpDir, "output"),
+					mode:                  "source-only",
+					depsOnly:              true,
+					enableDefaultRulesets: true,
+					AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+				}
+				return a.Validate(context.Background(), nil)
+			},
+			want: exitCodeValidation,
+		},
+		{
+			name: "unknown flag",
+			run: func() error {
+				return rootCmd.FlagErrorFunc()(rootCmd, errors.New("unknown flag: --inptu"))
+			},
+			want: exitCodeValidation,
+		},
+		{
+			name: "provider error",
+			run: func() error {
+				a := &analyzeCommand{AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+				return a.startProvidersContainerless(context.Background(), map[string]provider.InternalProviderClient{
+					"java": &failingProvider{},
+				})
+			},
+			want: exitCodeProvider,
+		},
+		{
+			name: "rule parse error",
+			run: func() error {
+				a := &analyzeCommand{
+					rules:                 []string{filepath.Join(tmpDir, "missing")},
+					strictRules:           true,
+					AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+				}
+				_, _, _, err := a.loadRulesContainerless(parser.RuleParser{Log: logr.Discard()}, logr.Discard())
+				return err
+			},
+			want: exitCodeRuleParse,
+		},
+		{
+			name: "invalid rules of validate-rules",
+			run: func() error {
+				v := &validateRulesCommand{rules: []string{invalidRules}, log: logr.Discard()}
+				return v.Run(io.Discard)
+			},
+			want: exitCodeRuleParse,
+		},
+		{
+			name: "fail-on incidents",
+			run: func() error {
+				return checkFailOn([]outputv1.RuleSet{{
+					Name: "ruleset",
+					Violations: map[string]outputv1.Violation{
+						"rule-00001": {Category: &mandatory, Incidents: []outputv1.Incident{{URI: "file:///src/Main.java"}}},
+					},
+				}}, "mandatory")
+			},
+			want: exitCodeFailOn,
+		},
+		{
+			name: "internal error",
+			run: func() error {
+				a := &analyzeCommand{output: filepath.Join(tmpDir, "missing"), AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+				return a.writeAnalysisOutput([]outputv1.RuleSet{{Name: "ruleset"}})
+			},
+			want: exitCodeInternal,
+		},
+		{
+			name: "failed container tool",
+			run: func() error {
+				err := exec.Command("sh", "-c", "exit 3").Run()
+				return fmt.Errorf("failed to run container: %w", err)
+			},
+			want: exitCodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCode(tt.run()))
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/provider"
+	"go.lsp.dev/uri"
+)
+
+// extensionMapLanguages are the languages --extension-map can map to, with
+// the extension their provider analyzes
+var extensionMapLanguages = map[string]string{
+	util.JavaProvider: ".java",
+}
+
+// parseExtensionMap parses --extension-map values of the form .ext=language
+// into a map of extension to the extension of the language
+func parseExtensionMap(values []string) (map[string]string, error) {
+	extensions := map[string]string{}
+	for _, value := range values {
+		ext, language, ok := strings.Cut(value, "=")
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		language = strings.ToLower(strings.TrimSpace(language))
+		if !ok || ext == "" || language == "" {
+			return nil, fmt.Errorf("invalid extension map %q, must be .ext=language", value)
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		target, ok := extensionMapLanguages[language]
+		if !ok {
+			return nil, fmt.Errorf("unsupported language %q for extension map %s, must be one of %s",
+				language, value, strings.Join(slices.Sorted(maps.Keys(extensionMapLanguages)), ", "))
+		}
+		if ext == target {
+			return nil, fmt.Errorf("extension map %s maps %s to itself", value, ext)
+		}
+		if previous, ok := extensions[ext]; ok && previous != target {
+			return nil, fmt.Errorf("extension %s is mapped more than once", ext)
+		}
+		extensions[ext] = target
+	}
+	return extensions, nil
+}
+
+// stageMappedFiles copies the input files with an --extension-map extension
+// to a temp dir, renamed to the extension of their language, which is
+// analyzed next to the input. The providers only analyze files with the
+// extensions of their language.
+func (a *analyzeCommand) stageMappedFiles() error {
+	extensions, err := parseExtensionMap(a.extensionMap)
+	if err != nil {
+		return err
+	}
+	if len(extensions) == 0 {
+		return nil
+	}
+	if a.isFileInput {
+		a.log.Info("WARNING: --extension-map is ignored for binary input")
+		return nil
+	}
+	tempDir, err := os.MkdirTemp("", "analyze-mapped-sources-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir for mapped sources: %w", err)
+	}
+	a.tempDirs = append(a.tempDirs, tempDir)
+	a.mappedFiles = map[string]string{}
+	err = filepath.WalkDir(a.input, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		target, ok := extensions[strings.ToLower(filepath.Ext(filePath))]
+		if !ok || !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(a.input, filePath)
+		if err != nil {
+			return err
+		}
+		staged := filepath.Join(tempDir, strings.TrimSuffix(rel, filepath.Ext(rel))+target)
+		if _, err := os.Stat(staged); err == nil {
+			a.log.Info("WARNING: skipping mapped file, another file maps to the same name", "file", rel)
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(staged), 0755); err != nil {
+			return err
+		}
+		if err := util.CopyFileContents(filePath, staged); err != nil {
+			return err
+		}
+		a.mappedFiles[staged] = filePath
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stage mapped files: %w", err)
+	}
+	if len(a.mappedFiles) == 0 {
+		a.log.Info("WARNING: no input files match --extension-map", "extensionMap", a.extensionMap)
+		return nil
+	}
+	a.log.V(1).Info("staged files for --extension-map", "dir", tempDir, "files", len(a.mappedFiles))
+	a.mappedSourcesDir = tempDir
+	return nil
+}
+
+// mappedSourcesInitConfig returns the provider init config analyzing the
+// staged --extension-map files, based on the config of the input. Paths of
+// the input config don't apply to the staged files.
+func (a *analyzeCommand) mappedSourcesInitConfig(base provider.InitConfig) provider.InitConfig {
+	config := maps.Clone(base.ProviderSpecificConfig)
+	delete(config, "excludedDirs")
+	delete(config, provider.IncludedPathsConfigKey)
+	return provider.InitConfig{
+		Location:               a.mappedSourcesDir,
+		AnalysisMode:           provider.SourceOnlyAnalysisMode,
+		ProviderSpecificConfig: config,
+	}
+}
+
+// remapMappedIncidents points the incidents of staged --extension-map files
+// back to the input files
+func (a *analyzeCommand) remapMappedIncidents(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if len(a.mappedFiles) == 0 {
+		return rulesets
+	}
+	remap := func(violations map[string]outputv1.Violation) {
+		for _, violation := range violations {
+			for j, incident := range violation.Incidents {
+				if !strings.HasPrefix(string(incident.URI), "file:") {
+					continue
+				}
+				if original, ok := a.mappedFiles[incident.URI.Filename()]; ok {
+					violation.Incidents[j].URI = uri.File(original)
+				}
+			}
+		}
+	}
+	for i := range rulesets {
+		remap(rulesets[i].Violations)
+		remap(rulesets[i].Insights)
+	}
+	return rulesets
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+)
+
+func TestParseExtensionMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "no map", want: map[string]string{}},
+		{name: "extension", values: []string{".tmpl=java"}, want: map[string]string{".tmpl": ".java"}},
+		{name: "without dot and in upper case", values: []string{"JSPF=Java"}, want: map[string]string{".jspf": ".java"}},
+		{name: "unsupported language", values: []string{".tmpl=cobol"}, wantErr: true},
+		{name: "missing language", values: []string{".tmpl"}, wantErr: true},
+		{name: "language extension", values: []string{".java=java"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExtensionMap(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStageMappedFiles(t *testing.T) {
+	input := t.TempDir()
+	template := filepath.Join(input, "src", "Service.tmpl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(template), 0755))
+	require.NoError(t, os.WriteFile(template, []byte("public class Service {}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(input, "src", "Main.java"), []byte("public class Main {}"), 0644))
+
+	a := &analyzeCommand{
+		input:                 input,
+		extensionMap:          []string{".tmpl=java"},
+		AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+	}
+	require.NoError(t, a.stageMappedFiles())
+	require.Len(t, a.tempDirs, 1)
+	staged := filepath.Join(a.mappedSourcesDir, "src", "Service.java")
+	assert.FileExists(t, staged)
+	assert.NoFileExists(t, filepath.Join(a.mappedSourcesDir, "src", "Main.java"))
+	assert.Equal(t, map[string]string{staged: template}, a.mappedFiles)
+
+	config := a.mappedSourcesInitConfig(provider.InitConfig{
+		Location:               input,
+		ProviderSpecificConfig: map[string]interface{}{"lspServerName": "java", "excludedDirs": []interface{}{input}},
+	})
+	assert.Equal(t, a.mappedSourcesDir, config.Location)
+	assert.Equal(t, provider.SourceOnlyAnalysisMode, config.AnalysisMode)
+	assert.Equal(t, map[string]interface{}{"lspServerName": "java"}, config.ProviderSpecificConfig)
+
+	rulesets := a.remapMappedIncidents([]outputv1.RuleSet{{
+		Name: "ruleset",
+		Violations: map[string]outputv1.Violation{
+			"rule-00001": {Incidents: []outputv1.Incident{
+				{URI: uri.File(staged)},
+				{URI: uri.File(filepath.Join(input, "src", "Main.java"))},
+			}},
+		},
+		Insights: map[string]outputv1.Violation{
+			"rule-00002": {Incidents: []outputv1.Incident{{URI: uri.File(staged)}}},
+		},
+	}})
+	incidents := rulesets[0].Violations["rule-00001"].Incidents
+	assert.Equal(t, uri.File(template), incidents[0].URI)
+	assert.Equal(t, uri.File(filepath.Join(input, "src", "Main.java")), incidents[1].URI)
+	assert.Equal(t, uri.File(template), rulesets[0].Insights["rule-00002"].Incidents[0].URI)
+}
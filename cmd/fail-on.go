@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+const failOnNone = "none"
+
+// categorySeverity orders violation categories, higher is more severe
+var categorySeverity = map[outputv1.Category]int{
+	outputv1.Potential: 1,
+	outputv1.Optional:  2,
+	outputv1.Mandatory: 3,
+}
+
+// failOnError is returned when the analysis found incidents at or above the
+// --fail-on severity threshold
+type failOnError struct {
+	threshold string
+	incidents int
+}
+
+func (e *failOnError) Error() string {
+	return fmt.Sprintf("found %d incidents at or above severity '%s'", e.incidents, e.threshold)
+}
+
+func validateFailOn(failOn string) error {
+	if failOn == "" || failOn == failOnNone {
+		return nil
+	}
+	if _, ok := categorySeverity[outputv1.Category(failOn)]; !ok {
+		return fmt.Errorf("fail-on must be one of 'mandatory', 'optional', 'potential' or 'none'")
+	}
+	return nil
+}
+
+// checkFailOn returns a failOnError when any incident meets the severity
+// threshold. Violations without a category are potential.
+func checkFailOn(rulesets []outputv1.RuleSet, failOn string) error {
+	if failOn == "" || failOn == failOnNone {
+		return nil
+	}
+	threshold := categorySeverity[outputv1.Category(failOn)]
+	incidents := 0
+	for _, ruleset := range rulesets {
+		for _, violation := range ruleset.Violations {
+			category := outputv1.Potential
+			if violation.Category != nil {
+				category = *violation.Category
+			}
+			if categorySeverity[category] >= threshold {
+				incidents += len(violation.Incidents)
+			}
+		}
+	}
+	if incidents == 0 {
+		return nil
+	}
+	return &failOnError{threshold: failOn, incidents: incidents}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFailOn(t *testing.T) {
+	for _, failOn := range []string{"", "none", "mandatory", "optional", "potential"} {
+		assert.NoError(t, validateFailOn(failOn), failOn)
+	}
+	assert.Error(t, validateFailOn("critical"))
+}
+
+func TestCheckFailOn(t *testing.T) {
+	mandatory := outputv1.Mandatory
+	optional := outputv1.Optional
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "ruleset",
+			Violations: map[string]outputv1.Violation{
+				"mandatory-rule": {
+					Category:  &mandatory,
+					Incidents: []outputv1.Incident{{URI: "file:///a"}, {URI: "file:///b"}},
+				},
+				"optional-rule": {
+					Category:  &optional,
+					Incidents: []outputv1.Incident{{URI: "file:///c"}},
+				},
+				// no category is potential
+				"potential-rule": {
+					Incidents: []outputv1.Incident{{URI: "file:///d"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		rulesets      []outputv1.RuleSet
+		failOn        string
+		wantIncidents int
+	}{
+		{name: "none never fails", rulesets: rulesets, failOn: "none"},
+		{name: "empty never fails", rulesets: rulesets, failOn: ""},
+		{name: "mandatory", rulesets: rulesets, failOn: "mandatory", wantIncidents: 2},
+		{name: "optional includes mandatory", rulesets: rulesets, failOn: "optional", wantIncidents: 3},
+		{name: "potential includes all", rulesets: rulesets, failOn: "potential", wantIncidents: 4},
+		{name: "no incidents", rulesets: []outputv1.RuleSet{{Name: "empty"}}, failOn: "potential"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFailOn(tt.rulesets, tt.failOn)
+			if tt.wantIncidents == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var failErr *failOnError
+			require.True(t, errors.As(err, &failErr))
+			assert.Equal(t, tt.wantIncidents, failErr.incidents)
+			assert.Equal(t, exitCodeFailOn, exitCode(err))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// flagsConfigFileName is the config file holding default analyze flags
+const flagsConfigFileName = ".kantra.yaml"
+
+// findFlagsConfigFile looks for the config file in the working dir first,
+// then in $XDG_CONFIG_HOME/.kantra on linux and then in $HOME/.kantra
+func findFlagsConfigFile() (string, error) {
+	candidates := []string{}
+	if wd, err := os.Getwd(); err == nil {
+		candidates = append(candidates, filepath.Join(wd, flagsConfigFileName))
+	}
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); runtime.GOOS == "linux" && xdgConfigHome != "" {
+		candidates = append(candidates, filepath.Join(xdgConfigHome, ".kantra", flagsConfigFileName))
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	candidates = append(candidates, filepath.Join(homeDir, ".kantra", flagsConfigFileName))
+
+	for _, candidate := range candidates {
+		stat, err := os.Stat(candidate)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return "", err
+		}
+		if !stat.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+// applyFlagsConfig sets defaults for the command flags from the config file.
+// Keys mirror flag names. Flags set on the command line take precedence over
+// scalar values while array values are merged with the command line values.
+// Config values don't mark flags as changed, so profiles and other checks of
+// explicit flags still apply. Unknown keys are logged and ignored.
+func (a *analyzeCommand) applyFlagsConfig(cmd *cobra.Command) error {
+	configPath, err := findFlagsConfigFile()
+	if err != nil {
+		return fmt.Errorf("failed to look up %s config file: %w", flagsConfigFileName, err)
+	}
+	if configPath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+	return a.applyFlagsConfigData(cmd, configPath, data)
+}
+
+func (a *analyzeCommand) applyFlagsConfigData(cmd *cobra.Command, configPath string, data []byte) error {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", configPath, err)
+	}
+	a.log.V(1).Info("loading default flags from config file", "file", configPath)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		flag := cmd.Flags().Lookup(key)
+		if flag == nil {
+			a.log.Info("WARNING: ignoring unknown key in config file", "file", configPath, "key", key)
+			continue
+		}
+		isArray := flag.Value.Type() == "stringArray" || flag.Value.Type() == "stringSlice"
+		// explicit command line values win over config file scalars
+		if flag.Changed && !isArray {
+			continue
+		}
+		items, isList := values[key].([]interface{})
+		if !isList {
+			items = []interface{}{values[key]}
+		}
+		changed := flag.Changed
+		for _, item := range items {
+			if err := cmd.Flags().Set(key, fmt.Sprint(item)); err != nil {
+				return fmt.Errorf("invalid value for %s in config file %s: %w", key, configPath, err)
+			}
+		}
+		flag.Changed = changed
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFlagsConfigData(t *testing.T) {
+	config := []byte(`
+mode: source-only
+target:
+  - quarkus
+  - cloud-readiness
+context-lines: 5
+unknown-key: true
+`)
+
+	t.Run("config values are used as defaults", func(t *testing.T) {
+		cmd := NewAnalyzeCmd(logr.Discard())
+		require.NoError(t, cmd.ParseFlags([]string{}))
+		a := &analyzeCommand{AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+		require.NoError(t, a.applyFlagsConfigData(cmd, ".kantra.yaml", config))
+
+		mode, _ := cmd.Flags().GetString("mode")
+		assert.Equal(t, "source-only", mode)
+		targets, _ := cmd.Flags().GetStringArray("target")
+		assert.Equal(t, []string{"quarkus", "cloud-readiness"}, targets)
+		contextLines, _ := cmd.Flags().GetInt("context-lines")
+		assert.Equal(t, 5, contextLines)
+		// config values are defaults, not explicit flags
+		for _, name := range []string{"mode", "target", "context-lines"} {
+			assert.False(t, cmd.Flags().Lookup(name).Changed, name)
+		}
+	})
+
+	t.Run("command line values take precedence and arrays merge", func(t *testing.T) {
+		cmd := NewAnalyzeCmd(logr.Discard())
+		require.NoError(t, cmd.ParseFlags([]string{"--mode", "full", "--target", "eap8"}))
+		a := &analyzeCommand{AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+		require.NoError(t, a.applyFlagsConfigData(cmd, ".kantra.yaml", config))
+
+		mode, _ := cmd.Flags().GetString("mode")
+		assert.Equal(t, "full", mode)
+		targets, _ := cmd.Flags().GetStringArray("target")
+		assert.Equal(t, []string{"eap8", "quarkus", "cloud-readiness"}, targets)
+		assert.True(t, cmd.Flags().Lookup("mode").Changed)
+		assert.True(t, cmd.Flags().Lookup("target").Changed)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		cmd := NewAnalyzeCmd(logr.Discard())
+		require.NoError(t, cmd.ParseFlags([]string{}))
+		a := &analyzeCommand{AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+		assert.Error(t, a.applyFlagsConfigData(cmd, ".kantra.yaml", []byte("context-lines: many")))
+	})
+}
+
+func TestFindFlagsConfigFile(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("$XDG_CONFIG_HOME is only used on linux")
+	}
+	t.Chdir(t.TempDir())
+	home := t.TempDir()
+	xdgConfigHome := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+
+	path, err := findFlagsConfigFile()
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	// $HOME/.kantra is the fallback of $XDG_CONFIG_HOME/.kantra
+	homeConfig := filepath.Join(home, ".kantra", flagsConfigFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(homeConfig), 0755))
+	require.NoError(t, os.WriteFile(homeConfig, []byte("target: [quarkus]\n"), 0644))
+	path, err = findFlagsConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, homeConfig, path)
+
+	xdgConfig := filepath.Join(xdgConfigHome, ".kantra", flagsConfigFileName)
+	require.NoError(t, os.MkdirAll(filepath.Dir(xdgConfig), 0755))
+	require.NoError(t, os.WriteFile(xdgConfig, []byte("target: [quarkus]\n"), 0644))
+	path, err = findFlagsConfigFile()
+	require.NoError(t, err)
+	assert.Equal(t, xdgConfig, path)
+}
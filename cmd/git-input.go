@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// gitInputScheme prefixes an --input git repository, e.g.
+// git::https://github.com/org/repo.git//services/api@v2.0.0
+const gitInputScheme = "git::"
+
+// isGitInput returns true when input is a git repository to clone
+func isGitInput(input string) bool {
+	return strings.HasPrefix(input, gitInputScheme)
+}
+
+// parseGitInput splits a git:: input into the repository url, the optional
+// ref after the last '@' of the path and the optional subpath after a '//'
+// that follows the url scheme. The ref may contain '/', e.g. release/1.0, an
+// '@' before the path is the user of the url.
+func parseGitInput(input string) (repo, ref, subPath string, err error) {
+	repo = strings.TrimPrefix(input, gitInputScheme)
+	pathStart := 0
+	if scheme := strings.Index(repo, "://"); scheme >= 0 {
+		pathStart = len(repo)
+		if slash := strings.Index(repo[scheme+len("://"):], "/"); slash >= 0 {
+			pathStart = scheme + len("://") + slash
+		}
+	} else if colon := strings.Index(repo, ":"); colon >= 0 {
+		// scp-like syntax, user@host:path
+		pathStart = colon
+	}
+	if at := strings.LastIndex(repo[pathStart:], "@"); at >= 0 {
+		repo, ref = repo[:pathStart+at], repo[pathStart+at+1:]
+	}
+	start := 0
+	if scheme := strings.Index(repo, "://"); scheme >= 0 {
+		start = scheme + len("://")
+	}
+	if sep := strings.Index(repo[start:], "//"); sep >= 0 {
+		repo, subPath = repo[:start+sep], repo[start+sep+len("//"):]
+	}
+	if repo == "" {
+		return "", "", "", fmt.Errorf("invalid git input %s, must be %s<url>[//<subpath>][@<ref>]", input, gitInputScheme)
+	}
+	if strings.HasPrefix(ref, "-") || strings.HasPrefix(repo, "-") {
+		return "", "", "", fmt.Errorf("invalid git input %s", input)
+	}
+	if subPath != "" {
+		subPath = filepath.Clean(filepath.FromSlash(subPath))
+		if filepath.IsAbs(subPath) || subPath == ".." || strings.HasPrefix(subPath, ".."+string(filepath.Separator)) {
+			return "", "", "", fmt.Errorf("git input subpath %s must be relative to the repository", subPath)
+		}
+	}
+	return repo, ref, subPath, nil
+}
+
+// fetchGitInput shallow-clones the ref of the git:: input to a temp dir and
+// points a.input to it, or to its subpath. The clone is removed right away
+// when it fails.
+func (a *analyzeCommand) fetchGitInput(ctx context.Context) (err error) {
+	repo, ref, subPath, err := parseGitInput(a.input)
+	if err != nil {
+		return err
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("a %s input requires git, install git or use a local --input: %w", gitInputScheme, err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "analyze-git-input-")
+	if err != nil {
+		return fmt.Errorf("%w failed to create temp dir for git input", err)
+	}
+	a.log.V(1).Info("created directory for git input", "dir", tempDir)
+	a.tempDirs = append(a.tempDirs, tempDir)
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rmErr := os.RemoveAll(tempDir); rmErr != nil {
+			a.log.V(1).Error(rmErr, "failed to delete git input dir", "dir", tempDir)
+			return
+		}
+		a.tempDirs = a.tempDirs[:len(a.tempDirs)-1]
+	}()
+
+	// fetching the ref instead of cloning a branch also supports tags and
+	// commits, and doesn't check out the default branch first
+	if ref == "" {
+		ref = "HEAD"
+	}
+	a.log.Info("cloning git input", "repository", repo, "ref", ref)
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", repo},
+		{"fetch", "--quiet", "--depth", "1", "origin", ref},
+		{"-c", "advice.detachedHead=false", "checkout", "--quiet", "FETCH_HEAD"},
+	} {
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "git", append([]string{"-C", tempDir}, args...)...)
+		cmd.Stderr = &stderr
+		// never prompt for credentials, the analysis isn't interactive
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to clone %s at %s: %w: %s", repo, ref, err, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	input := filepath.Join(tempDir, subPath)
+	if _, err := os.Stat(input); err != nil {
+		return fmt.Errorf("%w subpath %s not found in git input %s", err, subPath, repo)
+	}
+	a.input = input
+	return nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		repo    string
+		ref     string
+		subPath string
+		wantErr bool
+	}{
+		{name: "https", input: "git::https://github.com/org/repo.git", repo: "https://github.com/org/repo.git"},
+		{name: "ref", input: "git::https://github.com/org/repo.git@v2.0.0", repo: "https://github.com/org/repo.git", ref: "v2.0.0"},
+		{name: "subpath and ref", input: "git::https://github.com/org/repo.git//services/api@main", repo: "https://github.com/org/repo.git", ref: "main", subPath: filepath.Join("services", "api")},
+		{name: "user in url", input: "git::https://user@github.com/org/repo.git", repo: "https://user@github.com/org/repo.git"},
+		{name: "scp-like", input: "git::git@github.com:org/repo.git", repo: "git@github.com:org/repo.git"},
+		{name: "scp-like with ref", input: "git::git@github.com:org/repo.git@v1", repo: "git@github.com:org/repo.git", ref: "v1"},
+		{name: "ref with slash", input: "git::https://host/repo.git@release/1.0", repo: "https://host/repo.git", ref: "release/1.0"},
+		{name: "subpath and ref with slash", input: "git::https://host/repo.git//services/api@release/1.0", repo: "https://host/repo.git", ref: "release/1.0", subPath: filepath.Join("services", "api")},
+		{name: "user in url and ref", input: "git::https://user@host/repo.git@v1", repo: "https://user@host/repo.git", ref: "v1"},
+		{name: "scp-like with ref with slash", input: "git::git@github.com:org/repo.git@release/1.0", repo: "git@github.com:org/repo.git", ref: "release/1.0"},
+		{name: "local path with ref", input: "git::/srv/repo.git@v1", repo: "/srv/repo.git", ref: "v1"},
+		{name: "empty", input: "git::", wantErr: true},
+		{name: "option ref", input: "git::https://github.com/org/repo.git@--upload-pack=x", wantErr: true},
+		{name: "escaping subpath", input: "git::https://github.com/org/repo.git//../etc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, ref, subPath, err := parseGitInput(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.repo, repo)
+			assert.Equal(t, tt.ref, ref)
+			assert.Equal(t, tt.subPath, subPath)
+		})
+	}
+}
+
+func TestFetchGitInput(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	git("init", "--quiet")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "services", "api"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "services", "api", "App.java"), []byte("v1"), 0644))
+	git("add", ".")
+	git("commit", "--quiet", "-m", "v1")
+	git("tag", "v1")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "services", "api", "App.java"), []byte("v2"), 0644))
+	git("commit", "--quiet", "-am", "v2")
+
+	a := &analyzeCommand{
+		input: "git::file://" + filepath.ToSlash(repo) + "//services/api@v1",
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	require.NoError(t, a.fetchGitInput(context.Background()))
+	require.Len(t, a.tempDirs, 1)
+	assert.Equal(t, filepath.Join(a.tempDirs[0], "services", "api"), a.input)
+	content, err := os.ReadFile(filepath.Join(a.input, "App.java"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(content))
+
+	a = &analyzeCommand{
+		input: "git::file://" + filepath.ToSlash(repo) + "@missing",
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	assert.Error(t, a.fetchGitInput(context.Background()))
+	assert.Empty(t, a.tempDirs)
+
+	a = &analyzeCommand{
+		input: "git::file://" + filepath.ToSlash(repo) + "//services/web",
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	assert.Error(t, a.fetchGitInput(context.Background()))
+	assert.Empty(t, a.tempDirs)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// escapers for GitHub Actions workflow command messages and properties, see
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+var (
+	annotationDataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	annotationPropEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// buildGitHubAnnotations returns a workflow command per incident, mandatory
+// incidents are errors and all others warnings
+func (a *analyzeCommand) buildGitHubAnnotations(rulesets []outputv1.RuleSet) []string {
+	annotations := []string{}
+	for _, ruleset := range rulesets {
+		ruleIDs := make([]string, 0, len(ruleset.Violations))
+		for ruleID := range ruleset.Violations {
+			ruleIDs = append(ruleIDs, ruleID)
+		}
+		sort.Strings(ruleIDs)
+		for _, ruleID := range ruleIDs {
+			violation := ruleset.Violations[ruleID]
+			level := "warning"
+			if violation.Category != nil && *violation.Category == outputv1.Mandatory {
+				level = "error"
+			}
+			for _, incident := range violation.Incidents {
+				props := []string{}
+				if isFileIncident(incident) {
+					props = append(props, "file="+annotationPropEscaper.Replace(a.incidentFile(incident)))
+					if incident.LineNumber != nil && *incident.LineNumber > 0 {
+						props = append(props, fmt.Sprintf("line=%d", *incident.LineNumber))
+					}
+				}
+				props = append(props, "title="+annotationPropEscaper.Replace(ruleID))
+				message := incident.Message
+				if message == "" {
+					message = violation.Description
+				}
+				annotations = append(annotations, fmt.Sprintf("::%s %s::%s",
+					level, strings.Join(props, ","), annotationDataEscaper.Replace(strings.TrimSpace(message))))
+			}
+		}
+	}
+	return annotations
+}
+
+// writeGitHubAnnotations prints the incidents as GitHub Actions annotations
+// with --github-annotations so they show inline on the pull request diff
+func (a *analyzeCommand) writeGitHubAnnotations(out io.Writer, rulesets []outputv1.RuleSet) error {
+	if !a.githubAnnotations {
+		return nil
+	}
+	for _, annotation := range a.buildGitHubAnnotations(rulesets) {
+		if _, err := fmt.Fprintln(out, annotation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+)
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	line := 12
+	mandatory := outputv1.Mandatory
+	optional := outputv1.Optional
+	rulesets := []outputv1.RuleSet{{
+		Name: "ruleset",
+		Violations: map[string]outputv1.Violation{
+			"rule-b": {
+				Category: &optional,
+				Incidents: []outputv1.Incident{{
+					URI:     uri.File("/app/src/Main.java"),
+					Message: "Replace javax, with jakarta\nsee docs: 100%",
+				}},
+			},
+			"rule-a": {
+				Category:    &mandatory,
+				Description: "rule description",
+				Incidents: []outputv1.Incident{{
+					URI:        uri.File("/app/pom.xml"),
+					LineNumber: &line,
+				}},
+			},
+		},
+	}}
+	a := &analyzeCommand{input: "/app", githubAnnotations: true}
+
+	var out bytes.Buffer
+	require.NoError(t, a.writeGitHubAnnotations(&out, rulesets))
+	assert.Equal(t,
+		"::error file=pom.xml,line=12,title=rule-a::rule description\n"+
+			"::warning file=src/Main.java,title=rule-b::Replace javax, with jakarta%0Asee docs: 100%25\n",
+		out.String())
+
+	out.Reset()
+	a.githubAnnotations = false
+	require.NoError(t, a.writeGitHubAnnotations(&out, rulesets))
+	assert.Empty(t, out.String())
+}
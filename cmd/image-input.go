@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/konveyor-ecosystem/kantra/pkg/container"
+)
+
+// imageInputScheme prefixes an --input image reference, e.g.
+// oci://quay.io/org/app:v1
+const imageInputScheme = "oci://"
+
+// isImageInput returns true when input is an image reference to analyze the
+// filesystem of
+func isImageInput(input string) bool {
+	return strings.HasPrefix(input, imageInputScheme)
+}
+
+func (a *analyzeCommand) validateImageInput() error {
+	if !isImageInput(a.input) {
+		if a.imagePath != "" {
+			return fmt.Errorf("--image-path requires an %s image --input", imageInputScheme)
+		}
+		return nil
+	}
+	return validateImageReference(strings.TrimPrefix(a.input, imageInputScheme))
+}
+
+// fetchImageInput copies the filesystem of the --input image, or only its
+// --image-path, to a temp dir with the container tool, pulling the image when
+// it isn't present, and points a.input to it
+func (a *analyzeCommand) fetchImageInput(ctx context.Context) error {
+	image := strings.TrimPrefix(a.input, imageInputScheme)
+	if err := validateImageReference(image); err != nil {
+		return err
+	}
+	tempDir, err := os.MkdirTemp("", "analyze-image-input-")
+	if err != nil {
+		return fmt.Errorf("%w failed to create temp dir for image input", err)
+	}
+	a.log.V(1).Info("created directory for image input", "dir", tempDir)
+	a.tempDirs = append(a.tempDirs, tempDir)
+
+	name := fmt.Sprintf("image-input-%v", container.RandomName())
+	a.log.Info("creating container from input image", "image", image)
+	if output, err := exec.CommandContext(ctx, Settings.ContainerBinary, "create", "--name", name, image).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create container from input image %s: %w: %s", image, err, strings.TrimSpace(string(output)))
+	}
+	defer func() {
+		if output, err := exec.Command(Settings.ContainerBinary, "rm", name).CombinedOutput(); err != nil {
+			a.log.V(1).Error(err, "failed to remove input image container", "container", name, "output", string(output))
+		}
+	}()
+
+	sourceDir := filepath.Join(tempDir, "source")
+	if a.imagePath != "" {
+		imagePath := path.Join("/", a.imagePath)
+		output, err := exec.CommandContext(ctx, Settings.ContainerBinary, "cp", fmt.Sprintf("%s:%s", name, imagePath), sourceDir).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to copy %s from input image %s: %w: %s", imagePath, image, err, strings.TrimSpace(string(output)))
+		}
+	} else if err := a.exportContainer(ctx, name, sourceDir); err != nil {
+		return fmt.Errorf("failed to export the filesystem of input image %s: %w", image, err)
+	}
+	a.log.V(1).Info("copied image input", "image", image, "dir", sourceDir)
+	a.input = sourceDir
+	return nil
+}
+
+// exportContainer extracts the filesystem of the container to dest, links
+// and special files are left out
+func (a *analyzeCommand) exportContainer(ctx context.Context, name string, dest string) error {
+	cmd := exec.CommandContext(ctx, Settings.ContainerBinary, "export", name)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr := &strings.Builder{}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if err := extractTar(stdout, dest); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"archive/tar"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateImageInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		imagePath string
+		wantErr   bool
+	}{
+		{name: "local input", input: "/app"},
+		{name: "image input", input: "oci://quay.io/org/app:v1", imagePath: "/deployments"},
+		{name: "invalid image", input: "oci://Quay.io/org/App", wantErr: true},
+		{name: "image path without image", input: "/app", imagePath: "/deployments", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzeCommand{input: tt.input, imagePath: tt.imagePath}
+			err := a.validateImageInput()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// fakeContainerTool writes a container tool that exports the given tar
+func fakeContainerTool(t *testing.T, exportTar string) string {
+	tool := filepath.Join(t.TempDir(), "podman")
+	script := `#!/bin/sh
+case "$1" in
+create|rm) exit 0 ;;
+export) cat "` + exportTar + `" ;;
+*) exit 1 ;;
+esac
+`
+	require.NoError(t, os.WriteFile(tool, []byte(script), 0755))
+	return tool
+}
+
+func TestFetchImageInput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake container tool is a shell script")
+	}
+	exportTar := filepath.Join(t.TempDir(), "export.tar")
+	f, err := os.Create(exportTar)
+	require.NoError(t, err)
+	tw := tar.NewWriter(f)
+	content := []byte("<project/>")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "app/", Typeflag: tar.TypeDir, Mode: 0755}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "app/pom.xml", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+	_, err = tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "bin/sh", Typeflag: tar.TypeSymlink, Linkname: "/bin/busybox"}))
+	require.NoError(t, tw.Close())
+	require.NoError(t, f.Close())
+
+	containerBinary := Settings.ContainerBinary
+	t.Cleanup(func() { Settings.ContainerBinary = containerBinary })
+	Settings.ContainerBinary = fakeContainerTool(t, exportTar)
+
+	a := &analyzeCommand{
+		input: "oci://quay.io/org/app:v1",
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	require.NoError(t, a.fetchImageInput(context.Background()))
+	t.Cleanup(func() {
+		for _, dir := range a.tempDirs {
+			os.RemoveAll(dir)
+		}
+	})
+	require.Len(t, a.tempDirs, 1)
+	data, err := os.ReadFile(filepath.Join(a.input, "app", "pom.xml"))
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+	_, err = os.Lstat(filepath.Join(a.input, "bin", "sh"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// incidentLabelsVariable is the incident variable holding the labels given
+// with --incident-label
+const incidentLabelsVariable = "incidentLabels"
+
+// konveyorLabelDomain is reserved for the labels of the konveyor rules
+const konveyorLabelDomain = "konveyor.io"
+
+var incidentLabelKeyRegex = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+
+// parseIncidentLabels parses --incident-label values of the form key=value,
+// keys in the konveyor.io domain are reserved
+func parseIncidentLabels(values []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, value := range values {
+		key, labelValue, ok := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		labelValue = strings.TrimSpace(labelValue)
+		if !ok || key == "" || labelValue == "" {
+			return nil, fmt.Errorf("invalid incident label %q, must be key=value", value)
+		}
+		if !incidentLabelKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("invalid incident label key %q, must be alphanumeric and may contain '.', '_', '-' and '/'", key)
+		}
+		if domain, _, found := strings.Cut(key, "/"); found &&
+			(domain == konveyorLabelDomain || strings.HasSuffix(domain, "."+konveyorLabelDomain)) {
+			return nil, fmt.Errorf("incident label key %s is reserved, %s labels are set by the rules", key, konveyorLabelDomain)
+		}
+		if _, ok := labels[key]; ok {
+			return nil, fmt.Errorf("incident label %s is given more than once", key)
+		}
+		labels[key] = labelValue
+	}
+	return labels, nil
+}
+
+func (a *analyzeCommand) validateIncidentLabels() error {
+	labels, err := parseIncidentLabels(a.incidentLabels)
+	if err != nil {
+		return err
+	}
+	a.incidentLabelValues = labels
+	return nil
+}
+
+// addIncidentLabels stores the --incident-label labels in the variables of
+// every incident, e.g. to correlate the incidents of a run with other tools
+func (a *analyzeCommand) addIncidentLabels(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if len(a.incidentLabelValues) == 0 {
+		return rulesets
+	}
+	addLabels := func(violations map[string]outputv1.Violation) {
+		for _, violation := range violations {
+			for j := range violation.Incidents {
+				incident := &violation.Incidents[j]
+				if incident.Variables == nil {
+					incident.Variables = map[string]interface{}{}
+				}
+				labels := make(map[string]interface{}, len(a.incidentLabelValues))
+				for key, value := range a.incidentLabelValues {
+					labels[key] = value
+				}
+				incident.Variables[incidentLabelsVariable] = labels
+			}
+		}
+	}
+	for i := range rulesets {
+		addLabels(rulesets[i].Violations)
+		addLabels(rulesets[i].Insights)
+	}
+	return rulesets
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIncidentLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "none", values: nil, want: map[string]string{}},
+		{name: "labels", values: []string{"run-id=42", " env = staging "}, want: map[string]string{"run-id": "42", "env": "staging"}},
+		{name: "domain key", values: []string{"example.com/team=payments"}, want: map[string]string{"example.com/team": "payments"}},
+		{name: "value with equals", values: []string{"query=a=b"}, want: map[string]string{"query": "a=b"}},
+		{name: "missing value", values: []string{"env"}, wantErr: true},
+		{name: "empty value", values: []string{"env="}, wantErr: true},
+		{name: "empty key", values: []string{"=staging"}, wantErr: true},
+		{name: "invalid key", values: []string{"run id=42"}, wantErr: true},
+		{name: "reserved key", values: []string{"konveyor.io/target=quarkus"}, wantErr: true},
+		{name: "reserved subdomain key", values: []string{"tackle.konveyor.io/env=staging"}, wantErr: true},
+		{name: "duplicate key", values: []string{"env=staging", "env=prod"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseIncidentLabels(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAddIncidentLabels(t *testing.T) {
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "custom",
+			Violations: map[string]outputv1.Violation{
+				"custom-01": {Incidents: []outputv1.Incident{
+					{URI: "file:///app/A.java"},
+					{URI: "file:///app/B.java", Variables: map[string]interface{}{"package": "com.example"}},
+				}},
+			},
+			Insights: map[string]outputv1.Violation{
+				"custom-02": {Incidents: []outputv1.Incident{{URI: "file:///app/C.java"}}},
+			},
+		},
+	}
+	a := &analyzeCommand{incidentLabels: []string{"run-id=42", "env=staging"}}
+	require.NoError(t, a.validateIncidentLabels())
+	rulesets = a.addIncidentLabels(rulesets)
+
+	want := map[string]interface{}{"run-id": "42", "env": "staging"}
+	incidents := rulesets[0].Violations["custom-01"].Incidents
+	assert.Equal(t, want, incidents[0].Variables[incidentLabelsVariable])
+	assert.Equal(t, want, incidents[1].Variables[incidentLabelsVariable])
+	assert.Equal(t, "com.example", incidents[1].Variables["package"])
+	assert.Equal(t, want, rulesets[0].Insights["custom-02"].Incidents[0].Variables[incidentLabelsVariable])
+}
+
+func TestAddIncidentLabelsWithoutLabels(t *testing.T) {
+	rulesets := []outputv1.RuleSet{
+		{Violations: map[string]outputv1.Violation{"custom-01": {Incidents: []outputv1.Incident{{URI: "file:///app/A.java"}}}}},
+	}
+	a := &analyzeCommand{}
+	require.NoError(t, a.validateIncidentLabels())
+	rulesets = a.addIncidentLabels(rulesets)
+	assert.Nil(t, rulesets[0].Violations["custom-01"].Incidents[0].Variables)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// limitIncidents truncates the incidents of every violation to the given
+// limit and appends a note incident telling how many incidents were omitted.
+// A limit of zero or less leaves the rulesets untouched.
+func limitIncidents(rulesets []outputv1.RuleSet, limit int) []outputv1.RuleSet {
+	if limit <= 0 {
+		return rulesets
+	}
+	for i := range rulesets {
+		for ruleID, violation := range rulesets[i].Violations {
+			if len(violation.Incidents) <= limit {
+				continue
+			}
+			omitted := len(violation.Incidents) - limit
+			note := outputv1.Incident{
+				URI:     violation.Incidents[limit].URI,
+				Message: fmt.Sprintf("%d more incidents were omitted by --incident-limit=%d", omitted, limit),
+			}
+			incidents := make([]outputv1.Incident, 0, limit+1)
+			incidents = append(incidents, violation.Incidents[:limit]...)
+			violation.Incidents = append(incidents, note)
+			rulesets[i].Violations[ruleID] = violation
+		}
+	}
+	return rulesets
+}
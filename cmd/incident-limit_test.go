@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+)
+
+func TestLimitIncidents(t *testing.T) {
+	makeRulesets := func(count int) []outputv1.RuleSet {
+		incidents := []outputv1.Incident{}
+		for i := 0; i < count; i++ {
+			incidents = append(incidents, outputv1.Incident{
+				URI: uri.File(fmt.Sprintf("/app/File%d.java", i)),
+			})
+		}
+		return []outputv1.RuleSet{
+			{
+				Name: "test",
+				Violations: map[string]outputv1.Violation{
+					"rule-001": {Incidents: incidents},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		count         int
+		limit         int
+		wantIncidents int
+		wantNote      bool
+	}{
+		{name: "zero is unlimited", count: 10, limit: 0, wantIncidents: 10},
+		{name: "negative is unlimited", count: 10, limit: -1, wantIncidents: 10},
+		{name: "under the limit", count: 3, limit: 5, wantIncidents: 3},
+		{name: "equal to the limit", count: 5, limit: 5, wantIncidents: 5},
+		{name: "over the limit", count: 10, limit: 4, wantIncidents: 5, wantNote: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := limitIncidents(makeRulesets(tt.count), tt.limit)
+			incidents := got[0].Violations["rule-001"].Incidents
+			require.Len(t, incidents, tt.wantIncidents)
+			if tt.wantNote {
+				note := incidents[len(incidents)-1]
+				assert.Contains(t, note.Message, fmt.Sprintf("%d more incidents were omitted", tt.count-tt.limit))
+				assert.Equal(t, uri.File(fmt.Sprintf("/app/File%d.java", tt.limit)), note.URI)
+			}
+		})
+	}
+}
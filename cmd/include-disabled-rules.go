@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/konveyor/analyzer-lsp/engine"
+	"github.com/konveyor/analyzer-lsp/engine/labels"
+)
+
+// disabledRuleLabel is the label default rules are shipped disabled with, a
+// label selector never matches them
+var disabledRuleLabel = labels.AsString(labels.RuleIncludeLabel, labels.SelectNever)
+
+// includeDisabledSelector matches disabled rules as if they were not labeled
+// disabled, other rules are left to the wrapped selector
+type includeDisabledSelector struct {
+	selector engine.RuleSelector
+}
+
+var _ engine.RuleSelector = &includeDisabledSelector{}
+
+func (s *includeDisabledSelector) Matches(meta *engine.RuleMeta) (bool, error) {
+	ruleLabels := []string{}
+	for _, label := range meta.Labels {
+		if label != disabledRuleLabel {
+			ruleLabels = append(ruleLabels, label)
+		}
+	}
+	if len(ruleLabels) == len(meta.Labels) {
+		return s.selector.Matches(meta)
+	}
+	enabled := *meta
+	enabled.Labels = ruleLabels
+	return s.selector.Matches(&enabled)
+}
+
+// includeDisabledRules wraps the selectors so disabled rules are selected with
+// --rules-include-disabled
+func (a *analyzeCommand) includeDisabledRules(selectors []engine.RuleSelector) []engine.RuleSelector {
+	if !a.rulesIncludeDisabled {
+		return selectors
+	}
+	a.log.Info("WARNING: --rules-include-disabled is set, results include disabled and experimental rules")
+	wrapped := []engine.RuleSelector{}
+	for _, selector := range selectors {
+		wrapped = append(wrapped, &includeDisabledSelector{selector: selector})
+	}
+	return wrapped
+}
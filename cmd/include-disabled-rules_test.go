@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/engine"
+	"github.com/konveyor/analyzer-lsp/engine/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncludeDisabledRules(t *testing.T) {
+	selector, err := labels.NewLabelSelector[*engine.RuleMeta]("konveyor.io/target=quarkus", nil)
+	require.NoError(t, err)
+	disabled := &engine.RuleMeta{RuleID: "disabled", Labels: []string{"konveyor.io/target=quarkus", "konveyor.io/include=never"}}
+	otherTarget := &engine.RuleMeta{RuleID: "other", Labels: []string{"konveyor.io/target=azure-appservice", "konveyor.io/include=never"}}
+	enabled := &engine.RuleMeta{RuleID: "enabled", Labels: []string{"konveyor.io/target=quarkus"}}
+
+	a := &analyzeCommand{}
+	a.log = logr.Discard()
+	selectors := a.includeDisabledRules([]engine.RuleSelector{selector})
+	matches, err := selectors[0].Matches(disabled)
+	require.NoError(t, err)
+	assert.False(t, matches)
+
+	a.rulesIncludeDisabled = true
+	selectors = a.includeDisabledRules([]engine.RuleSelector{selector})
+	for meta, want := range map[*engine.RuleMeta]bool{disabled: true, otherTarget: false, enabled: true} {
+		matches, err := selectors[0].Matches(meta)
+		require.NoError(t, err)
+		assert.Equal(t, want, matches, meta.RuleID)
+	}
+	// the rule itself is left labeled disabled
+	assert.Contains(t, disabled.Labels, "konveyor.io/include=never")
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"golang.org/x/term"
+)
+
+// promptTechnologies lets the user pick the sources and targets from a menu
+// with --interactive. Skipped when any of them or a label selector is given,
+// or when stdin isn't a terminal, so scripts keep working.
+func (a *analyzeCommand) promptTechnologies(ctx context.Context) error {
+	if !a.interactive || len(a.sources) > 0 || len(a.targets) > 0 || a.labelSelector != "" {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		a.log.V(1).Info("stdin is not a terminal, skipping the interactive source and target selection")
+		return nil
+	}
+	return a.selectTechnologies(ctx, os.Stdin, os.Stdout)
+}
+
+// selectTechnologies prompts for the sources and then the targets
+func (a *analyzeCommand) selectTechnologies(ctx context.Context, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	sources, err := a.technologyOptions(ctx, outputv1.SourceTechnologyLabel)
+	if err != nil {
+		return err
+	}
+	a.sources, err = selectOptions(reader, out, "source", sources)
+	if err != nil {
+		return err
+	}
+	targets, err := a.technologyOptions(ctx, outputv1.TargetTechnologyLabel)
+	if err != nil {
+		return err
+	}
+	a.targets, err = selectOptions(reader, out, "target", targets)
+	if err != nil {
+		return err
+	}
+	a.log.Info("selected technologies", "sources", a.sources, "targets", a.targets)
+	return nil
+}
+
+// technologyOptions lists the technologies of the given label the same way
+// --list-sources and --list-targets do
+func (a *analyzeCommand) technologyOptions(ctx context.Context, label string) ([]string, error) {
+	var raw bytes.Buffer
+	listSources := label == outputv1.SourceTechnologyLabel
+	var err error
+	if a.runLocal {
+		err = a.fetchLabelsContainerless(ctx, listSources, !listSources, &raw)
+	} else {
+		err = a.fetchLabels(ctx, listSources, !listSources, &raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+	options := []string{}
+	for _, line := range strings.Split(raw.String(), "\n") {
+		line = strings.TrimSpace(line)
+		// skip the "available ... technologies:" header
+		if line == "" || strings.HasSuffix(line, ":") {
+			continue
+		}
+		options = append(options, line)
+	}
+	return options, nil
+}
+
+// selectOptions prints a numbered menu of options and reads a comma
+// separated list of numbers, asking again on invalid input. An empty answer
+// selects nothing.
+func selectOptions(reader *bufio.Reader, out io.Writer, name string, options []string) ([]string, error) {
+	if len(options) == 0 {
+		fmt.Fprintf(out, "no %s technologies available\n", name)
+		return []string{}, nil
+	}
+	fmt.Fprintf(out, "available %s technologies:\n", name)
+	for i, option := range options {
+		fmt.Fprintf(out, "%3d) %s\n", i+1, option)
+	}
+	for {
+		fmt.Fprintf(out, "select %s technologies by number, separated by commas (empty for none): ", name)
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return nil, fmt.Errorf("failed to read the selected %s technologies: %w", name, err)
+		}
+		selected, parseErr := parseSelection(line, options)
+		if parseErr == nil {
+			return selected, nil
+		}
+		fmt.Fprintln(out, parseErr)
+		if err == io.EOF {
+			return nil, parseErr
+		}
+	}
+}
+
+// parseSelection maps an answer like "1, 3" to the options, ignoring
+// duplicates
+func parseSelection(answer string, options []string) ([]string, error) {
+	selected := []string{}
+	seen := map[int]bool{}
+	for _, field := range strings.Split(answer, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 1 || n > len(options) {
+			return nil, fmt.Errorf("invalid selection %q, enter numbers between 1 and %d", field, len(options))
+		}
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		selected = append(selected, options[n-1])
+	}
+	return selected, nil
+}
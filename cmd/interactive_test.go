@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelection(t *testing.T) {
+	options := []string{"eap7", "eap8", "quarkus"}
+	tests := []struct {
+		name    string
+		answer  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", answer: "\n", want: []string{}},
+		{name: "single", answer: "2\n", want: []string{"eap8"}},
+		{name: "multiple", answer: " 3, 1 \n", want: []string{"quarkus", "eap7"}},
+		{name: "duplicates", answer: "1,1\n", want: []string{"eap7"}},
+		{name: "out of range", answer: "4\n", wantErr: true},
+		{name: "not a number", answer: "quarkus\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSelection(tt.answer, options)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSelectOptions(t *testing.T) {
+	var out bytes.Buffer
+	reader := bufio.NewReader(strings.NewReader("5\n2\n"))
+	got, err := selectOptions(reader, &out, "target", []string{"eap8", "quarkus"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"quarkus"}, got)
+	assert.Contains(t, out.String(), "  2) quarkus")
+	assert.Contains(t, out.String(), "invalid selection \"5\"")
+
+	_, err = selectOptions(bufio.NewReader(strings.NewReader("")), &out, "target", []string{"eap8"})
+	assert.Error(t, err)
+}
+
+func TestSelectTechnologies(t *testing.T) {
+	kantraDir := t.TempDir()
+	rulesDir := filepath.Join(kantraDir, RulesetsLocation, "eap")
+	require.NoError(t, os.MkdirAll(rulesDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "rules.yaml"), []byte(`- ruleID: test-01
+  labels:
+  - konveyor.io/source=eap7
+  - konveyor.io/target=quarkus
+  - konveyor.io/target=eap8+
+`), 0644))
+
+	a := &analyzeCommand{
+		runLocal: true,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			kantraDir: kantraDir,
+			log:       logr.Discard(),
+		},
+	}
+	var out bytes.Buffer
+	require.NoError(t, a.selectTechnologies(context.Background(), strings.NewReader("1\n2\n"), &out))
+	assert.Equal(t, []string{"eap7"}, a.sources)
+	assert.Equal(t, []string{"quarkus"}, a.targets)
+}
+
+func TestPromptTechnologiesSkipped(t *testing.T) {
+	a := &analyzeCommand{
+		interactive: true,
+		targets:     []string{"quarkus"},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	require.NoError(t, a.promptTechnologies(context.Background()))
+	assert.Empty(t, a.sources)
+	assert.Equal(t, []string{"quarkus"}, a.targets)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/go-logr/logr"
+)
+
+// interruptContext returns a context cancelled on SIGINT or SIGTERM, so the
+// analysis stops the engine and providers and cleans up before exiting. The
+// signals are only caught once, repeating one exits immediately.
+func interruptContext(parent context.Context, log logr.Logger) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		defer signal.Stop(signals)
+		select {
+		case sig := <-signals:
+			log.Info("received signal, stopping analysis and cleaning up, repeat to exit immediately", "signal", sig.String())
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterruptContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupt signals can't be sent to the process on windows")
+	}
+	ctx, cancel := interruptContext(context.Background(), logr.Discard())
+	defer cancel()
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(os.Interrupt))
+
+	select {
+	case <-ctx.Done():
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("context not cancelled on interrupt")
+	}
+}
+
+func TestInterruptContextParentCancelled(t *testing.T) {
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := interruptContext(parent, logr.Discard())
+	defer cancel()
+
+	cancelParent()
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context not cancelled with its parent")
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// buildJUnitReport maps each ruleset to a testsuite and each violation to a
+// testcase. Mandatory violations become failures, optional and potential
+// violations are reported as skipped.
+func buildJUnitReport(rulesets []outputv1.RuleSet) junitTestSuites {
+	report := junitTestSuites{Name: "kantra"}
+	for _, ruleset := range rulesets {
+		suite := junitTestSuite{Name: ruleset.Name}
+		ruleIDs := make([]string, 0, len(ruleset.Violations))
+		for ruleID := range ruleset.Violations {
+			ruleIDs = append(ruleIDs, ruleID)
+		}
+		sort.Strings(ruleIDs)
+		for _, ruleID := range ruleIDs {
+			violation := ruleset.Violations[ruleID]
+			testCase := junitTestCase{
+				Name:      ruleID,
+				ClassName: ruleset.Name,
+			}
+			category := outputv1.Potential
+			if violation.Category != nil {
+				category = *violation.Category
+			}
+			if category == outputv1.Mandatory {
+				testCase.Failure = &junitFailure{
+					Message: violation.Description,
+					Type:    string(category),
+					Body:    junitIncidentsBody(violation.Incidents),
+				}
+				suite.Failures++
+			} else {
+				testCase.Skipped = &junitSkipped{
+					Message: fmt.Sprintf("%s: %s", category, violation.Description),
+				}
+				suite.Skipped++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suite.Tests = len(suite.TestCases)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.TestSuites = append(report.TestSuites, suite)
+	}
+	return report
+}
+
+func junitIncidentsBody(incidents []outputv1.Incident) string {
+	lines := []string{}
+	for _, incident := range incidents {
+		location := string(incident.URI)
+		if incident.LineNumber != nil {
+			location = fmt.Sprintf("%s:%d", location, *incident.LineNumber)
+		}
+		lines = append(lines, fmt.Sprintf("%s\n%s", location, strings.TrimSpace(incident.Message)))
+	}
+	return strings.Join(lines, "\n\n")
+}
+
+// writeJUnitOutput writes junit.xml to the output dir when --junit-output is set
+func (a *analyzeCommand) writeJUnitOutput(rulesets []outputv1.RuleSet) error {
+	if !a.junitOutput {
+		return nil
+	}
+	a.log.Info("writing analysis results as junit output", "output", a.output)
+	data, err := xml.MarshalIndent(buildJUnitReport(rulesets), "", "	")
+	if err != nil {
+		a.log.V(1).Error(err, "failed to marshal junit report")
+		return err
+	}
+	data = append([]byte(xml.Header), data...)
+	err = os.WriteFile(filepath.Join(a.output, "junit.xml"), data, 0644)
+	if err != nil {
+		a.log.V(1).Error(err, "failed to write junit output", "dir", a.output, "file", "junit.xml")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildJUnitReport(t *testing.T) {
+	mandatory := outputv1.Mandatory
+	optional := outputv1.Optional
+	lineNumber := 42
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "eap8/eap7",
+			Violations: map[string]outputv1.Violation{
+				"rule-002": {
+					Description: "optional change",
+					Category:    &optional,
+				},
+				"rule-001": {
+					Description: "mandatory change",
+					Category:    &mandatory,
+					Incidents: []outputv1.Incident{
+						{
+							URI:        "file:///app/src/Main.java",
+							Message:    "replace javax with jakarta",
+							LineNumber: &lineNumber,
+						},
+					},
+				},
+				"rule-003": {
+					Description: "no category",
+				},
+			},
+		},
+		{
+			Name: "empty",
+		},
+	}
+
+	report := buildJUnitReport(rulesets)
+
+	assert.Equal(t, 3, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 2, report.Skipped)
+	require.Len(t, report.TestSuites, 2)
+
+	suite := report.TestSuites[0]
+	assert.Equal(t, "eap8/eap7", suite.Name)
+	require.Len(t, suite.TestCases, 3)
+	assert.Equal(t, "rule-001", suite.TestCases[0].Name)
+	require.NotNil(t, suite.TestCases[0].Failure)
+	assert.Contains(t, suite.TestCases[0].Failure.Body, "file:///app/src/Main.java:42")
+	assert.Contains(t, suite.TestCases[0].Failure.Body, "replace javax with jakarta")
+	assert.NotNil(t, suite.TestCases[1].Skipped)
+	assert.NotNil(t, suite.TestCases[2].Skipped)
+
+	assert.Equal(t, 0, report.TestSuites[1].Tests)
+}
+
+func TestWriteJUnitOutput(t *testing.T) {
+	tmpOutput := t.TempDir()
+	a := &analyzeCommand{
+		output: tmpOutput,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+
+	// disabled by default
+	require.NoError(t, a.writeJUnitOutput(nil))
+	_, err := os.Stat(filepath.Join(tmpOutput, "junit.xml"))
+	assert.True(t, os.IsNotExist(err))
+
+	a.junitOutput = true
+	require.NoError(t, a.writeJUnitOutput([]outputv1.RuleSet{{Name: "test"}}))
+	data, err := os.ReadFile(filepath.Join(tmpOutput, "junit.xml"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), xml.Header))
+
+	parsed := junitTestSuites{}
+	require.NoError(t, xml.Unmarshal(data, &parsed))
+	require.Len(t, parsed.TestSuites, 1)
+	assert.Equal(t, "test", parsed.TestSuites[0].Name)
+}
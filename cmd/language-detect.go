@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+)
+
+// providerExtensions maps source file extensions to the provider analyzing them
+var providerExtensions = map[string]string{
+	".java": util.JavaProvider,
+	".go":   util.GoProvider,
+	".py":   util.PythonProvider,
+	".js":   util.NodeJSProvider,
+	".jsx":  util.NodeJSProvider,
+	".ts":   util.NodeJSProvider,
+	".tsx":  util.NodeJSProvider,
+	".cs":   util.DotnetProvider,
+}
+
+// languageDetectSkipDirs are directories holding dependencies or build output
+// which don't tell the language of the application
+var languageDetectSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+	"build":        true,
+	"dist":         true,
+	"venv":         true,
+	"__pycache__":  true,
+}
+
+// supportedLanguages lists the languages providers can be detected for
+const supportedLanguages = "Java, Go, Python, JavaScript/TypeScript, C#"
+
+// detectProvidersByExtension counts source files by extension under input and
+// returns the providers for the languages found, most files first
+func detectProvidersByExtension(input string) ([]string, map[string]int, error) {
+	counts := map[string]int{}
+	err := filepath.WalkDir(input, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != input && (strings.HasPrefix(d.Name(), ".") || languageDetectSkipDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if prov, ok := providerExtensions[strings.ToLower(filepath.Ext(path))]; ok {
+			counts[prov]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to detect languages for input %s: %w", input, err)
+	}
+	providers := make([]string, 0, len(counts))
+	for prov := range counts {
+		providers = append(providers, prov)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		if counts[providers[i]] == counts[providers[j]] {
+			return providers[i] < providers[j]
+		}
+		return counts[providers[i]] > counts[providers[j]]
+	})
+	return providers, counts, nil
+}
+
+// detectProvidersFallback is used when no component language was recognized
+// for the input, it checks for java build files and then counts source files
+func (a *analyzeCommand) detectProvidersFallback() ([]string, error) {
+	foundJava, err := a.detectJavaProviderFallback()
+	if err != nil {
+		return nil, err
+	}
+	if foundJava {
+		return []string{util.JavaProvider}, nil
+	}
+	providers, counts, err := detectProvidersByExtension(a.input)
+	if err != nil {
+		return nil, err
+	}
+	if len(providers) > 0 {
+		a.log.V(1).Info("detected providers from source file extensions", "providers", providers, "files", counts)
+	}
+	return providers, nil
+}
+
+func noLanguageDetectedError(input string) error {
+	return fmt.Errorf("no supported language detected for input %s. Supported languages are %s. "+
+		"Use --provider to select providers or --rules to run custom rules", input, supportedLanguages)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFiles(t *testing.T, dir string, files ...string) {
+	t.Helper()
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(""), 0644))
+	}
+}
+
+func TestDetectProvidersByExtension(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         []string
+		wantProviders []string
+	}{
+		{
+			name:          "no source files",
+			files:         []string{"README.md", "config.xml"},
+			wantProviders: []string{},
+		},
+		{
+			name:          "most files first",
+			files:         []string{"app/main.py", "app/util.py", "src/Main.java"},
+			wantProviders: []string{"python", "java"},
+		},
+		{
+			name:          "javascript and typescript use the nodejs provider",
+			files:         []string{"src/index.ts", "src/app.tsx", "src/legacy.js"},
+			wantProviders: []string{"nodejs"},
+		},
+		{
+			name:          "dependency and hidden dirs are skipped",
+			files:         []string{"main.go", "node_modules/lib/index.js", "vendor/lib/lib.go", ".venv/lib/site.py"},
+			wantProviders: []string{"go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFiles(t, dir, tt.files...)
+			providers, _, err := detectProvidersByExtension(dir)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProviders, providers)
+		})
+	}
+}
+
+func TestDetectProvidersFallback(t *testing.T) {
+	dir := t.TempDir()
+	a := &analyzeCommand{input: dir}
+	a.log = logr.Discard()
+
+	providers, err := a.detectProvidersFallback()
+	require.NoError(t, err)
+	assert.Empty(t, providers)
+
+	writeTestFiles(t, dir, "scripts/run.py")
+	providers, err = a.detectProvidersFallback()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"python"}, providers)
+
+	// java build files take precedence
+	writeTestFiles(t, dir, "pom.xml")
+	providers, err = a.detectProvidersFallback()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"java"}, providers)
+}
+
+func TestNoLanguageDetectedError(t *testing.T) {
+	err := noLanguageDetectedError("/test/input")
+	assert.Contains(t, err.Error(), "/test/input")
+	assert.Contains(t, err.Error(), supportedLanguages)
+}
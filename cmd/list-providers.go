@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+)
+
+// containerProviders run in provider images in hybrid mode
+var containerProviders = []string{
+	util.JavaProvider,
+	util.PythonProvider,
+	util.GoProvider,
+	util.DotnetProvider,
+	util.NodeJSProvider,
+}
+
+// ListAllProviders prints every known provider, whether it runs in container
+// and containerless mode, the containerless binaries missing from the kantra
+// dir and PATH, and the analysis mode it runs in with --mode and
+// --provider-mode
+func (a *analyzeCommand) ListAllProviders(out io.Writer) error {
+	if a.kantraDir == "" {
+		if err := a.setKantraDir(); err != nil {
+			return err
+		}
+	}
+	overrides, err := parseProviderModes(a.providerModes)
+	if err != nil {
+		return err
+	}
+	a.providerModeOverrides = overrides
+	if err := a.validateProviderBinaries(); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tCONTAINER\tCONTAINERLESS\tMODE\tMISSING")
+	fmt.Fprintf(w, "builtin\tyes\tyes\t%s\t\n", a.modeForProvider("builtin"))
+	for _, name := range containerProviders {
+		containerless, missing := a.containerlessProviderStatus(name)
+		fmt.Fprintf(w, "%s\tyes\t%s\t%s\t%s\n", name, containerless, a.modeForProvider(name), strings.Join(missing, ", "))
+	}
+	return w.Flush()
+}
+
+// containerlessProviderStatus returns whether the provider can run
+// containerless, "installed", "missing" or "unsupported", and the binaries
+// that were not found. The go dependency provider is optional, go is
+// installed without it but lists no dependencies.
+func (a *analyzeCommand) containerlessProviderStatus(name string) (string, []string) {
+	missing := []string{}
+	switch name {
+	case util.JavaProvider:
+		for _, path := range a.javaProviderBins() {
+			if checkBinFile(path) != nil {
+				missing = append(missing, path)
+			}
+		}
+	case util.GoProvider, util.PythonProvider:
+		for _, bin := range []string{GenericProviderBinary, genericProviderLSPBinaries[name]} {
+			if _, err := a.lookupProviderBin(bin); err != nil {
+				missing = append(missing, bin)
+			}
+		}
+		if name == util.GoProvider && len(missing) == 0 {
+			if _, err := a.lookupProviderBin(GoDependencyProviderBinary); err != nil {
+				return "installed", []string{GoDependencyProviderBinary}
+			}
+		}
+	default:
+		return "unsupported", missing
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		return "missing", missing
+	}
+	return "installed", missing
+}
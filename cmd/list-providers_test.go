@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAllProviders(t *testing.T) {
+	kantraDir := t.TempDir()
+	t.Setenv("PATH", t.TempDir())
+	for _, bin := range []string{JavaBundlesLocation, JDTLSBinLocation, GenericProviderBinary, PythonLSPBinary} {
+		path := filepath.Join(kantraDir, bin)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte{}, 0755))
+	}
+
+	a := &analyzeCommand{
+		mode:          "full",
+		providerModes: []string{"python=source-only"},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			kantraDir: kantraDir,
+		},
+	}
+	out := &bytes.Buffer{}
+	require.NoError(t, a.ListAllProviders(out))
+
+	rows := map[string][]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n")[1:] {
+		fields := strings.Fields(line)
+		rows[fields[0]] = fields[1:]
+	}
+	assert.Equal(t, []string{"yes", "yes", "full"}, rows["builtin"])
+	assert.Equal(t, []string{"yes", "installed", "full"}, rows["java"])
+	assert.Equal(t, []string{"yes", "installed", "source-only"}, rows["python"])
+	assert.Equal(t, []string{"yes", "missing", "full", "gopls"}, rows["go"])
+	assert.Equal(t, []string{"yes", "unsupported", "full"}, rows["dotnet"])
+	assert.Equal(t, []string{"yes", "unsupported", "full"}, rows["nodejs"])
+}
+
+func TestListAllProvidersInvalidProviderMode(t *testing.T) {
+	a := &analyzeCommand{
+		providerModes: []string{"java"},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			kantraDir: t.TempDir(),
+		},
+	}
+	assert.Error(t, a.ListAllProviders(&bytes.Buffer{}))
+}
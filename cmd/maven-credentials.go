@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	mavenUsernameEnv = "MAVEN_USERNAME"
+	mavenPasswordEnv = "MAVEN_PASSWORD"
+	mavenServerIDEnv = "MAVEN_SERVER_ID"
+)
+
+const emptyMavenSettings = `<?xml version="1.0" encoding="UTF-8"?>
+<settings xmlns="http://maven.apache.org/SETTINGS/1.0.0"
+          xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+          xsi:schemaLocation="http://maven.apache.org/SETTINGS/1.0.0 https://maven.apache.org/xsd/settings-1.0.0.xsd">
+</settings>
+`
+
+// mavenSettingsServers holds the server ids already defined in a settings file
+type mavenSettingsServers struct {
+	Servers []struct {
+		ID string `xml:"id"`
+	} `xml:"servers>server"`
+}
+
+// resolveMavenCredentials writes the --maven-username and --maven-password
+// credentials into a <server> entry of a temp maven settings file, merged
+// with --maven-settings when given, and uses that file for the analysis
+func (a *analyzeCommand) resolveMavenCredentials() error {
+	if a.mavenUsername == "" {
+		a.mavenUsername = os.Getenv(mavenUsernameEnv)
+	}
+	if a.mavenPassword == "" {
+		a.mavenPassword = os.Getenv(mavenPasswordEnv)
+	}
+	if a.mavenServerID == "" {
+		a.mavenServerID = os.Getenv(mavenServerIDEnv)
+	}
+	if a.mavenUsername == "" && a.mavenPassword == "" {
+		return nil
+	}
+	if a.mavenUsername == "" || a.mavenPassword == "" {
+		return fmt.Errorf("both maven-username and maven-password must be set for maven repository credentials")
+	}
+	if a.mavenServerID == "" {
+		return fmt.Errorf("maven-server-id must be set to the id of the repository the credentials are for")
+	}
+
+	settings := []byte(emptyMavenSettings)
+	if a.mavenSettingsFile != "" {
+		var err error
+		settings, err = os.ReadFile(a.mavenSettingsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read maven settings file %s: %w", a.mavenSettingsFile, err)
+		}
+	}
+	merged, err := addMavenServer(settings, a.mavenServerID, a.mavenUsername, a.mavenPassword)
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := os.MkdirTemp("", "analyze-maven-settings-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir for maven settings: %w", err)
+	}
+	a.tempDirs = append(a.tempDirs, tempDir)
+	settingsFile := filepath.Join(tempDir, "settings.xml")
+	// the file holds credentials, keep it private to the user
+	if err := os.WriteFile(settingsFile, merged, 0600); err != nil {
+		return fmt.Errorf("failed to write maven settings: %w", err)
+	}
+	a.log.V(1).Info("using generated maven settings with repository credentials", "serverId", a.mavenServerID)
+	a.mavenSettingsFile = settingsFile
+	return nil
+}
+
+// addMavenServer adds a <server> with the given credentials to the settings,
+// keeping everything else in the file as is
+func addMavenServer(settings []byte, id, username, password string) ([]byte, error) {
+	existing := mavenSettingsServers{}
+	if err := xml.Unmarshal(settings, &existing); err != nil {
+		return nil, fmt.Errorf("failed to parse maven settings: %w", err)
+	}
+	for _, server := range existing.Servers {
+		if strings.TrimSpace(server.ID) == id {
+			return nil, fmt.Errorf("maven settings already define server %s", id)
+		}
+	}
+
+	var server bytes.Buffer
+	server.WriteString("    <server>\n      <id>")
+	xml.EscapeText(&server, []byte(id))
+	server.WriteString("</id>\n      <username>")
+	xml.EscapeText(&server, []byte(username))
+	server.WriteString("</username>\n      <password>")
+	xml.EscapeText(&server, []byte(password))
+	server.WriteString("</password>\n    </server>\n")
+
+	content := string(settings)
+	if idx := strings.LastIndex(content, "</servers>"); idx != -1 {
+		return []byte(content[:idx] + server.String() + "  " + content[idx:]), nil
+	}
+	if idx := strings.LastIndex(content, "<servers/>"); idx != -1 {
+		return []byte(content[:idx] + "<servers>\n" + server.String() + "  </servers>" + content[idx+len("<servers/>"):]), nil
+	}
+	if idx := strings.LastIndex(content, "</settings>"); idx != -1 {
+		return []byte(content[:idx] + "  <servers>\n" + server.String() + "  </servers>\n" + content[idx:]), nil
+	}
+	return nil, fmt.Errorf("maven settings have no <settings> element")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddMavenServer(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings string
+		wantErr  bool
+	}{
+		{name: "no servers", settings: emptyMavenSettings},
+		{name: "existing servers", settings: "<settings><servers><server><id>central</id></server></servers></settings>"},
+		{name: "empty servers element", settings: "<settings><servers/></settings>"},
+		{name: "server already defined", settings: "<settings><servers><server><id>nexus</id></server></servers></settings>", wantErr: true},
+		{name: "not xml", settings: "settings", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := addMavenServer([]byte(tt.settings), "nexus", "user", "p&ss<")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			path := filepath.Join(t.TempDir(), "settings.xml")
+			require.NoError(t, os.WriteFile(path, merged, 0644))
+			require.NoError(t, validateMavenSettingsFile(path))
+			servers := mavenSettingsServers{}
+			require.NoError(t, xml.Unmarshal(merged, &servers))
+			ids := []string{}
+			for _, server := range servers.Servers {
+				ids = append(ids, server.ID)
+			}
+			assert.Contains(t, ids, "nexus")
+			assert.Contains(t, string(merged), "<password>p&amp;ss&lt;</password>")
+		})
+	}
+}
+
+func TestResolveMavenCredentials(t *testing.T) {
+	t.Setenv(mavenUsernameEnv, "")
+	t.Setenv(mavenPasswordEnv, "")
+	t.Setenv(mavenServerIDEnv, "")
+
+	a := &analyzeCommand{AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+	require.NoError(t, a.resolveMavenCredentials())
+	assert.Empty(t, a.mavenSettingsFile)
+	assert.Empty(t, a.tempDirs)
+
+	a.mavenUsername = "user"
+	assert.Error(t, a.resolveMavenCredentials(), "password is required")
+
+	t.Setenv(mavenPasswordEnv, "secret")
+	assert.Error(t, a.resolveMavenCredentials(), "server id is required")
+
+	existing := filepath.Join(t.TempDir(), "settings.xml")
+	require.NoError(t, os.WriteFile(existing, []byte("<settings><localRepository>/m2</localRepository></settings>"), 0644))
+	a.mavenServerID = "nexus"
+	a.mavenSettingsFile = existing
+	require.NoError(t, a.resolveMavenCredentials())
+
+	require.Len(t, a.tempDirs, 1)
+	assert.Equal(t, filepath.Join(a.tempDirs[0], "settings.xml"), a.mavenSettingsFile)
+	content, err := os.ReadFile(a.mavenSettingsFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "<localRepository>/m2</localRepository>")
+	assert.Contains(t, string(content), "<username>user</username>")
+	assert.Contains(t, string(content), "<password>secret</password>")
+	stat, err := os.Stat(a.mavenSettingsFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+}
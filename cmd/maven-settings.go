@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// mavenSettingsByID are the settings sections whose entries are merged by
+// their <id>, an entry of a later file replaces the one of an earlier file
+var mavenSettingsByID = map[string]bool{
+	"mirrors":  true,
+	"servers":  true,
+	"proxies":  true,
+	"profiles": true,
+}
+
+// mavenSettingsUnion are the settings sections whose values are merged
+var mavenSettingsUnion = map[string]bool{
+	"activeProfiles": true,
+	"pluginGroups":   true,
+}
+
+// mavenSettingsElement is an element of a settings file kept as raw XML
+type mavenSettingsElement struct {
+	XMLName xml.Name
+	Inner   []byte `xml:",innerxml"`
+}
+
+type mavenSettingsDocument struct {
+	XMLName  xml.Name               `xml:"settings"`
+	Elements []mavenSettingsElement `xml:",any"`
+}
+
+// mavenSettingsSection is a top level element of the merged settings
+type mavenSettingsSection struct {
+	name    string
+	inner   []byte
+	entries []mavenSettingsElement
+	ids     map[string]int
+}
+
+// resolveMavenSettings validates every --maven-settings file and, when more
+// than one is given, merges them into a temp settings file that is used for
+// the analysis
+func (a *analyzeCommand) resolveMavenSettings() error {
+	settingsFiles := a.mavenSettingsFiles
+	if len(settingsFiles) == 0 && a.mavenSettingsFile != "" {
+		settingsFiles = []string{a.mavenSettingsFile}
+	}
+	for idx, settingsFile := range settingsFiles {
+		if err := validateMavenSettingsFile(settingsFile); err != nil {
+			return err
+		}
+		// try to get abs path, if not, continue with relative path
+		if absPath, err := filepath.Abs(settingsFile); err == nil {
+			settingsFiles[idx] = absPath
+		}
+	}
+	switch len(settingsFiles) {
+	case 0:
+		return nil
+	case 1:
+		a.mavenSettingsFile = settingsFiles[0]
+		return nil
+	}
+
+	settings := [][]byte{}
+	for _, settingsFile := range settingsFiles {
+		content, err := os.ReadFile(settingsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read maven settings file %s: %w", settingsFile, err)
+		}
+		settings = append(settings, content)
+	}
+	merged, err := mergeMavenSettings(settings)
+	if err != nil {
+		return err
+	}
+	tempDir, err := os.MkdirTemp("", "analyze-maven-settings-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir for maven settings: %w", err)
+	}
+	a.tempDirs = append(a.tempDirs, tempDir)
+	settingsFile := filepath.Join(tempDir, "settings.xml")
+	// servers of the merged files may hold credentials
+	if err := os.WriteFile(settingsFile, merged, 0600); err != nil {
+		return fmt.Errorf("failed to write merged maven settings: %w", err)
+	}
+	a.log.V(1).Info("using merged maven settings", "files", settingsFiles)
+	a.mavenSettingsFile = settingsFile
+	return nil
+}
+
+// mergeMavenSettings merges settings files in order. Mirrors, servers,
+// proxies and profiles are merged by id, active profiles and plugin groups
+// are merged and any other element of a later file replaces the earlier one.
+func mergeMavenSettings(settings [][]byte) ([]byte, error) {
+	sections := []*mavenSettingsSection{}
+	byName := map[string]*mavenSettingsSection{}
+	for idx, content := range settings {
+		doc := mavenSettingsDocument{}
+		if err := xml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse maven settings file %d: %w", idx+1, err)
+		}
+		for _, element := range doc.Elements {
+			name := element.XMLName.Local
+			section, ok := byName[name]
+			if !ok {
+				section = &mavenSettingsSection{name: name, ids: map[string]int{}}
+				byName[name] = section
+				sections = append(sections, section)
+			}
+			if !mavenSettingsByID[name] && !mavenSettingsUnion[name] {
+				section.inner = element.Inner
+				continue
+			}
+			entries := struct {
+				Entries []mavenSettingsElement `xml:",any"`
+			}{}
+			if err := xml.Unmarshal(wrapMavenSettingsElement(element), &entries); err != nil {
+				return nil, fmt.Errorf("failed to parse <%s> of maven settings file %d: %w", name, idx+1, err)
+			}
+			for _, entry := range entries.Entries {
+				key := strings.TrimSpace(string(entry.Inner))
+				if mavenSettingsByID[name] {
+					id := struct {
+						ID string `xml:"id"`
+					}{}
+					if err := xml.Unmarshal(wrapMavenSettingsElement(entry), &id); err != nil {
+						return nil, fmt.Errorf("failed to parse <%s> of maven settings file %d: %w", name, idx+1, err)
+					}
+					key = strings.TrimSpace(id.ID)
+				}
+				if pos, ok := section.ids[key]; ok && key != "" {
+					if mavenSettingsByID[name] {
+						section.entries[pos] = entry
+					}
+					continue
+				}
+				section.ids[key] = len(section.entries)
+				section.entries = append(section.entries, entry)
+			}
+		}
+	}
+
+	var merged bytes.Buffer
+	merged.WriteString(strings.TrimSuffix(emptyMavenSettings, "</settings>\n"))
+	for _, section := range sections {
+		if section.entries == nil {
+			fmt.Fprintf(&merged, "  <%s>%s</%s>\n", section.name, section.inner, section.name)
+			continue
+		}
+		fmt.Fprintf(&merged, "  <%s>\n", section.name)
+		for _, entry := range section.entries {
+			fmt.Fprintf(&merged, "    %s\n", wrapMavenSettingsElement(entry))
+		}
+		fmt.Fprintf(&merged, "  </%s>\n", section.name)
+	}
+	merged.WriteString("</settings>\n")
+	return merged.Bytes(), nil
+}
+
+// wrapMavenSettingsElement returns the raw XML of an element
+func wrapMavenSettingsElement(element mavenSettingsElement) []byte {
+	name := element.XMLName.Local
+	return []byte(fmt.Sprintf("<%s>%s</%s>", name, element.Inner, name))
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveMavenSettings(t *testing.T) {
+	dir := t.TempDir()
+	company := filepath.Join(dir, "company.xml")
+	require.NoError(t, os.WriteFile(company, []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<settings xmlns="http://maven.apache.org/SETTINGS/1.0.0">
+  <localRepository>/company/m2</localRepository>
+  <mirrors>
+    <mirror><id>central</id><url>https://company.example.com/maven</url><mirrorOf>*</mirrorOf></mirror>
+  </mirrors>
+  <servers>
+    <server><id>company</id><username>ci</username></server>
+  </servers>
+  <activeProfiles><activeProfile>company</activeProfile></activeProfiles>
+</settings>
+`), 0644))
+	team := filepath.Join(dir, "team.xml")
+	require.NoError(t, os.WriteFile(team, []byte(`<settings>
+  <mirrors>
+    <mirror><id>central</id><url>https://team.example.com/maven</url><mirrorOf>*</mirrorOf></mirror>
+  </mirrors>
+  <servers>
+    <server><id>team</id><username>dev</username></server>
+  </servers>
+  <activeProfiles><activeProfile>company</activeProfile><activeProfile>team</activeProfile></activeProfiles>
+</settings>
+`), 0644))
+
+	a := &analyzeCommand{
+		mavenSettingsFiles: []string{company, team},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	require.NoError(t, a.resolveMavenSettings())
+	require.Len(t, a.tempDirs, 1)
+	assert.Equal(t, filepath.Join(a.tempDirs[0], "settings.xml"), a.mavenSettingsFile)
+	require.NoError(t, validateMavenSettingsFile(a.mavenSettingsFile))
+
+	content, err := os.ReadFile(a.mavenSettingsFile)
+	require.NoError(t, err)
+	merged := struct {
+		LocalRepository string `xml:"localRepository"`
+		Mirrors         []struct {
+			ID  string `xml:"id"`
+			URL string `xml:"url"`
+		} `xml:"mirrors>mirror"`
+		Servers []struct {
+			ID string `xml:"id"`
+		} `xml:"servers>server"`
+		ActiveProfiles []string `xml:"activeProfiles>activeProfile"`
+	}{}
+	require.NoError(t, xml.Unmarshal(content, &merged))
+	assert.Equal(t, "/company/m2", merged.LocalRepository)
+	require.Len(t, merged.Mirrors, 1)
+	assert.Equal(t, "https://team.example.com/maven", merged.Mirrors[0].URL)
+	require.Len(t, merged.Servers, 2)
+	assert.Equal(t, "company", merged.Servers[0].ID)
+	assert.Equal(t, "team", merged.Servers[1].ID)
+	assert.Equal(t, []string{"company", "team"}, merged.ActiveProfiles)
+}
+
+func TestResolveMavenSettingsSingleFile(t *testing.T) {
+	settings := filepath.Join(t.TempDir(), "settings.xml")
+	require.NoError(t, os.WriteFile(settings, []byte(emptyMavenSettings), 0644))
+	a := &analyzeCommand{mavenSettingsFiles: []string{settings}}
+	require.NoError(t, a.resolveMavenSettings())
+	assert.Equal(t, settings, a.mavenSettingsFile)
+	assert.Empty(t, a.tempDirs)
+}
+
+func TestResolveMavenSettingsInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.xml")
+	require.NoError(t, os.WriteFile(valid, []byte(emptyMavenSettings), 0644))
+	invalid := filepath.Join(dir, "invalid.xml")
+	require.NoError(t, os.WriteFile(invalid, []byte("<settings><servers></settings>"), 0644))
+	a := &analyzeCommand{mavenSettingsFiles: []string{valid, invalid}}
+	assert.Error(t, a.resolveMavenSettings())
+	assert.Empty(t, a.tempDirs)
+}
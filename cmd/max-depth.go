@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// findTooDeepDirs collects the input directories below --max-depth, they
+// are excluded from the provider configs so nested projects of the input
+// aren't analyzed. With depth 0 only the files of the input dir are kept.
+func (a *analyzeCommand) findTooDeepDirs() error {
+	if a.maxDepth < 0 {
+		return nil
+	}
+	if a.isFileInput {
+		a.log.Info("WARNING: --max-depth is ignored for binary input")
+		return nil
+	}
+	a.tooDeepDirs = []string{}
+	return filepath.WalkDir(a.input, func(dirPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || dirPath == a.input {
+			return nil
+		}
+		rel, err := filepath.Rel(a.input, dirPath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if strings.Count(rel, "/") < a.maxDepth {
+			return nil
+		}
+		a.log.V(1).Info("skipping directory below max-depth", "dir", rel, "maxDepth", a.maxDepth)
+		a.tooDeepDirs = append(a.tooDeepDirs, rel)
+		return filepath.SkipDir
+	})
+}
+
+// tooDeepDirPaths returns the directories below --max-depth under the given
+// input location
+func (a *analyzeCommand) tooDeepDirPaths(location string, useContainerPath bool) []interface{} {
+	paths := []interface{}{}
+	for _, dir := range a.tooDeepDirs {
+		if useContainerPath {
+			paths = append(paths, path.Join(location, dir))
+			continue
+		}
+		paths = append(paths, filepath.Join(location, filepath.FromSlash(dir)))
+	}
+	return paths
+}
+
+// isBelowMaxDepth reports whether location is one of the input directories
+// below --max-depth or inside one of them
+func (a *analyzeCommand) isBelowMaxDepth(location string) bool {
+	for _, dir := range a.tooDeepDirPaths(a.input, false) {
+		rel, err := filepath.Rel(dir.(string), location)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateMaxDepth(maxDepth int) error {
+	if maxDepth < -1 {
+		return fmt.Errorf("invalid --max-depth %d, must be 0 or more, or -1 for no limit", maxDepth)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindTooDeepDirs(t *testing.T) {
+	input := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(input, "src", "main", "java"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(input, "modules", "billing", "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(input, "pom.xml"), []byte("<project/>"), 0644))
+
+	tests := []struct {
+		maxDepth int
+		want     []string
+	}{
+		{maxDepth: -1, want: nil},
+		{maxDepth: 0, want: []string{"modules", "src"}},
+		{maxDepth: 1, want: []string{"modules/billing", "src/main"}},
+		{maxDepth: 3, want: []string{}},
+	}
+	for _, tt := range tests {
+		a := &analyzeCommand{
+			input:                 input,
+			maxDepth:              tt.maxDepth,
+			AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+		}
+		require.NoError(t, a.findTooDeepDirs())
+		assert.Equal(t, tt.want, a.tooDeepDirs, "max depth %d", tt.maxDepth)
+	}
+
+	a := &analyzeCommand{
+		input:                 input,
+		maxDepth:              1,
+		AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+	}
+	require.NoError(t, a.findTooDeepDirs())
+	assert.Equal(t, []interface{}{"/opt/input/source/modules/billing", "/opt/input/source/src/main"}, a.excludedDirs("/opt/input/source", true))
+	assert.True(t, a.isBelowMaxDepth(filepath.Join(input, "src", "main", "java")))
+	assert.True(t, a.isBelowMaxDepth(filepath.Join(input, "src", "main")))
+	assert.False(t, a.isBelowMaxDepth(filepath.Join(input, "src")))
+	assert.False(t, a.isBelowMaxDepth(filepath.Join(input, "src", "mainframe")))
+}
+
+func TestValidateMaxDepth(t *testing.T) {
+	assert.NoError(t, validateMaxDepth(-1))
+	assert.NoError(t, validateMaxDepth(0))
+	assert.Error(t, validateMaxDepth(-2))
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fileSizeUnits are the --max-file-size suffixes, multiples of 1024
+var fileSizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+}
+
+// parseFileSize parses a size in bytes with an optional unit, e.g. 10MB
+func parseFileSize(size string) (int64, error) {
+	size = strings.TrimSpace(size)
+	digits := strings.TrimRightFunc(size, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	multiplier, ok := fileSizeUnits[strings.ToLower(strings.TrimSpace(size[len(digits):]))]
+	if !ok || digits == "" {
+		return 0, fmt.Errorf("invalid file size %q, must be a number of bytes with an optional KB, MB or GB unit", size)
+	}
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || value < 0 || value > (1<<63-1)/multiplier {
+		return 0, fmt.Errorf("invalid file size %q, must be a number of bytes with an optional KB, MB or GB unit", size)
+	}
+	return value * multiplier, nil
+}
+
+// findOversizedFiles collects the input files above --max-file-size, they
+// are excluded from the provider configs so huge generated files don't
+// stall the analysis. Every skipped file is logged.
+func (a *analyzeCommand) findOversizedFiles() error {
+	if a.maxFileSize == "" {
+		return nil
+	}
+	maxSize, err := parseFileSize(a.maxFileSize)
+	if err != nil {
+		return err
+	}
+	if maxSize == 0 {
+		return nil
+	}
+	if a.isFileInput {
+		a.log.Info("WARNING: --max-file-size is ignored for binary input")
+		return nil
+	}
+	a.oversizedFiles = []string{}
+	return filepath.WalkDir(a.input, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() <= maxSize {
+			return nil
+		}
+		rel, err := filepath.Rel(a.input, filePath)
+		if err != nil {
+			return err
+		}
+		a.log.Info("WARNING: skipping file larger than max-file-size", "file", rel, "size", info.Size(), "maxFileSize", maxSize)
+		a.oversizedFiles = append(a.oversizedFiles, filepath.ToSlash(rel))
+		return nil
+	})
+}
+
+// oversizedFilePaths returns the files above --max-file-size under the given
+// input location
+func (a *analyzeCommand) oversizedFilePaths(location string, useContainerPath bool) []interface{} {
+	paths := []interface{}{}
+	for _, file := range a.oversizedFiles {
+		if useContainerPath {
+			paths = append(paths, path.Join(location, file))
+			continue
+		}
+		paths = append(paths, filepath.Join(location, filepath.FromSlash(file)))
+	}
+	return paths
+}
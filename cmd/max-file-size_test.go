@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFileSize(t *testing.T) {
+	tests := []struct {
+		size    string
+		want    int64
+		wantErr bool
+	}{
+		{size: "1024", want: 1024},
+		{size: "512B", want: 512},
+		{size: "10KB", want: 10 << 10},
+		{size: "10MB", want: 10 << 20},
+		{size: "2 gb", want: 2 << 30},
+		{size: "1GiB", want: 1 << 30},
+		{size: "0", want: 0},
+		{size: "10TB", wantErr: true},
+		{size: "MB", wantErr: true},
+		{size: "-1", wantErr: true},
+		{size: "1.5MB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			got, err := parseFileSize(tt.size)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindOversizedFiles(t *testing.T) {
+	input := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(input, "db"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(input, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(input, "db", "dump.sql"), make([]byte, 2048), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(input, ".git", "pack"), make([]byte, 2048), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(input, "Main.java"), make([]byte, 100), 0644))
+
+	a := &analyzeCommand{
+		input:                 input,
+		maxFileSize:           "1KB",
+		AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+	}
+	require.NoError(t, a.findOversizedFiles())
+	assert.Equal(t, []string{"db/dump.sql"}, a.oversizedFiles)
+	assert.Equal(t, []interface{}{filepath.Join(input, "db", "dump.sql")}, a.excludedDirs(input, false))
+	assert.Equal(t, []interface{}{"/opt/input/source/db/dump.sql"}, a.excludedDirs("/opt/input/source", true))
+
+	a = &analyzeCommand{input: input, AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+	require.NoError(t, a.findOversizedFiles())
+	assert.Empty(t, a.excludedDirs(input, false))
+}
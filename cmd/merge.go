@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type mergeCommand struct {
+	inputs           []string
+	output           string
+	overwrite        bool
+	skipStaticReport bool
+	log              logr.Logger
+}
+
+func NewMergeCommand(log logr.Logger) *cobra.Command {
+	mergeCmd := &mergeCommand{
+		log: log,
+	}
+
+	mergeCommand := &cobra.Command{
+		Use:   "merge --output <dir> <output.yaml>...",
+		Short: "Merge multiple analysis output files into a single output and static report",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mergeCmd.inputs = args
+			err := mergeCmd.Validate()
+			if err != nil {
+				log.Error(err, "failed to validate flags")
+				return err
+			}
+			err = mergeCmd.Run()
+			if err != nil {
+				log.Error(err, "failed to merge analysis output")
+				return err
+			}
+			return nil
+		},
+	}
+	mergeCommand.Flags().StringVarP(&mergeCmd.output, "output", "o", "", "path to the directory for merged output")
+	mergeCommand.Flags().BoolVar(&mergeCmd.overwrite, "overwrite", false, "overwrite output directory")
+	mergeCommand.Flags().BoolVar(&mergeCmd.skipStaticReport, "skip-static-report", false, "do not generate static report")
+	mergeCommand.MarkFlagRequired("output")
+
+	return mergeCommand
+}
+
+func (m *mergeCommand) Validate() error {
+	for idx, input := range m.inputs {
+		absPath, err := filepath.Abs(input)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path for %s: %w", input, err)
+		}
+		stat, err := os.Stat(absPath)
+		if err != nil {
+			return fmt.Errorf("failed to stat analysis output %s: %w", input, err)
+		}
+		if stat.IsDir() {
+			return fmt.Errorf("analysis output %s must be a file", input)
+		}
+		m.inputs[idx] = absPath
+	}
+	absOutput, err := filepath.Abs(m.output)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for output %s: %w", m.output, err)
+	}
+	m.output = absOutput
+	stat, err := os.Stat(m.output)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if stat == nil {
+		return nil
+	}
+	if !m.overwrite {
+		return fmt.Errorf("output dir %v already exists and --overwrite not set", m.output)
+	}
+	for _, input := range m.inputs {
+		if strings.HasPrefix(input, m.output+string(filepath.Separator)) {
+			return fmt.Errorf("analysis output %s is inside output dir %s which would be overwritten", input, m.output)
+		}
+	}
+	return os.RemoveAll(m.output)
+}
+
+func (m *mergeCommand) Run() error {
+	rulesetsList := [][]outputv1.RuleSet{}
+	for _, input := range m.inputs {
+		content, err := readOutputFile(input)
+		if err != nil {
+			return fmt.Errorf("failed to read analysis output %s: %w", input, err)
+		}
+		rulesets := []outputv1.RuleSet{}
+		err = yaml.Unmarshal(content, &rulesets)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal analysis output %s: %w", input, err)
+		}
+		rulesetsList = append(rulesetsList, rulesets)
+	}
+	merged := mergeRuleSets(rulesetsList...)
+
+	err := os.MkdirAll(m.output, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create output dir %s: %w", m.output, err)
+	}
+	b, err := yaml.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("failed to marshal merged output: %w", err)
+	}
+	outputPath := filepath.Join(m.output, "output.yaml")
+	err = os.WriteFile(outputPath, b, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write output.yaml: %w", err)
+	}
+	m.log.Info("merged analysis output", "inputs", len(m.inputs), "output", outputPath)
+
+	if m.skipStaticReport {
+		return nil
+	}
+	return m.generateStaticReport(outputPath)
+}
+
+func (m *mergeCommand) generateStaticReport(outputPath string) error {
+	kantraDir, err := findKantraDir(m.log)
+	if err != nil {
+		return fmt.Errorf("failed to find kantra dir: %w", err)
+	}
+	staticReportPath := filepath.Join(m.output, "static-report")
+	err = util.CopyFolderContents(filepath.Join(kantraDir, "static-report"), staticReportPath)
+	if err != nil {
+		return fmt.Errorf("failed to copy static report files: %w", err)
+	}
+	apps, err := validateFlags([]string{outputPath}, []string{filepath.Base(m.output)}, []string{}, m.log)
+	if err != nil {
+		return fmt.Errorf("failed to validate flags: %w", err)
+	}
+	err = loadApplications(apps)
+	if err != nil {
+		return fmt.Errorf("failed to load report data from merged output: %w", err)
+	}
+	err = generateJSBundle(apps, staticReportInfo{}, filepath.Join(staticReportPath, "output.js"), m.log)
+	if err != nil {
+		return fmt.Errorf("failed to generate output.js file from template: %w", err)
+	}
+	m.log.Info("Static report created. Access it at this URL:", "URL", "file://"+filepath.Join(staticReportPath, "index.html"))
+	return nil
+}
+
+// mergeRuleSets combines rulesets from several analyses by ruleset name.
+// Violations and insights of the same rule are combined with identical
+// incidents deduplicated.
+func mergeRuleSets(rulesetsList ...[]outputv1.RuleSet) []outputv1.RuleSet {
+	byName := map[string]*outputv1.RuleSet{}
+	for _, rulesets := range rulesetsList {
+		for _, rs := range rulesets {
+			merged, ok := byName[rs.Name]
+			if !ok {
+				merged = &outputv1.RuleSet{
+					Name:        rs.Name,
+					Description: rs.Description,
+				}
+				byName[rs.Name] = merged
+			}
+			merged.Tags = mergeStrings(merged.Tags, rs.Tags)
+			merged.Unmatched = mergeStrings(merged.Unmatched, rs.Unmatched)
+			merged.Skipped = mergeStrings(merged.Skipped, rs.Skipped)
+			if len(rs.Errors) > 0 && merged.Errors == nil {
+				merged.Errors = map[string]string{}
+			}
+			for ruleID, msg := range rs.Errors {
+				merged.Errors[ruleID] = msg
+			}
+			merged.Violations = mergeViolations(merged.Violations, rs.Violations)
+			merged.Insights = mergeViolations(merged.Insights, rs.Insights)
+		}
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]outputv1.RuleSet, 0, len(names))
+	for _, name := range names {
+		rs := byName[name]
+		// a rule matched in any input is no longer unmatched
+		rs.Unmatched = removeMatched(rs.Unmatched, rs.Violations, rs.Insights)
+		result = append(result, *rs)
+	}
+	return result
+}
+
+func mergeViolations(into map[string]outputv1.Violation, from map[string]outputv1.Violation) map[string]outputv1.Violation {
+	if len(from) == 0 {
+		return into
+	}
+	if into == nil {
+		into = map[string]outputv1.Violation{}
+	}
+	for ruleID, violation := range from {
+		existing, ok := into[ruleID]
+		if !ok {
+			violation.Incidents = dedupeIncidents(nil, violation.Incidents)
+			into[ruleID] = violation
+			continue
+		}
+		existing.Labels = mergeStrings(existing.Labels, violation.Labels)
+		for _, link := range violation.Links {
+			if !slices.Contains(existing.Links, link) {
+				existing.Links = append(existing.Links, link)
+			}
+		}
+		existing.Incidents = dedupeIncidents(existing.Incidents, violation.Incidents)
+		into[ruleID] = existing
+	}
+	return into
+}
+
+// dedupeIncidents appends incidents to existing skipping identical ones
+func dedupeIncidents(existing []outputv1.Incident, incidents []outputv1.Incident) []outputv1.Incident {
+	seen := map[string]bool{}
+	result := make([]outputv1.Incident, 0, len(existing)+len(incidents))
+	for _, incident := range append(append([]outputv1.Incident{}, existing...), incidents...) {
+		key, err := json.Marshal(incident)
+		if err == nil {
+			if seen[string(key)] {
+				continue
+			}
+			seen[string(key)] = true
+		}
+		result = append(result, incident)
+	}
+	return result
+}
+
+func mergeStrings(into []string, from []string) []string {
+	for _, s := range from {
+		if !slices.Contains(into, s) {
+			into = append(into, s)
+		}
+	}
+	return into
+}
+
+func removeMatched(unmatched []string, violations ...map[string]outputv1.Violation) []string {
+	result := []string{}
+	for _, ruleID := range unmatched {
+		matched := false
+		for _, v := range violations {
+			if _, ok := v[ruleID]; ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			result = append(result, ruleID)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
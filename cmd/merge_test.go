@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+	"gopkg.in/yaml.v2"
+)
+
+func TestMergeRuleSets(t *testing.T) {
+	first := []outputv1.RuleSet{
+		{
+			Name:      "ruleset-a",
+			Tags:      []string{"Java"},
+			Unmatched: []string{"rule-2", "rule-3"},
+			Violations: map[string]outputv1.Violation{
+				"rule-1": {
+					Description: "rule 1",
+					Incidents: []outputv1.Incident{
+						{URI: "file:///svc-a/A.java", Message: "found", LineNumber: intPtr(1)},
+					},
+				},
+			},
+		},
+	}
+	second := []outputv1.RuleSet{
+		{
+			Name:      "ruleset-a",
+			Tags:      []string{"Java", "Spring"},
+			Unmatched: []string{"rule-3"},
+			Violations: map[string]outputv1.Violation{
+				"rule-1": {
+					Description: "rule 1",
+					Incidents: []outputv1.Incident{
+						// identical incident is deduplicated
+						{URI: "file:///svc-a/A.java", Message: "found", LineNumber: intPtr(1)},
+						{URI: "file:///svc-b/B.java", Message: "found", LineNumber: intPtr(2)},
+					},
+				},
+				"rule-2": {
+					Description: "rule 2",
+					Incidents: []outputv1.Incident{
+						{URI: "file:///svc-b/C.java", Message: "found"},
+					},
+				},
+			},
+		},
+		{
+			Name: "ruleset-b",
+			Violations: map[string]outputv1.Violation{
+				"rule-4": {Description: "rule 4"},
+			},
+		},
+	}
+
+	merged := mergeRuleSets(first, second)
+	require.Len(t, merged, 2)
+
+	a := merged[0]
+	assert.Equal(t, "ruleset-a", a.Name)
+	assert.Equal(t, []string{"Java", "Spring"}, a.Tags)
+	assert.Equal(t, []string{"rule-3"}, a.Unmatched)
+	require.Len(t, a.Violations, 2)
+	assert.Len(t, a.Violations["rule-1"].Incidents, 2)
+	assert.Len(t, a.Violations["rule-2"].Incidents, 1)
+
+	assert.Equal(t, "ruleset-b", merged[1].Name)
+	assert.Contains(t, merged[1].Violations, "rule-4")
+}
+
+func TestMergeCommand_Run(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputs := []string{}
+	for i, file := range []uri.URI{"file:///svc-a/A.java", "file:///svc-b/B.java"} {
+		rulesets := []outputv1.RuleSet{
+			{
+				Name: "ruleset-a",
+				Violations: map[string]outputv1.Violation{
+					"rule-1": {Incidents: []outputv1.Incident{{URI: "file:///shared/S.java"}, {URI: file}}},
+				},
+			},
+		}
+		b, err := yaml.Marshal(rulesets)
+		require.NoError(t, err)
+		input := filepath.Join(tmpDir, "input", string(rune('a'+i)), "output.yaml")
+		require.NoError(t, os.MkdirAll(filepath.Dir(input), 0755))
+		require.NoError(t, os.WriteFile(input, b, 0644))
+		inputs = append(inputs, input)
+	}
+
+	m := &mergeCommand{
+		inputs:           inputs,
+		output:           filepath.Join(tmpDir, "combined"),
+		skipStaticReport: true,
+		log:              logr.Discard(),
+	}
+	require.NoError(t, m.Validate())
+	require.NoError(t, m.Run())
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "combined", "output.yaml"))
+	require.NoError(t, err)
+	merged := []outputv1.RuleSet{}
+	require.NoError(t, yaml.Unmarshal(content, &merged))
+	require.Len(t, merged, 1)
+	assert.Len(t, merged[0].Violations["rule-1"].Incidents, 3)
+
+	// existing output requires --overwrite
+	m.inputs = inputs
+	assert.Error(t, m.Validate())
+	m.overwrite = true
+	assert.NoError(t, m.Validate())
+}
+
+func TestMergeCommand_ValidateMissingInput(t *testing.T) {
+	m := &mergeCommand{
+		inputs: []string{filepath.Join(t.TempDir(), "missing.yaml")},
+		output: t.TempDir(),
+		log:    logr.Discard(),
+	}
+	assert.Error(t, m.Validate())
+}
+
+func intPtr(i int) *int {
+	return &i
+}
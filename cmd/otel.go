@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const otlpTracesPath = "/v1/traces"
+
+// otlpTracesURL returns the OTLP/HTTP traces URL for the --otel-endpoint
+// value, which may be a host:port or a full URL
+func otlpTracesURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		u, err = url.Parse("http://" + endpoint)
+		if err != nil {
+			return "", fmt.Errorf("invalid otel endpoint %s: %w", endpoint, err)
+		}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid otel endpoint %s: scheme must be http or https", endpoint)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid otel endpoint %s: missing host", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpTracesPath
+	}
+	return u.String(), nil
+}
+
+// validateOtelFlags validates the --otel-* flags. --otel-endpoint can't be
+// used with --jaeger-endpoint, both set the global tracer provider.
+func validateOtelFlags(endpoint string, sampleRate float64, jaegerEndpoint string) error {
+	if endpoint != "" && jaegerEndpoint != "" {
+		return fmt.Errorf("must not specify both otel-endpoint and jaeger-endpoint")
+	}
+	if sampleRate < 0 || sampleRate > 1 {
+		return fmt.Errorf("otel-sample-rate must be between 0 and 1")
+	}
+	if endpoint == "" {
+		return nil
+	}
+	_, err := otlpTracesURL(endpoint)
+	return err
+}
+
+// initOtelTracing sets a global tracer provider exporting spans over
+// OTLP/HTTP to endpoint, so the spans started with tracing.StartNewSpan
+// are shipped to a collector
+func initOtelTracing(ctx context.Context, log logr.Logger, endpoint string, sampleRate float64) (*sdktrace.TracerProvider, error) {
+	tracesURL, err := otlpTracesURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(tracesURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRate))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "kantra"))),
+	)
+	otel.SetTracerProvider(tp)
+	log.V(1).Info("exporting traces", "url", tracesURL, "sampleRate", sampleRate)
+	return tp, nil
+}
+
+// shutdownOtelTracing flushes remaining spans to the collector
+func shutdownOtelTracing(log logr.Logger, tp *sdktrace.TracerProvider) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := tp.Shutdown(ctx); err != nil {
+		log.Error(err, "failed to flush traces")
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOtlpTracesURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		want     string
+		wantErr  bool
+	}{
+		{name: "host and port", endpoint: "localhost:4318", want: "http://localhost:4318/v1/traces"},
+		{name: "url without path", endpoint: "https://collector:4318", want: "https://collector:4318/v1/traces"},
+		{name: "url with path", endpoint: "http://collector:4318/custom/traces", want: "http://collector:4318/custom/traces"},
+		{name: "unsupported scheme", endpoint: "grpc://collector:4317", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := otlpTracesURL(tt.endpoint)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateOtelFlags(t *testing.T) {
+	assert.NoError(t, validateOtelFlags("", 1, ""))
+	assert.NoError(t, validateOtelFlags("localhost:4318", 0.5, ""))
+	assert.NoError(t, validateOtelFlags("", 1, "http://localhost:14268/api/traces"))
+	assert.Error(t, validateOtelFlags("localhost:4318", 1.5, ""))
+	assert.Error(t, validateOtelFlags("", -0.1, ""))
+	assert.Error(t, validateOtelFlags("localhost:4318", 1, "http://localhost:14268/api/traces"))
+}
+
+func TestInitOtelTracingExportsSpans(t *testing.T) {
+	received := &coltracepb.ExportTraceServiceRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(body, received))
+	}))
+	defer server.Close()
+
+	tp, err := initOtelTracing(context.Background(), logr.Discard(), server.URL, 1)
+	require.NoError(t, err)
+	_, span := tp.Tracer("kantra-test").Start(context.Background(), "analysis")
+	span.SetAttributes(attribute.Int("rules", 3))
+	span.End()
+	shutdownOtelTracing(logr.Discard(), tp)
+
+	require.Len(t, received.ResourceSpans, 1)
+	require.Len(t, received.ResourceSpans[0].ScopeSpans, 1)
+	scopeSpans := received.ResourceSpans[0].ScopeSpans[0]
+	assert.Equal(t, "kantra-test", scopeSpans.Scope.Name)
+	require.Len(t, scopeSpans.Spans, 1)
+	got := scopeSpans.Spans[0]
+	assert.Equal(t, "analysis", got.Name)
+	assert.Len(t, got.TraceId, 16)
+	assert.Len(t, got.SpanId, 8)
+	require.Len(t, got.Attributes, 1)
+	assert.Equal(t, "rules", got.Attributes[0].Key)
+	assert.Equal(t, int64(3), got.Attributes[0].Value.GetIntValue())
+}
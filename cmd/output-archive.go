@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// validateOutputArchive checks the --output-archive path and makes it absolute
+func (a *analyzeCommand) validateOutputArchive() error {
+	if a.outputArchive == "" {
+		return nil
+	}
+	if !strings.EqualFold(filepath.Ext(a.outputArchive), ".zip") {
+		return fmt.Errorf("output archive %s must be a .zip file", a.outputArchive)
+	}
+	absPath, err := filepath.Abs(a.outputArchive)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for output archive %s: %w", a.outputArchive, err)
+	}
+	if stat, err := os.Stat(absPath); err == nil && stat.IsDir() {
+		return fmt.Errorf("output archive %s is a directory", absPath)
+	}
+	a.outputArchive = absPath
+	return nil
+}
+
+// writeOutputArchive packages all files in outputDir into a zip at archivePath,
+// preserving the directory structure relative to outputDir
+func writeOutputArchive(outputDir string, archivePath string) error {
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for output archive %s: %w", archivePath, err)
+	}
+	// write to a temporary file first so a failed run doesn't leave a partial archive
+	tmpFile, err := os.CreateTemp(filepath.Dir(archivePath), ".kantra-archive-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create output archive %s: %w", archivePath, err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	zw := zip.NewWriter(tmpFile)
+	err = filepath.WalkDir(outputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// the archive may be written inside the output directory
+		if path == tmpPath || path == archivePath {
+			return nil
+		}
+		rel, err := filepath.Rel(outputDir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		if d.IsDir() {
+			_, err := zw.Create(name + "/")
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return addFileToArchive(zw, path, name)
+	})
+	if err != nil {
+		zw.Close()
+		tmpFile.Close()
+		return fmt.Errorf("failed to archive output directory %s: %w", outputDir, err)
+	}
+	if err := zw.Close(); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write output archive %s: %w", archivePath, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write output archive %s: %w", archivePath, err)
+	}
+	if err := os.Rename(tmpPath, archivePath); err != nil {
+		return fmt.Errorf("failed to move output archive to %s: %w", archivePath, err)
+	}
+	return nil
+}
+
+func addFileToArchive(zw *zip.Writer, path string, name string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOutputArchive(t *testing.T) {
+	tmpDir := t.TempDir()
+	tests := []struct {
+		name    string
+		archive string
+		wantErr bool
+	}{
+		{name: "empty is allowed", archive: ""},
+		{name: "zip file", archive: filepath.Join(tmpDir, "results.zip")},
+		{name: "upper case extension", archive: filepath.Join(tmpDir, "results.ZIP")},
+		{name: "not a zip", archive: filepath.Join(tmpDir, "results.tar.gz"), wantErr: true},
+		{name: "directory", archive: tmpDir + ".zip", wantErr: true},
+	}
+	require.NoError(t, os.Mkdir(tmpDir+".zip", 0755))
+	t.Cleanup(func() { os.RemoveAll(tmpDir + ".zip") })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzeCommand{outputArchive: tt.archive}
+			err := a.validateOutputArchive()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.archive != "" {
+				assert.True(t, filepath.IsAbs(a.outputArchive))
+			}
+		})
+	}
+}
+
+func TestWriteOutputArchive(t *testing.T) {
+	outputDir := t.TempDir()
+	files := map[string]string{
+		"output.yaml":                       "rulesets",
+		"dependencies.yaml":                 "deps",
+		"analysis.log":                      "log",
+		"static-report/index.html":          "<html></html>",
+		"static-report/static/js/output.js": "window[\"apps\"] = []",
+	}
+	for name, content := range files {
+		path := filepath.Join(outputDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	// archive written inside the output directory must not include itself
+	archivePath := filepath.Join(outputDir, "results.zip")
+	require.NoError(t, writeOutputArchive(outputDir, archivePath))
+
+	r, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	defer r.Close()
+
+	got := []string{}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		got = append(got, f.Name)
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		assert.Equal(t, files[f.Name], string(b))
+	}
+	want := []string{}
+	for name := range files {
+		want = append(want, name)
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
+)
+
+// compilePathPatterns compiles --include-path or --exclude-path glob patterns
+func compilePathPatterns(flag string, patterns []string) ([]*regexp.Regexp, error) {
+	compiled := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		re, err := util.GlobToRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", flag, pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+func (a *analyzeCommand) validatePathFilters() error {
+	if _, err := compilePathPatterns("include-path", a.includePaths); err != nil {
+		return err
+	}
+	_, err := compilePathPatterns("exclude-path", a.excludePaths)
+	return err
+}
+
+// filterIncidentsByPath applies --include-path and --exclude-path to the
+// analysis results. Unlike --exclude the rules still run on every file, only
+// the reported incidents are filtered, including dependency incidents.
+func (a *analyzeCommand) filterIncidentsByPath(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if len(a.includePaths) == 0 && len(a.excludePaths) == 0 {
+		return rulesets
+	}
+	include, err := compilePathPatterns("include-path", a.includePaths)
+	if err != nil {
+		// patterns are validated before analysis starts
+		a.log.Error(err, "failed to compile include-path patterns")
+		return rulesets
+	}
+	exclude, err := compilePathPatterns("exclude-path", a.excludePaths)
+	if err != nil {
+		a.log.Error(err, "failed to compile exclude-path patterns")
+		return rulesets
+	}
+	roots := a.incidentRoots()
+	return filterIncidents(rulesets, func(incidentURI uri.URI) bool {
+		// incidents without a file path can't be matched, keep them
+		if !strings.HasPrefix(string(incidentURI), "file:") {
+			return true
+		}
+		if len(include) > 0 && !uriMatchesAny(incidentURI, roots, include) {
+			return false
+		}
+		return !uriMatchesAny(incidentURI, roots, exclude)
+	})
+}
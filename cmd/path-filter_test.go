@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"go.lsp.dev/uri"
+)
+
+func TestFilterIncidentsByPath(t *testing.T) {
+	input := t.TempDir()
+	fileURI := func(rel string) uri.URI {
+		return uri.File(filepath.Join(input, rel))
+	}
+	newRulesets := func() []outputv1.RuleSet {
+		return []outputv1.RuleSet{
+			{
+				Name: "test",
+				Violations: map[string]outputv1.Violation{
+					"source": {
+						Incidents: []outputv1.Incident{
+							{URI: fileURI("src/main/java/com/mycorp/App.java")},
+							{URI: fileURI("src/main/java/com/mycorp/generated/Gen.java")},
+							{URI: fileURI("src/main/java/org/other/Lib.java")},
+						},
+					},
+					"dependency": {
+						Incidents: []outputv1.Incident{{URI: fileURI("pom.xml")}},
+					},
+					"no-file": {
+						Incidents: []outputv1.Incident{{URI: "jar:file:///lib.jar!/A.class"}},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    map[string][]uri.URI
+	}{
+		{
+			name:    "include only",
+			include: []string{"src/main/java/com/mycorp"},
+			want: map[string][]uri.URI{
+				"source":  {fileURI("src/main/java/com/mycorp/App.java"), fileURI("src/main/java/com/mycorp/generated/Gen.java")},
+				"no-file": {"jar:file:///lib.jar!/A.class"},
+			},
+		},
+		{
+			name:    "include and exclude",
+			include: []string{"src/main/java/com/mycorp", "pom.xml"},
+			exclude: []string{"**/generated"},
+			want: map[string][]uri.URI{
+				"source":     {fileURI("src/main/java/com/mycorp/App.java")},
+				"dependency": {fileURI("pom.xml")},
+				"no-file":    {"jar:file:///lib.jar!/A.class"},
+			},
+		},
+		{
+			name:    "exclude only",
+			exclude: []string{"src"},
+			want: map[string][]uri.URI{
+				"dependency": {fileURI("pom.xml")},
+				"no-file":    {"jar:file:///lib.jar!/A.class"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzeCommand{
+				input:        input,
+				includePaths: tt.include,
+				excludePaths: tt.exclude,
+				AnalyzeCommandContext: AnalyzeCommandContext{
+					log: logr.Discard(),
+				},
+			}
+			got := map[string][]uri.URI{}
+			for ruleID, violation := range a.filterIncidentsByPath(newRulesets())[0].Violations {
+				for _, incident := range violation.Incidents {
+					got[ruleID] = append(got[ruleID], incident.URI)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidatePathFilters(t *testing.T) {
+	a := &analyzeCommand{includePaths: []string{"src/**"}, excludePaths: []string{"**/test"}}
+	assert.NoError(t, a.validatePathFilters())
+	a.excludePaths = []string{""}
+	assert.Error(t, a.validatePathFilters())
+}
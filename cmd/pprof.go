@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startCPUProfile writes a CPU profile of the analysis to --pprof-cpu, the
+// returned func stops it
+func (a *analyzeCommand) startCPUProfile() (func(), error) {
+	if a.pprofCPU == "" {
+		return func() {}, nil
+	}
+	file, err := os.Create(a.pprofCPU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cpu profile %s: %w", a.pprofCPU, err)
+	}
+	if err := pprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to start cpu profile: %w", err)
+	}
+	a.log.V(1).Info("writing cpu profile", "file", a.pprofCPU)
+	return func() {
+		pprof.StopCPUProfile()
+		file.Close()
+	}, nil
+}
+
+// writeHeapProfile writes a heap profile to --pprof-mem at the end of the
+// analysis
+func (a *analyzeCommand) writeHeapProfile() error {
+	if a.pprofMem == "" {
+		return nil
+	}
+	file, err := os.Create(a.pprofMem)
+	if err != nil {
+		return fmt.Errorf("failed to create heap profile %s: %w", a.pprofMem, err)
+	}
+	defer file.Close()
+	// up to date statistics of the objects still in use
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return fmt.Errorf("failed to write heap profile %s: %w", a.pprofMem, err)
+	}
+	a.log.V(1).Info("wrote heap profile", "file", a.pprofMem)
+	return nil
+}
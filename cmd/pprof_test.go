@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	a := &analyzeCommand{AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()}}
+	stop, err := a.startCPUProfile()
+	require.NoError(t, err)
+	stop()
+	require.NoError(t, a.writeHeapProfile())
+
+	dir := t.TempDir()
+	a.pprofCPU = filepath.Join(dir, "cpu.pprof")
+	a.pprofMem = filepath.Join(dir, "mem.pprof")
+	stop, err = a.startCPUProfile()
+	require.NoError(t, err)
+	stop()
+	require.NoError(t, a.writeHeapProfile())
+	for _, profile := range []string{a.pprofCPU, a.pprofMem} {
+		stat, err := os.Stat(profile)
+		require.NoError(t, err)
+		assert.NotZero(t, stat.Size(), profile)
+	}
+
+	a.pprofCPU = filepath.Join(dir, "missing", "cpu.pprof")
+	_, err = a.startCPUProfile()
+	assert.Error(t, err)
+}
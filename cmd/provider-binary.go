@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// providerBinaryNames are the binaries --provider-binary can replace, the
+// java bundle and jdtls, and the ones looked up for the generic providers
+var providerBinaryNames = []string{
+	"bundle",
+	"jdtls",
+	GenericProviderBinary,
+	GoLSPBinary,
+	GoDependencyProviderBinary,
+	PythonLSPBinary,
+}
+
+// parseProviderBinaries parses --provider-binary values of the form
+// name=path
+func parseProviderBinaries(values []string) (map[string]string, error) {
+	binaries := map[string]string{}
+	for _, value := range values {
+		name, path, found := strings.Cut(value, "=")
+		if !found || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid provider binary %q, must be of the form name=path", value)
+		}
+		if !slices.Contains(providerBinaryNames, name) {
+			return nil, fmt.Errorf("unknown provider binary %q, must be one of: %s", name, strings.Join(providerBinaryNames, ", "))
+		}
+		binaries[name] = path
+	}
+	return binaries, nil
+}
+
+// validateProviderBinaries merges --jdtls-path into the --provider-binary
+// overrides and checks every override is an executable file
+func (a *analyzeCommand) validateProviderBinaries() error {
+	binaries, err := parseProviderBinaries(a.providerBinaries)
+	if err != nil {
+		return err
+	}
+	if a.jdtlsPath != "" {
+		if _, ok := binaries["jdtls"]; ok {
+			return fmt.Errorf("--jdtls-path cannot be used with --provider-binary jdtls=<path>")
+		}
+		binaries["jdtls"] = a.jdtlsPath
+	}
+	for name, path := range binaries {
+		if absPath, err := filepath.Abs(path); err == nil {
+			path = absPath
+		}
+		if err := checkProviderBinary(name, path); err != nil {
+			return err
+		}
+		binaries[name] = path
+	}
+	a.providerBinaryOverrides = binaries
+	return nil
+}
+
+// checkProviderBinary makes sure an override points to a file that can be
+// run, the java bundle is a jar and only has to be a file
+func checkProviderBinary(name string, path string) error {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%w failed to stat %s binary %s", err, name, path)
+	}
+	if stat.Mode().IsDir() {
+		return fmt.Errorf("%s binary %s is a directory, expected a file", name, path)
+	}
+	if name != "bundle" && runtime.GOOS != "windows" && stat.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("%s binary %s is not executable", name, path)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProviderBinaries(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "no overrides",
+			values: []string{},
+			want:   map[string]string{},
+		},
+		{
+			name:   "multiple overrides",
+			values: []string{"jdtls=/opt/jdtls/bin/jdtls", "gopls=/usr/local/bin/gopls"},
+			want: map[string]string{
+				"jdtls": "/opt/jdtls/bin/jdtls",
+				"gopls": "/usr/local/bin/gopls",
+			},
+		},
+		{
+			name:    "missing path",
+			values:  []string{"jdtls="},
+			wantErr: true,
+		},
+		{
+			name:    "missing separator",
+			values:  []string{"/opt/jdtls/bin/jdtls"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown binary",
+			values:  []string{"jdk=/opt/jdk/bin/java"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProviderBinaries(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateProviderBinaries(t *testing.T) {
+	dir := t.TempDir()
+	jdtls := filepath.Join(dir, "jdtls")
+	require.NoError(t, os.WriteFile(jdtls, []byte("#!/bin/sh\n"), 0755))
+	notExecutable := filepath.Join(dir, "gopls")
+	require.NoError(t, os.WriteFile(notExecutable, []byte("#!/bin/sh\n"), 0644))
+	bundle := filepath.Join(dir, "bundle.jar")
+	require.NoError(t, os.WriteFile(bundle, []byte{}, 0644))
+
+	a := &analyzeCommand{jdtlsPath: jdtls, providerBinaries: []string{"bundle=" + bundle}}
+	require.NoError(t, a.validateProviderBinaries())
+	assert.Equal(t, map[string]string{"jdtls": jdtls, "bundle": bundle}, a.providerBinaryOverrides)
+
+	a = &analyzeCommand{jdtlsPath: jdtls, providerBinaries: []string{"jdtls=" + jdtls}}
+	assert.Error(t, a.validateProviderBinaries())
+
+	a = &analyzeCommand{jdtlsPath: dir}
+	assert.ErrorContains(t, a.validateProviderBinaries(), "is a directory")
+
+	a = &analyzeCommand{providerBinaries: []string{"gopls=" + notExecutable}}
+	assert.ErrorContains(t, a.validateProviderBinaries(), "not executable")
+
+	a = &analyzeCommand{providerBinaries: []string{"gopls=" + filepath.Join(dir, "missing")}}
+	assert.Error(t, a.validateProviderBinaries())
+}
+
+func TestSetBinMapContainerlessOverrides(t *testing.T) {
+	dir := t.TempDir()
+	jdtls := filepath.Join(dir, "jdtls")
+	require.NoError(t, os.WriteFile(jdtls, []byte("#!/bin/sh\n"), 0755))
+	bundle := filepath.Join(dir, "bundle.jar")
+	require.NoError(t, os.WriteFile(bundle, []byte{}, 0644))
+
+	a := &analyzeCommand{
+		providerBinaryOverrides: map[string]string{"jdtls": jdtls, "bundle": bundle},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			kantraDir: filepath.Join(dir, "kantra"),
+			reqMap:    map[string]string{},
+		},
+	}
+	require.NoError(t, a.setBinMapContainerless())
+	assert.Equal(t, jdtls, a.reqMap["jdtls"])
+	assert.Equal(t, bundle, a.reqMap["bundle"])
+
+	path, err := a.lookupProviderBin("jdtls")
+	require.NoError(t, err)
+	assert.Equal(t, jdtls, path)
+}
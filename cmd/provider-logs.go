@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bombsimon/logrusr/v3"
+	"github.com/go-logr/logr"
+	"github.com/sirupsen/logrus"
+)
+
+// teeLogSink writes every message to all of its sinks
+type teeLogSink struct {
+	sinks []logr.LogSink
+}
+
+func (t teeLogSink) Init(info logr.RuntimeInfo) {
+	// the tee adds a frame between the caller and the sinks
+	info.CallDepth++
+	for _, sink := range t.sinks {
+		sink.Init(info)
+	}
+}
+
+func (t teeLogSink) Enabled(level int) bool {
+	for _, sink := range t.sinks {
+		if sink.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeLogSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	for _, sink := range t.sinks {
+		if sink.Enabled(level) {
+			sink.Info(level, msg, keysAndValues...)
+		}
+	}
+}
+
+func (t teeLogSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	for _, sink := range t.sinks {
+		sink.Error(err, msg, keysAndValues...)
+	}
+}
+
+func (t teeLogSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	sinks := make([]logr.LogSink, 0, len(t.sinks))
+	for _, sink := range t.sinks {
+		sinks = append(sinks, sink.WithValues(keysAndValues...))
+	}
+	return teeLogSink{sinks: sinks}
+}
+
+func (t teeLogSink) WithName(name string) logr.LogSink {
+	sinks := make([]logr.LogSink, 0, len(t.sinks))
+	for _, sink := range t.sinks {
+		sinks = append(sinks, sink.WithName(name))
+	}
+	return teeLogSink{sinks: sinks}
+}
+
+func (a *analyzeCommand) validateSplitProviderLogs() error {
+	if a.splitProviderLogs && a.bulk {
+		return fmt.Errorf("--split-provider-logs cannot be used with --bulk")
+	}
+	return nil
+}
+
+// providerLogger returns the logger to create a provider with. With
+// --split-provider-logs the provider also logs to <provider>.log in the
+// output dir, analysis.log keeps the logs of all providers.
+func (a *analyzeCommand) providerLogger(name string, analysisLog logr.Logger) logr.Logger {
+	if !a.splitProviderLogs {
+		return analysisLog
+	}
+	path := filepath.Join(a.output, fmt.Sprintf("%s.log", name))
+	file, err := os.Create(path)
+	if err != nil {
+		a.log.Error(err, "failed to create provider log file, logging to analysis.log only", "file", path)
+		return analysisLog
+	}
+	a.providerLogFiles = append(a.providerLogFiles, file)
+
+	logrusProviderLog := logrus.New()
+	logrusProviderLog.SetOutput(file)
+	logrusProviderLog.SetFormatter(&logrus.TextFormatter{})
+	logrusProviderLog.SetLevel(logrus.Level(logLevel))
+	providerLog := logrusr.New(logrusProviderLog)
+	if analysisLog.GetSink() == nil {
+		return providerLog
+	}
+	return logr.New(teeLogSink{sinks: []logr.LogSink{analysisLog.GetSink(), providerLog.GetSink()}})
+}
+
+// closeProviderLogs closes the --split-provider-logs files
+func (a *analyzeCommand) closeProviderLogs() {
+	for _, file := range a.providerLogFiles {
+		file.Close()
+	}
+	a.providerLogFiles = nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bombsimon/logrusr/v3"
+	"github.com/go-logr/logr"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderLogger(t *testing.T) {
+	var aggregate bytes.Buffer
+	logrusAggregate := logrus.New()
+	logrusAggregate.SetOutput(&aggregate)
+	analysisLog := logrusr.New(logrusAggregate)
+
+	t.Run("disabled", func(t *testing.T) {
+		a := &analyzeCommand{output: t.TempDir()}
+		log := a.providerLogger("java", analysisLog)
+		assert.Equal(t, analysisLog, log)
+		assert.NoFileExists(t, filepath.Join(a.output, "java.log"))
+	})
+
+	t.Run("split", func(t *testing.T) {
+		aggregate.Reset()
+		a := &analyzeCommand{output: t.TempDir(), splitProviderLogs: true}
+		a.log = logr.Discard()
+		a.providerLogger("java", analysisLog).WithName("java").Info("java message")
+		a.providerLogger("builtin", analysisLog).Error(fmt.Errorf("boom"), "builtin message")
+		a.closeProviderLogs()
+
+		javaLog, err := os.ReadFile(filepath.Join(a.output, "java.log"))
+		require.NoError(t, err)
+		assert.Contains(t, string(javaLog), "java message")
+		assert.NotContains(t, string(javaLog), "builtin message")
+
+		builtinLog, err := os.ReadFile(filepath.Join(a.output, "builtin.log"))
+		require.NoError(t, err)
+		assert.Contains(t, string(builtinLog), "builtin message")
+		assert.NotContains(t, string(builtinLog), "java message")
+
+		assert.Contains(t, aggregate.String(), "java message")
+		assert.Contains(t, aggregate.String(), "builtin message")
+		assert.Empty(t, a.providerLogFiles)
+	})
+}
+
+func TestValidateSplitProviderLogs(t *testing.T) {
+	assert.NoError(t, (&analyzeCommand{splitProviderLogs: true}).validateSplitProviderLogs())
+	assert.Error(t, (&analyzeCommand{splitProviderLogs: true, bulk: true}).validateSplitProviderLogs())
+}
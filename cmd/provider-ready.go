@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/konveyor/analyzer-lsp/provider"
+)
+
+// providerReadyInterval is how often the java provider is queried while
+// waiting for it to be ready
+var providerReadyInterval = 5 * time.Second
+
+// providerReadyCondition only searches import statements which is cheap
+// compared to searching every reference, its results only settle once jdtls
+// finished building its indexes
+const providerReadyCondition = "referenced:\n  pattern: java.*\n  location: IMPORT\n"
+
+func (a *analyzeCommand) validateProviderReady() error {
+	if a.waitForProviderReady && a.providerReadyTimeout <= 0 {
+		return fmt.Errorf("--provider-ready-timeout must be greater than 0")
+	}
+	return nil
+}
+
+// pollProviderReady queries the provider until two consecutive queries
+// return the same non-zero number of incidents, jdtls may report it is ready
+// before its indexes are built which makes rules miss incidents. The analysis
+// goes on when --provider-ready-timeout elapses, e.g. for an application
+// without any java imports.
+func (a *analyzeCommand) pollProviderReady(ctx context.Context, name string, client provider.ServiceClient) {
+	start := time.Now()
+	readyCtx, cancel := context.WithTimeout(ctx, a.providerReadyTimeout)
+	defer cancel()
+	previous := -1
+	for {
+		resp, err := client.Evaluate(readyCtx, "referenced", []byte(providerReadyCondition))
+		switch {
+		case err != nil:
+			a.log.V(1).Info("provider is not ready", "provider", name, "error", err.Error())
+			previous = -1
+		case len(resp.Incidents) > 0 && len(resp.Incidents) == previous:
+			a.log.Info("provider is ready", "provider", name, "wait_ms", time.Since(start).Milliseconds())
+			return
+		default:
+			previous = len(resp.Incidents)
+		}
+		select {
+		case <-readyCtx.Done():
+			a.log.Info("WARNING: provider did not return consistent results before --provider-ready-timeout, continuing the analysis",
+				"provider", name, "wait_ms", time.Since(start).Milliseconds())
+			return
+		case <-time.After(providerReadyInterval):
+		}
+	}
+}
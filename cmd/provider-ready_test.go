@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+// indexingProvider returns more incidents on every query until its indexes
+// are built
+type indexingProvider struct {
+	provider.ServiceClient
+	responses []int
+	calls     int
+}
+
+func (p *indexingProvider) Evaluate(ctx context.Context, cap string, conditionInfo []byte) (provider.ProviderEvaluateResponse, error) {
+	p.calls++
+	if p.calls > len(p.responses) {
+		return provider.ProviderEvaluateResponse{Incidents: make([]provider.IncidentContext, p.calls)}, nil
+	}
+	incidents := p.responses[p.calls-1]
+	if incidents < 0 {
+		return provider.ProviderEvaluateResponse{}, errors.New("not initialized")
+	}
+	return provider.ProviderEvaluateResponse{Incidents: make([]provider.IncidentContext, incidents)}, nil
+}
+
+func TestPollProviderReady(t *testing.T) {
+	interval := providerReadyInterval
+	providerReadyInterval = time.Millisecond
+	t.Cleanup(func() { providerReadyInterval = interval })
+
+	a := &analyzeCommand{
+		providerReadyTimeout:  time.Minute,
+		AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+	}
+	client := &indexingProvider{responses: []int{-1, 0, 0, 4, 9, 9}}
+	a.pollProviderReady(context.Background(), "java", client)
+	assert.Equal(t, 6, client.calls)
+
+	// the analysis goes on when the results never settle
+	a.providerReadyTimeout = 20 * time.Millisecond
+	client = &indexingProvider{}
+	start := time.Now()
+	a.pollProviderReady(context.Background(), "java", client)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Greater(t, client.calls, 1)
+
+	// no incidents are not a stable result
+	client = &indexingProvider{responses: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}}
+	a.pollProviderReady(context.Background(), "java", client)
+	assert.Greater(t, client.calls, 2)
+}
+
+func TestValidateProviderReady(t *testing.T) {
+	a := &analyzeCommand{waitForProviderReady: true}
+	assert.Error(t, a.validateProviderReady())
+	a.providerReadyTimeout = time.Minute
+	assert.NoError(t, a.validateProviderReady())
+}
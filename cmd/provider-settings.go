@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	"github.com/konveyor/analyzer-lsp/provider"
+)
+
+// containerlessSettingsProviders are the providers that can be created from a
+// --provider-settings file in containerless mode.
+var containerlessSettingsProviders = []string{util.JavaProvider, util.GoProvider, util.PythonProvider, "builtin"}
+
+// loadProviderSettings reads a provider settings.json file and keeps the
+// configs of the providers supported in containerless mode.
+func loadProviderSettings(path string) ([]provider.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provider settings file: %w", err)
+	}
+	var configs []provider.Config
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse provider settings file %s: %w", path, err)
+	}
+	supported := []provider.Config{}
+	for _, config := range configs {
+		if slices.Contains(containerlessSettingsProviders, config.Name) {
+			supported = append(supported, config)
+		}
+	}
+	if len(supported) == 0 {
+		return nil, fmt.Errorf("provider settings file %s has no supported provider, expected one of %v",
+			path, containerlessSettingsProviders)
+	}
+	return supported, nil
+}
+
+// validateProviderSettings checks the --provider-settings file can be used.
+func (a *analyzeCommand) validateProviderSettings() error {
+	if a.providerSettings == "" {
+		return nil
+	}
+	if !a.runLocal {
+		return fmt.Errorf("--provider-settings is only supported in containerless mode, use --override-provider-settings with --run-local=false")
+	}
+	if a.overrideProviderSettings != "" {
+		return fmt.Errorf("--provider-settings cannot be used with --override-provider-settings")
+	}
+	configs, err := loadProviderSettings(a.providerSettings)
+	if err != nil {
+		return err
+	}
+	if absPath, err := filepath.Abs(a.providerSettings); err == nil {
+		a.providerSettings = absPath
+	}
+	a.log.V(1).Info("validated provider settings file", "file", a.providerSettings, "providers", len(configs))
+	return nil
+}
+
+// startProvidersFromSettings creates and starts the providers of the
+// --provider-settings file instead of building their configs from flags.
+func (a *analyzeCommand) startProvidersFromSettings(ctx context.Context, analysisLog logr.Logger) (map[string]provider.InternalProviderClient, []string, error) {
+	configs, err := loadProviderSettings(a.providerSettings)
+	if err != nil {
+		return nil, nil, err
+	}
+	providers, providerLocations, err := a.setInternalProviders(a.setConfigsContainerless(configs), analysisLog)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := a.startProvidersContainerless(ctx, providers); err != nil {
+		return nil, nil, err
+	}
+	return providers, providerLocations, nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProviderSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name:      "java and builtin",
+			content:   `[{"name": "java", "binaryPath": "/bin/jdtls", "initConfig": [{"location": "/app"}]}, {"name": "builtin", "initConfig": [{"location": "/app"}]}]`,
+			wantNames: []string{"java", "builtin"},
+		},
+		{
+			name:      "unsupported providers are dropped",
+			content:   `[{"name": "go", "address": "localhost:14651"}, {"name": "python", "initConfig": [{"location": "/app"}]}]`,
+			wantNames: []string{"python"},
+		},
+		{
+			name:    "no supported provider",
+			content: `[{"name": "nodejs", "address": "localhost:14651"}]`,
+			wantErr: "no supported provider",
+		},
+		{
+			name:    "empty list",
+			content: `[]`,
+			wantErr: "no supported provider",
+		},
+		{
+			name:    "invalid json",
+			content: `{"name": "java"`,
+			wantErr: "failed to parse provider settings file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "settings.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			configs, err := loadProviderSettings(path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			names := []string{}
+			for _, config := range configs {
+				names = append(names, config.Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+		})
+	}
+}
+
+func TestValidateProviderSettings(t *testing.T) {
+	dir := t.TempDir()
+	settings := filepath.Join(dir, "settings.json")
+	require.NoError(t, os.WriteFile(settings, []byte(`[{"name": "java"}]`), 0644))
+
+	tests := []struct {
+		name    string
+		cmd     analyzeCommand
+		wantErr string
+	}{
+		{
+			name: "unset",
+			cmd:  analyzeCommand{runLocal: true},
+		},
+		{
+			name: "valid file",
+			cmd:  analyzeCommand{runLocal: true, providerSettings: settings},
+		},
+		{
+			name:    "missing file",
+			cmd:     analyzeCommand{runLocal: true, providerSettings: filepath.Join(dir, "missing.json")},
+			wantErr: "failed to read provider settings file",
+		},
+		{
+			name:    "hybrid mode",
+			cmd:     analyzeCommand{providerSettings: settings},
+			wantErr: "only supported in containerless mode",
+		},
+		{
+			name:    "with override provider settings",
+			cmd:     analyzeCommand{runLocal: true, providerSettings: settings, overrideProviderSettings: settings},
+			wantErr: "cannot be used with --override-provider-settings",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.cmd
+			a.log = logr.Discard()
+			err := a.validateProviderSettings()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/bombsimon/logrusr/v3"
+	"github.com/go-logr/logr"
+	"github.com/sirupsen/logrus"
+)
+
+// quietConsoleLogger returns the console logger of --quiet and --output -. It
+// writes to stderr, keeping stdout for the results, and only logs at the
+// error level. The shared console logger is left as is.
+func quietConsoleLogger(console *logrus.Logger) logr.Logger {
+	quiet := logrus.New()
+	quiet.SetOutput(os.Stderr)
+	quiet.SetFormatter(console.Formatter)
+	quiet.SetLevel(logrus.ErrorLevel)
+	return logrusr.New(quiet)
+}
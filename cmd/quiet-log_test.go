@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietConsoleLogger(t *testing.T) {
+	stderr := os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	console := logrus.New()
+	console.SetOutput(io.Discard)
+	quiet := quietConsoleLogger(console).WithName("test").WithValues("key", "value")
+	quiet.Info("informational message")
+	quiet.V(1).Info("debug message")
+	quiet.Error(errors.New("boom"), "error message")
+	require.NoError(t, w.Close())
+
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "error message")
+	assert.NotContains(t, string(content), "informational message")
+	assert.NotContains(t, string(content), "debug message")
+	// the shared console logger still writes where it did
+	assert.Equal(t, io.Discard, console.Out)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// redactedText replaces every match of a --redact pattern
+const redactedText = "***REDACTED***"
+
+// defaultRedactPatterns match AWS keys, bearer tokens and private key
+// headers, --redact-pattern adds to them
+var defaultRedactPatterns = []string{
+	`\b(AKIA|ASIA)[0-9A-Z]{16}\b`,
+	`(?i)aws_secret_access_key\s*[=:]\s*["']?[A-Za-z0-9/+=]{40}["']?`,
+	`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]{8,}=*`,
+	`-----BEGIN ([A-Z0-9]+ )*PRIVATE KEY-----`,
+}
+
+// validateRedact compiles the default and --redact-pattern patterns
+func (a *analyzeCommand) validateRedact() error {
+	if !a.redact {
+		if len(a.redactPatterns) > 0 {
+			return fmt.Errorf("--redact-pattern requires --redact")
+		}
+		return nil
+	}
+	a.redactRegexps = []*regexp.Regexp{}
+	for _, pattern := range append(append([]string{}, defaultRedactPatterns...), a.redactPatterns...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		a.redactRegexps = append(a.redactRegexps, re)
+	}
+	return nil
+}
+
+// redactIncidents replaces the secrets in incident messages, code snippets
+// and variables with --redact, after the stable ids and baseline fingerprints are
+// computed so they don't change with the patterns. Every output, including
+// the static report, is written from the redacted results.
+func (a *analyzeCommand) redactIncidents(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if !a.redact {
+		return rulesets
+	}
+	redacted := 0
+	for i := range rulesets {
+		for ruleID, violation := range rulesets[i].Violations {
+			redacted += redactViolation(&violation, a.redactRegexps)
+			rulesets[i].Violations[ruleID] = violation
+		}
+		for ruleID, insight := range rulesets[i].Insights {
+			redacted += redactViolation(&insight, a.redactRegexps)
+			rulesets[i].Insights[ruleID] = insight
+		}
+	}
+	if redacted > 0 {
+		a.log.V(1).Info("redacted incidents", "count", redacted)
+	}
+	return rulesets
+}
+
+// redactViolation redacts the incidents of violation and returns how many
+// were changed. Changed variables are copied, the maps may be shared with
+// the engine.
+func redactViolation(violation *outputv1.Violation, patterns []*regexp.Regexp) int {
+	redacted := 0
+	for i, incident := range violation.Incidents {
+		message := redactText(incident.Message, patterns)
+		codeSnip := redactText(incident.CodeSnip, patterns)
+		variables, variablesChanged := redactValue(incident.Variables, patterns)
+		if message == incident.Message && codeSnip == incident.CodeSnip && !variablesChanged {
+			continue
+		}
+		violation.Incidents[i].Message = message
+		violation.Incidents[i].CodeSnip = codeSnip
+		if variablesChanged {
+			violation.Incidents[i].Variables = variables.(map[string]interface{})
+		}
+		redacted++
+	}
+	return redacted
+}
+
+// redactValue redacts the strings in a variable value, e.g. the
+// matchingText of the builtin provider, recursing into maps and slices. It
+// returns a copy when anything changed.
+func redactValue(value interface{}, patterns []*regexp.Regexp) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		redacted := redactText(v, patterns)
+		return redacted, redacted != v
+	case map[string]interface{}:
+		var redacted map[string]interface{}
+		for key, item := range v {
+			item, changed := redactValue(item, patterns)
+			if !changed {
+				continue
+			}
+			if redacted == nil {
+				redacted = maps.Clone(v)
+			}
+			redacted[key] = item
+		}
+		if redacted == nil {
+			return value, false
+		}
+		return redacted, true
+	case []interface{}:
+		var redacted []interface{}
+		for i, item := range v {
+			item, changed := redactValue(item, patterns)
+			if !changed {
+				continue
+			}
+			if redacted == nil {
+				redacted = slices.Clone(v)
+			}
+			redacted[i] = item
+		}
+		if redacted == nil {
+			return value, false
+		}
+		return redacted, true
+	}
+	return value, false
+}
+
+func redactText(text string, patterns []*regexp.Regexp) string {
+	for _, pattern := range patterns {
+		text = pattern.ReplaceAllString(text, redactedText)
+	}
+	return text
+}
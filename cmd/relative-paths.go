@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
+)
+
+// relativizeIncidentURIs rewrites the file URIs of incidents in the input to
+// paths relative to it with --relative-paths, e.g.
+// file:///tmp/analyze-input/source/src/Main.java becomes src/Main.java, so
+// outputs don't leak the layout of the machine that ran the analysis.
+// Incidents outside the input, e.g. in dependencies, keep their URI. Run last
+// as the incident filters need the file URIs.
+func (a *analyzeCommand) relativizeIncidentURIs(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if !a.relativePaths {
+		return rulesets
+	}
+	roots := a.incidentRoots()
+	for i := range rulesets {
+		relativizeViolations(rulesets[i].Violations, roots)
+		relativizeViolations(rulesets[i].Insights, roots)
+	}
+	return rulesets
+}
+
+func relativizeViolations(violations map[string]outputv1.Violation, roots []string) {
+	for ruleID, violation := range violations {
+		for i := range violation.Incidents {
+			violation.Incidents[i].URI = uri.URI(relativeIncidentFile(violation.Incidents[i], roots))
+		}
+		violations[ruleID] = violation
+	}
+}
+
+// isFileIncident reports whether the incident is in a file, given by a file
+// URI or a path relative to the input with --relative-paths
+func isFileIncident(incident outputv1.Incident) bool {
+	return strings.HasPrefix(string(incident.URI), "file:") || (incident.URI != "" && !strings.Contains(string(incident.URI), ":"))
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"go.lsp.dev/uri"
+)
+
+func TestRelativizeIncidentURIs(t *testing.T) {
+	rulesets := func() []outputv1.RuleSet {
+		return []outputv1.RuleSet{
+			{
+				Name: "eap8",
+				Violations: map[string]outputv1.Violation{
+					"eap8-00001": {Incidents: []outputv1.Incident{
+						{URI: uri.File("/tmp/analyze-input/source/src/main/java/Main.java")},
+						{URI: uri.File("/root/.m2/repository/org/acme/lib.jar")},
+					}},
+				},
+				Insights: map[string]outputv1.Violation{
+					"eap8-00002": {Incidents: []outputv1.Incident{
+						{URI: uri.File("/tmp/analyze-input/source/pom.xml")},
+					}},
+				},
+			},
+		}
+	}
+
+	a := &analyzeCommand{input: "/tmp/analyze-input/source"}
+	assert.Equal(t, rulesets(), a.relativizeIncidentURIs(rulesets()))
+
+	a.relativePaths = true
+	got := a.relativizeIncidentURIs(rulesets())
+	incidents := got[0].Violations["eap8-00001"].Incidents
+	assert.Equal(t, uri.URI("src/main/java/Main.java"), incidents[0].URI)
+	assert.Equal(t, uri.File("/root/.m2/repository/org/acme/lib.jar"), incidents[1].URI)
+	assert.Equal(t, uri.URI("pom.xml"), got[0].Insights["eap8-00002"].Incidents[0].URI)
+
+	assert.True(t, isFileIncident(incidents[0]))
+	assert.True(t, isFileIncident(incidents[1]))
+	assert.False(t, isFileIncident(outputv1.Incident{URI: "mvn://org.acme:lib:1.0"}))
+	assert.False(t, isFileIncident(outputv1.Incident{}))
+}
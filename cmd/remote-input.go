@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const remoteInputChecksumParam = "sha256"
+
+// isRemoteArchiveInput returns true when input is an http(s) URL pointing
+// to a .tar.gz or .zip archive
+func isRemoteArchiveInput(input string) bool {
+	u, err := url.Parse(input)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	return remoteArchiveType(u.Path) != ""
+}
+
+func remoteArchiveType(urlPath string) string {
+	switch {
+	case strings.HasSuffix(urlPath, ".tar.gz"), strings.HasSuffix(urlPath, ".tgz"):
+		return ".tar.gz"
+	case strings.HasSuffix(urlPath, ".zip"):
+		return ".zip"
+	}
+	return ""
+}
+
+// fetchRemoteInput downloads the archive at a.input, verifies the optional
+// sha256 query parameter, extracts it to a temp dir and points a.input to
+// the extracted source root
+func (a *analyzeCommand) fetchRemoteInput(ctx context.Context) error {
+	u, err := url.Parse(a.input)
+	if err != nil {
+		return fmt.Errorf("%w failed to parse input url %s", err, a.input)
+	}
+	query := u.Query()
+	expectedChecksum := strings.ToLower(query.Get(remoteInputChecksumParam))
+	query.Del(remoteInputChecksumParam)
+	u.RawQuery = query.Encode()
+
+	tempDir, err := os.MkdirTemp("", "analyze-input-")
+	if err != nil {
+		return fmt.Errorf("%w failed to create temp dir for remote input", err)
+	}
+	a.log.V(1).Info("created directory for remote input", "dir", tempDir)
+	a.tempDirs = append(a.tempDirs, tempDir)
+
+	archiveType := remoteArchiveType(u.Path)
+	archivePath := filepath.Join(tempDir, "input"+archiveType)
+	client, err := a.downloadClient()
+	if err != nil {
+		return err
+	}
+	a.log.Info("downloading remote input", "url", u.Redacted())
+	checksum, err := downloadFile(ctx, client, u.String(), archivePath)
+	if err != nil {
+		return fmt.Errorf("%w failed to download input %s", err, u.Redacted())
+	}
+	if expectedChecksum != "" && checksum != expectedChecksum {
+		return fmt.Errorf("checksum mismatch for input %s: expected sha256 %s, got %s", u.Redacted(), expectedChecksum, checksum)
+	}
+
+	extractDir := filepath.Join(tempDir, "source")
+	switch archiveType {
+	case ".zip":
+		err = extractZip(archivePath, extractDir)
+	default:
+		err = extractTarGz(archivePath, extractDir)
+	}
+	if err != nil {
+		return fmt.Errorf("%w failed to extract input archive %s", err, u.Redacted())
+	}
+	if err := os.Remove(archivePath); err != nil {
+		a.log.V(1).Error(err, "failed to remove downloaded archive", "file", archivePath)
+	}
+
+	root, err := archiveRoot(extractDir)
+	if err != nil {
+		return err
+	}
+	a.log.V(1).Info("extracted remote input", "dir", root)
+	a.input = root
+	return nil
+}
+
+// downloadFile writes the body at the given url to dest and returns its sha256 checksum
+func downloadFile(ctx context.Context, client *http.Client, fileURL string, dest string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	out, err := os.Create(dest)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, hash), resp.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// safeExtractPath joins name to dest making sure the result stays within dest
+func safeExtractPath(dest string, name string) (string, error) {
+	target := filepath.Join(dest, name)
+	if target != filepath.Clean(dest) && !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("archive entry %s points outside of extraction dir", name)
+	}
+	return target, nil
+}
+
+func extractTarGz(archivePath string, dest string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	return extractTar(gz, dest)
+}
+
+// extractTar extracts the directories and regular files of the tar stream r
+// to dest
+func extractTar(r io.Reader, dest string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target, err := safeExtractPath(dest, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeExtractedFile(target, tr, os.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+		default:
+			// links and special files are not needed for analysis
+			continue
+		}
+	}
+}
+
+func extractZip(archivePath string, dest string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		target, err := safeExtractPath(dest, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = writeExtractedFile(target, rc, f.Mode().Perm())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeExtractedFile(target string, r io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if perm == 0 {
+		perm = 0644
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, r)
+	return err
+}
+
+// archiveRoot returns the single top level directory of an extracted
+// archive, as source tarballs usually have, or the extraction dir itself
+func archiveRoot(extractDir string) (string, error) {
+	entries, err := os.ReadDir(extractDir)
+	if err != nil {
+		return "", fmt.Errorf("%w failed to read extracted input %s", err, extractDir)
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(extractDir, entries[0].Name()), nil
+	}
+	return extractDir, nil
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRemoteArchiveInput(t *testing.T) {
+	assert.True(t, isRemoteArchiveInput("https://example.com/app-src.tar.gz"))
+	assert.True(t, isRemoteArchiveInput("http://example.com/app-src.zip?sha256=abc"))
+	assert.False(t, isRemoteArchiveInput("https://example.com/app.war"))
+	assert.False(t, isRemoteArchiveInput("/local/app-src.tar.gz"))
+	assert.False(t, isRemoteArchiveInput("ftp://example.com/app-src.tar.gz"))
+}
+
+func TestFetchRemoteInput(t *testing.T) {
+	archive := &bytes.Buffer{}
+	gz := gzip.NewWriter(archive)
+	tw := tar.NewWriter(gz)
+	content := []byte("package main\n")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "app-src/", Typeflag: tar.TypeDir, Mode: 0755}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "app-src/main.go", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	sum := sha256.Sum256(archive.Bytes())
+	checksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive.Bytes())
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:  "without checksum",
+			input: server.URL + "/app-src.tar.gz",
+		},
+		{
+			name:  "with matching checksum",
+			input: server.URL + "/app-src.tar.gz?sha256=" + checksum,
+		},
+		{
+			name:    "with checksum mismatch",
+			input:   server.URL + "/app-src.tar.gz?sha256=deadbeef",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzeCommand{
+				input: tt.input,
+				AnalyzeCommandContext: AnalyzeCommandContext{
+					log: logr.Discard(),
+				},
+			}
+			defer func() {
+				for _, dir := range a.tempDirs {
+					os.RemoveAll(dir)
+				}
+			}()
+
+			err := a.fetchRemoteInput(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, a.tempDirs, 1)
+			assert.Equal(t, "app-src", filepath.Base(a.input))
+			data, err := os.ReadFile(filepath.Join(a.input, "main.go"))
+			require.NoError(t, err)
+			assert.Equal(t, content, data)
+		})
+	}
+}
+
+func TestSafeExtractPath(t *testing.T) {
+	dest := t.TempDir()
+	_, err := safeExtractPath(dest, "../../etc/passwd")
+	assert.Error(t, err)
+	target, err := safeExtractPath(dest, "src/main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dest, "src", "main.go"), target)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// validateReportOnly checks the output dir of a --report-only run holds the
+// results of an earlier analysis, dependencies.yaml is optional
+func (a *analyzeCommand) validateReportOnly() error {
+	if a.bulk {
+		return fmt.Errorf("--report-only cannot be used with --bulk")
+	}
+	if a.skipStaticReport {
+		return fmt.Errorf("--report-only cannot be used with --skip-static-report")
+	}
+	if absPath, err := filepath.Abs(a.output); err == nil {
+		a.output = absPath
+	}
+	_, err := os.Stat(filepath.Join(a.output, "output.yaml"))
+	if errors.Is(err, os.ErrNotExist) {
+		// the output of a --compress-output analysis
+		if _, gzErr := os.Stat(filepath.Join(a.output, "output.yaml"+gzipExtension)); gzErr != nil {
+			return fmt.Errorf("no output.yaml found in %s to generate the static report from, run an analysis with --output %s first", a.output, a.output)
+		}
+		a.compressOutput = true
+	} else if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(a.output, "dependencies.yaml")); errors.Is(err, os.ErrNotExist) {
+		a.log.Info("no dependencies.yaml found, the static report won't list dependencies", "output", a.output)
+	} else if err != nil {
+		return err
+	}
+	if a.input == "" {
+		a.input = a.analyzedInput()
+	}
+	return nil
+}
+
+// analyzedInput returns the input recorded in the run metadata of the
+// output dir, the static report names the application after it
+func (a *analyzeCommand) analyzedInput() string {
+	content, err := os.ReadFile(filepath.Join(a.output, runMetadataFile))
+	if err != nil {
+		return a.output
+	}
+	metadata := runMetadata{}
+	if err := json.Unmarshal(content, &metadata); err != nil || metadata.Flags["input"] == "" {
+		return a.output
+	}
+	return metadata.Flags["input"]
+}
+
+// runReportOnly regenerates the static report from the output.yaml and
+// dependencies.yaml of an earlier analysis without analyzing anything
+func (a *analyzeCommand) runReportOnly(ctx context.Context) error {
+	if a.kantraDir == "" {
+		if err := a.setKantraDir(); err != nil {
+			return err
+		}
+	}
+	if err := a.GenerateStaticReportContainerless(ctx, a.log); err != nil {
+		a.log.Error(err, "failed to generate static report")
+		return &OutputWriteError{Path: filepath.Join(a.output, "static-report"), Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReportOnly(t *testing.T) {
+	output := t.TempDir()
+	newCommand := func() *analyzeCommand {
+		return &analyzeCommand{
+			output:                output,
+			reportOnly:            true,
+			AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+		}
+	}
+
+	err := newCommand().Validate(context.Background(), nil)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.ErrorContains(t, err, "no output.yaml found in "+output)
+
+	require.NoError(t, os.WriteFile(filepath.Join(output, "output.yaml.gz"), []byte{}, 0644))
+	a := newCommand()
+	require.NoError(t, a.Validate(context.Background(), nil))
+	assert.True(t, a.compressOutput)
+	assert.Equal(t, output, a.input, "without run metadata the report is named after the output dir")
+
+	require.NoError(t, os.WriteFile(filepath.Join(output, "output.yaml"), []byte("[]"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(output, runMetadataFile), []byte(`{"flags": {"input": "/src/billing"}}`), 0644))
+	a = newCommand()
+	require.NoError(t, a.Validate(context.Background(), nil))
+	assert.False(t, a.compressOutput)
+	assert.Equal(t, "billing", a.reportAppName())
+
+	a = newCommand()
+	a.reportTheme = "sepia"
+	assert.ErrorContains(t, a.Validate(context.Background(), nil), "report-theme")
+
+	a = newCommand()
+	a.bulk = true
+	assert.Error(t, a.Validate(context.Background(), nil))
+	a = newCommand()
+	a.skipStaticReport = true
+	assert.Error(t, a.Validate(context.Background(), nil))
+}
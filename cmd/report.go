@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+)
+
+// static report assets not covered by Go's builtin MIME table
+var staticReportMimeTypes = map[string]string{
+	".ico":   "image/x-icon",
+	".map":   "application/json",
+	".ttf":   "font/ttf",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+}
+
+type reportServeCommand struct {
+	output      string
+	port        int
+	openBrowser bool
+	log         logr.Logger
+}
+
+func NewReportCommand(log logr.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Work with the static report of an analysis",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+	cmd.AddCommand(NewReportServeCommand(log))
+	return cmd
+}
+
+func NewReportServeCommand(log logr.Logger) *cobra.Command {
+	serveCmd := &reportServeCommand{
+		log: log,
+	}
+
+	serveCommand := &cobra.Command{
+		Use:   "serve --output <dir>",
+		Short: "Serve the static report of an analysis output directory over HTTP",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := serveCmd.Validate(); err != nil {
+				log.Error(err, "failed to validate flags")
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			if err := serveCmd.Run(ctx, os.Stdout); err != nil {
+				log.Error(err, "failed to serve static report")
+				return err
+			}
+			return nil
+		},
+	}
+	serveCommand.Flags().StringVarP(&serveCmd.output, "output", "o", "", "path to the analysis output directory containing the static report")
+	serveCommand.Flags().IntVar(&serveCmd.port, "port", 8080, "port to serve the static report on, 0 picks a free port")
+	serveCommand.Flags().BoolVar(&serveCmd.openBrowser, "open", false, "open the static report in the default browser")
+	serveCommand.MarkFlagRequired("output")
+
+	return serveCommand
+}
+
+func (r *reportServeCommand) reportDir() string {
+	return filepath.Join(r.output, "static-report")
+}
+
+func (r *reportServeCommand) Validate() error {
+	if r.port < 0 || r.port > 65535 {
+		return fmt.Errorf("invalid port %d", r.port)
+	}
+	absOutput, err := filepath.Abs(r.output)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for output %s: %w", r.output, err)
+	}
+	r.output = absOutput
+	if _, err := os.Stat(filepath.Join(r.reportDir(), "index.html")); err != nil {
+		return fmt.Errorf("no static report found in %s, run analyze without --skip-static-report first: %w", r.output, err)
+	}
+	return nil
+}
+
+// Run serves the static report until ctx is done
+func (r *reportServeCommand) Run(ctx context.Context, out io.Writer) error {
+	for ext, mimeType := range staticReportMimeTypes {
+		if mime.TypeByExtension(ext) == "" {
+			mime.AddExtensionType(ext, mimeType)
+		}
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", r.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", r.port, err)
+	}
+	server := &http.Server{
+		Handler:           http.FileServer(http.Dir(r.reportDir())),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	url := fmt.Sprintf("http://localhost:%d/", listener.Addr().(*net.TCPAddr).Port)
+	fmt.Fprintf(out, "Serving static report at %s, press Ctrl+C to stop\n", url)
+	if r.openBrowser {
+		if err := openURL(url); err != nil {
+			r.log.Error(err, "failed to open browser", "url", url)
+		}
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportServeValidate(t *testing.T) {
+	output := t.TempDir()
+	r := &reportServeCommand{output: output, port: 8080, log: logr.Discard()}
+	assert.Error(t, r.Validate(), "missing static report")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(output, "static-report"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(output, "static-report", "index.html"), []byte("<html></html>"), 0644))
+	assert.NoError(t, r.Validate())
+
+	r.port = 70000
+	assert.Error(t, r.Validate())
+}
+
+func TestReportServeRun(t *testing.T) {
+	output := t.TempDir()
+	reportDir := filepath.Join(output, "static-report")
+	require.NoError(t, os.MkdirAll(filepath.Join(reportDir, "static", "js"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(reportDir, "index.html"), []byte("<html></html>"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(reportDir, "static", "js", "main.js"), []byte("var a;"), 0644))
+
+	r := &reportServeCommand{output: output, port: 0, log: logr.Discard()}
+	require.NoError(t, r.Validate())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Run(ctx, out)
+	}()
+
+	urlPattern := regexp.MustCompile(`http://localhost:\d+/`)
+	var url string
+	require.Eventually(t, func() bool {
+		url = urlPattern.FindString(out.String())
+		return url != ""
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+	assert.Equal(t, "<html></html>", string(body))
+
+	resp, err = http.Get(url + "static/js/main.js")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Contains(t, resp.Header.Get("Content-Type"), "javascript")
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+// syncBuffer is a bytes.Buffer safe for the server goroutine to write to
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
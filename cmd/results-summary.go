@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// rulesetSummary counts the violations of a ruleset and their incidents by
+// category
+type rulesetSummary struct {
+	violations int
+	incidents  map[outputv1.Category]int
+}
+
+func (s *rulesetSummary) add(violation outputv1.Violation) {
+	category := outputv1.Potential
+	if violation.Category != nil {
+		category = *violation.Category
+	}
+	s.violations++
+	s.incidents[category] += len(violation.Incidents)
+}
+
+// writeResultsSummary prints a table of the violations and incidents by
+// category of every ruleset with violations, printed to stderr at the end of
+// a run unless --no-progress or --quiet is set. Rulesets are expected to be
+// sorted by name.
+func writeResultsSummary(out io.Writer, rulesets []outputv1.RuleSet) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RULESET\tVIOLATIONS\tMANDATORY\tOPTIONAL\tPOTENTIAL")
+	total := rulesetSummary{incidents: map[outputv1.Category]int{}}
+	for _, ruleset := range rulesets {
+		if len(ruleset.Violations) == 0 {
+			continue
+		}
+		summary := rulesetSummary{incidents: map[outputv1.Category]int{}}
+		for _, violation := range ruleset.Violations {
+			summary.add(violation)
+			total.add(violation)
+		}
+		writeRulesetSummary(w, ruleset.Name, summary)
+	}
+	writeRulesetSummary(w, "total", total)
+	return w.Flush()
+}
+
+func writeRulesetSummary(w io.Writer, name string, summary rulesetSummary) {
+	fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", name, summary.violations,
+		summary.incidents[outputv1.Mandatory], summary.incidents[outputv1.Optional], summary.incidents[outputv1.Potential])
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteResultsSummary(t *testing.T) {
+	mandatory := outputv1.Mandatory
+	optional := outputv1.Optional
+	incidents := func(n int) []outputv1.Incident {
+		return make([]outputv1.Incident, n)
+	}
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "eap8/eap7",
+			Violations: map[string]outputv1.Violation{
+				"eap8-00001": {Category: &mandatory, Incidents: incidents(3)},
+				"eap8-00002": {Category: &optional, Incidents: incidents(1)},
+			},
+		},
+		{Name: "empty"},
+		{
+			Name: "quarkus/springboot",
+			Violations: map[string]outputv1.Violation{
+				"springboot-00001": {Category: &mandatory, Incidents: incidents(2)},
+				"springboot-00002": {Incidents: incidents(4)},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	require.NoError(t, writeResultsSummary(out, rulesets))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, []string{"RULESET", "VIOLATIONS", "MANDATORY", "OPTIONAL", "POTENTIAL"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"eap8/eap7", "2", "3", "1", "0"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"quarkus/springboot", "2", "2", "0", "4"}, strings.Fields(lines[2]))
+	assert.Equal(t, []string{"total", "4", "5", "1", "4"}, strings.Fields(lines[3]))
+	// columns are aligned
+	assert.Equal(t, strings.Index(lines[0], "VIOLATIONS"), strings.Index(lines[3], "4"))
+}
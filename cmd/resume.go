@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// completeMarkerPrefix names the file marking that the --bulk analysis of
+// an input finished, it must not match the output.yaml.* report glob
+const completeMarkerPrefix = ".analysis-complete."
+
+func (a *analyzeCommand) validateResume() error {
+	if a.resume && !a.bulk {
+		return fmt.Errorf("--resume requires --bulk")
+	}
+	return nil
+}
+
+func (a *analyzeCommand) completeMarkerPath() string {
+	return filepath.Join(a.output, completeMarkerPrefix+a.inputShortName())
+}
+
+// markInputComplete atomically writes the marker of the input once its
+// results are moved to the per-application files
+func (a *analyzeCommand) markInputComplete() error {
+	tmp, err := os.CreateTemp(a.output, completeMarkerPrefix+"tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(a.input + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), a.completeMarkerPath())
+}
+
+// checkResume prepares the output dir of a --bulk --resume run. An input
+// with a complete marker is skipped. analysis.log marks a --bulk analysis
+// in progress and is never removed: while it exists resuming is refused.
+// Otherwise the results of an unfinished analysis, the shared files of a run
+// that failed and the per-application files of an input without a marker,
+// are stale and removed so the input is analyzed again.
+func (a *analyzeCommand) checkResume() error {
+	if _, err := os.Stat(a.completeMarkerPath()); err == nil {
+		a.resumeSkip = true
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(a.output, "analysis.log")); err == nil {
+		return fmt.Errorf("output dir %v contains 'analysis.log', a --bulk analysis is running or the last one failed. If none is running, remove analysis.log to resume", a.output)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	leftovers := []string{
+		filepath.Join(a.output, "output.yaml"),
+		filepath.Join(a.output, "dependencies.yaml"),
+	}
+	for _, name := range []string{"analysis.log", "output.yaml", "dependencies.yaml"} {
+		leftovers = append(leftovers, fmt.Sprintf("%s.%s", filepath.Join(a.output, name), a.inputShortName()))
+	}
+	for _, leftover := range leftovers {
+		if _, err := os.Stat(leftover); err != nil {
+			continue
+		}
+		a.log.Info("WARNING: removing the results of an unfinished analysis", "file", leftover)
+		if err := os.Remove(leftover); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", leftover, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumeBulkAnalysis(t *testing.T) {
+	output := t.TempDir()
+	input := filepath.Join(t.TempDir(), "billing")
+	require.NoError(t, os.Mkdir(input, 0755))
+	newCommand := func() *analyzeCommand {
+		return &analyzeCommand{
+			input:  input,
+			output: output,
+			mode:   "source-only",
+			bulk:   true,
+			resume: true,
+			AnalyzeCommandContext: AnalyzeCommandContext{
+				log: logr.Discard(),
+			},
+		}
+	}
+
+	// analysis.log marks a running analysis, or one that failed, it is never
+	// removed
+	require.NoError(t, os.WriteFile(filepath.Join(output, "analysis.log"), []byte("log"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(output, "output.yaml"), []byte("partial"), 0644))
+	a := newCommand()
+	assert.ErrorContains(t, a.CheckOverwriteOutput(), "remove analysis.log to resume")
+	assert.FileExists(t, filepath.Join(output, "analysis.log"))
+	assert.FileExists(t, filepath.Join(output, "output.yaml"))
+
+	// once analysis.log of the failed run is removed its results are stale
+	require.NoError(t, os.Remove(filepath.Join(output, "analysis.log")))
+	a = newCommand()
+	require.NoError(t, a.CheckOverwriteOutput())
+	assert.False(t, a.resumeSkip)
+	assert.NoFileExists(t, filepath.Join(output, "output.yaml"))
+
+	// the run that follows finishes the input
+	require.NoError(t, os.WriteFile(filepath.Join(output, "analysis.log"), []byte("log"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(output, "output.yaml"), []byte("output"), 0644))
+	require.NoError(t, a.moveResults())
+	assert.FileExists(t, filepath.Join(output, completeMarkerPrefix+"billing"))
+	matches, err := filepath.Glob(filepath.Join(output, "output.yaml.*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(output, "output.yaml.billing")}, matches)
+
+	a = newCommand()
+	require.NoError(t, a.CheckOverwriteOutput())
+	assert.True(t, a.resumeSkip)
+	assert.FileExists(t, filepath.Join(output, "output.yaml.billing"))
+
+	// without the marker the results of the input are incomplete
+	require.NoError(t, os.Remove(filepath.Join(output, completeMarkerPrefix+"billing")))
+	a = newCommand()
+	require.NoError(t, a.CheckOverwriteOutput())
+	assert.False(t, a.resumeSkip)
+	assert.NoFileExists(t, filepath.Join(output, "output.yaml.billing"))
+	assert.NoFileExists(t, filepath.Join(output, "analysis.log.billing"))
+
+	// without --resume the same input is still rejected
+	require.NoError(t, os.WriteFile(filepath.Join(output, "output.yaml.billing"), []byte("output"), 0644))
+	a = newCommand()
+	a.resume = false
+	assert.Error(t, a.CheckOverwriteOutput())
+}
+
+func TestValidateResume(t *testing.T) {
+	a := &analyzeCommand{resume: true}
+	assert.Error(t, a.validateResume())
+	a.bulk = true
+	assert.NoError(t, a.validateResume())
+}
@@ -42,7 +42,8 @@ var rootCmd = &cobra.Command{
 				return
 			}
 			// In production, report the error to the user
-			log.Fatalf("Error parsing flags: %v", err)
+			log.Printf("Error parsing flags: %v", err)
+			os.Exit(exitCodeValidation)
 		}
 		// TODO (pgaikwad): this is a hack to set log level
 		// this won't work if any subcommand overrides this func
@@ -64,11 +65,23 @@ func init() {
 	}
 	rootCmd.AddGroup(&assertGenerationGroup)
 
+	// unknown flags and invalid flag values are usage errors
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &ValidationError{Err: err}
+	})
+
 	logger := logrusr.New(logrusLog)
 	rootCmd.AddCommand(NewTransformCommand(logger))
 	rootCmd.AddCommand(NewAnalyzeCmd(logger))
 	rootCmd.AddCommand(NewTestCommand(logger))
+	rootCmd.AddCommand(NewMergeCommand(logger))
+	rootCmd.AddCommand(NewValidateRulesCommand(logger))
+	rootCmd.AddCommand(NewRulesCommand(logger))
+	rootCmd.AddCommand(NewReportCommand(logger))
+	rootCmd.AddCommand(NewCleanCommand(logger))
+	rootCmd.AddCommand(NewDiffCommand(logger))
 	rootCmd.AddCommand(NewVersionCommand())
+	rootCmd.AddCommand(NewCompletionCommand())
 	rootCmd.AddCommand(discover.NewDiscoverCommand(logger))
 	rootCmd.AddCommand(generate.NewGenerateCommand(logger))
 	rootCmd.AddCommand(config.NewConfigCmd(logger))
@@ -79,8 +92,8 @@ func init() {
 func Execute() {
 	err := Settings.Load()
 	if err != nil {
-		log.Fatal(err, "failed to load global settings")
-		os.Exit(1)
+		log.Println(err, "failed to load global settings")
+		os.Exit(exitCodeInternal)
 	}
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
@@ -88,7 +101,7 @@ func Execute() {
 
 	rootCmd.Use = Settings.RootCommandName
 	err = rootCmd.ExecuteContext(ctx)
-	if err != nil {
-		os.Exit(1)
+	if code := exitCode(err); code != exitCodeSuccess {
+		os.Exit(code)
 	}
 }
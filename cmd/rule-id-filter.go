@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"regexp"
+
+	"github.com/konveyor/analyzer-lsp/engine"
+)
+
+// ruleIDSelector selects the rules whose ID matches any --rule-id glob
+// pattern. The engine requires every selector to match, so it combines with
+// the label selector as an AND.
+type ruleIDSelector struct {
+	patterns []*regexp.Regexp
+}
+
+var _ engine.RuleSelector = &ruleIDSelector{}
+
+func newRuleIDSelector(patterns []string) (*ruleIDSelector, error) {
+	compiled, err := compilePathPatterns("rule-id", patterns)
+	if err != nil {
+		return nil, err
+	}
+	return &ruleIDSelector{patterns: compiled}, nil
+}
+
+func (s *ruleIDSelector) Matches(meta *engine.RuleMeta) (bool, error) {
+	for _, pattern := range s.patterns {
+		if pattern.MatchString(meta.RuleID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ruleIDSelectors returns the --rule-id selector to append to the engine
+// selectors, none when the flag is not set
+func (a *analyzeCommand) ruleIDSelectors() ([]engine.RuleSelector, error) {
+	if len(a.ruleIDs) == 0 {
+		return nil, nil
+	}
+	selector, err := newRuleIDSelector(a.ruleIDs)
+	if err != nil {
+		return nil, err
+	}
+	return []engine.RuleSelector{selector}, nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/konveyor/analyzer-lsp/engine"
+	"github.com/konveyor/analyzer-lsp/engine/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleIDSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		ruleID   string
+		want     bool
+	}{
+		{name: "exact", patterns: []string{"jakarta-00010"}, ruleID: "jakarta-00010", want: true},
+		{name: "exact mismatch", patterns: []string{"jakarta-00010"}, ruleID: "jakarta-00011", want: false},
+		{name: "glob", patterns: []string{"my-ruleset-*"}, ruleID: "my-ruleset-00001", want: true},
+		{name: "glob mismatch", patterns: []string{"my-ruleset-*"}, ruleID: "other-ruleset-00001", want: false},
+		{name: "any pattern", patterns: []string{"a-*", "b-?"}, ruleID: "b-1", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := newRuleIDSelector(tt.patterns)
+			require.NoError(t, err)
+			got, err := selector.Matches(&engine.RuleMeta{RuleID: tt.ruleID})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRuleIDSelectorWithLabelSelector(t *testing.T) {
+	a := &analyzeCommand{ruleIDs: []string{"my-ruleset-*"}}
+	selectors, err := a.ruleIDSelectors()
+	require.NoError(t, err)
+	labelSelector, err := labels.NewLabelSelector[*engine.RuleMeta]("konveyor.io/target=quarkus", nil)
+	require.NoError(t, err)
+	selectors = append(selectors, labelSelector)
+
+	matchesAll := func(meta engine.RuleMeta) bool {
+		for _, s := range selectors {
+			if ok, err := s.Matches(&meta); err != nil || !ok {
+				return false
+			}
+		}
+		return true
+	}
+	assert.True(t, matchesAll(engine.RuleMeta{RuleID: "my-ruleset-1", Labels: []string{"konveyor.io/target=quarkus"}}))
+	assert.False(t, matchesAll(engine.RuleMeta{RuleID: "my-ruleset-1", Labels: []string{"konveyor.io/target=eap8"}}))
+	assert.False(t, matchesAll(engine.RuleMeta{RuleID: "other-1", Labels: []string{"konveyor.io/target=quarkus"}}))
+}
+
+func TestRuleIDSelectorsUnset(t *testing.T) {
+	a := &analyzeCommand{}
+	selectors, err := a.ruleIDSelectors()
+	require.NoError(t, err)
+	assert.Empty(t, selectors)
+}
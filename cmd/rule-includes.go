@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	"gopkg.in/yaml.v3"
+)
+
+// ruleIncludeTag inlines the content of the yaml file at the tagged path,
+// e.g. `or: !include conditions/ejb.yaml`
+const ruleIncludeTag = "!include"
+
+// resolveRuleIncludes returns the path to load rulePath from, a temp copy
+// with every !include inlined when any of its rule files has one. Files only
+// included by other files are left out of the copy, so fragments can be kept
+// next to the rules without being loaded as rules.
+func (a *analyzeCommand) resolveRuleIncludes(rulePath string) (string, error) {
+	stat, err := os.Stat(rulePath)
+	if err != nil {
+		// left for the rule parser to report
+		return rulePath, nil
+	}
+	files, err := findRuleFiles(rulePath)
+	if err != nil {
+		return "", err
+	}
+	resolved := map[string][]byte{}
+	included := map[string]bool{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Contains(content, []byte(ruleIncludeTag)) {
+			continue
+		}
+		content, err = resolveRuleFileIncludes(file, content, included)
+		if err != nil {
+			return "", err
+		}
+		resolved[file] = content
+	}
+	if len(resolved) == 0 {
+		return rulePath, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "analyze-rule-includes-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir for rules with includes: %w", err)
+	}
+	a.tempDirs = append(a.tempDirs, tempDir)
+	if !stat.IsDir() {
+		dest := filepath.Join(tempDir, filepath.Base(rulePath))
+		a.log.V(1).Info("resolved rule includes", "rules", rulePath, "dest", dest)
+		return dest, os.WriteFile(dest, resolved[rulePath], 0644)
+	}
+	err = filepath.WalkDir(rulePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relpath, err := filepath.Rel(rulePath, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(tempDir, relpath)
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+		if included[filepath.Clean(path)] {
+			return nil
+		}
+		if content, ok := resolved[path]; ok {
+			return os.WriteFile(dest, content, 0644)
+		}
+		return util.CopyFileContents(path, dest)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to copy rules with includes %s: %w", rulePath, err)
+	}
+	a.log.V(1).Info("resolved rule includes", "rules", rulePath, "dest", tempDir)
+	return tempDir, nil
+}
+
+// resolveRuleFileIncludes inlines the includes of a rule file, paths are
+// relative to the including file. Every included file is added to included.
+func resolveRuleFileIncludes(file string, content []byte, included map[string]bool) ([]byte, error) {
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse rules %s: %w", file, err)
+	}
+	if err := resolveIncludeNodes(&doc, filepath.Dir(file), []string{filepath.Clean(file)}, included); err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to write rules %s: %w", file, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resolveIncludeNodes replaces the !include nodes below node with the
+// content of the included file, stack holds the files being included to
+// report cycles
+func resolveIncludeNodes(node *yaml.Node, dir string, stack []string, included map[string]bool) error {
+	if node.Kind == yaml.ScalarNode && node.Tag == ruleIncludeTag {
+		path := node.Value
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		path = filepath.Clean(path)
+		for i, file := range stack {
+			if file == path {
+				return fmt.Errorf("include cycle %s", strings.Join(append(stack[i:], path), " -> "))
+			}
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s included from %s: %w", node.Value, stack[len(stack)-1], err)
+		}
+		doc := yaml.Node{}
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return fmt.Errorf("failed to parse %s included from %s: %w", node.Value, stack[len(stack)-1], err)
+		}
+		if len(doc.Content) == 0 {
+			return fmt.Errorf("%s included from %s is empty", node.Value, stack[len(stack)-1])
+		}
+		included[path] = true
+		if err := resolveIncludeNodes(doc.Content[0], filepath.Dir(path), append(stack, path), included); err != nil {
+			return err
+		}
+		*node = *doc.Content[0]
+		return nil
+	}
+	for _, child := range node.Content {
+		if err := resolveIncludeNodes(child, dir, stack, included); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func writeRuleFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestResolveRuleIncludes(t *testing.T) {
+	rulesDir := t.TempDir()
+	writeRuleFiles(t, rulesDir, map[string]string{
+		"ruleset.yaml": "name: custom\n",
+		"rules.yaml": `- ruleID: ejb-00001
+  message: remove ejb
+  when: !include fragments/ejb.yaml
+`,
+		"fragments/ejb.yaml":       "or:\n- !include stateless.yaml\n- java.referenced:\n    pattern: javax.ejb.Stateful\n",
+		"fragments/stateless.yaml": "java.referenced:\n  pattern: javax.ejb.Stateless\n",
+		"other.yaml":               "- ruleID: other-00001\n  when:\n    builtin.file:\n      pattern: pom.xml\n",
+	})
+
+	a := &analyzeCommand{}
+	a.log = logr.Discard()
+	resolved, err := a.resolveRuleIncludes(rulesDir)
+	require.NoError(t, err)
+	assert.NotEqual(t, rulesDir, resolved)
+	assert.Equal(t, []string{resolved}, a.tempDirs)
+	assert.NoFileExists(t, filepath.Join(resolved, "fragments", "ejb.yaml"))
+	assert.NoFileExists(t, filepath.Join(resolved, "fragments", "stateless.yaml"))
+	assert.FileExists(t, filepath.Join(resolved, "ruleset.yaml"))
+	assert.FileExists(t, filepath.Join(resolved, "other.yaml"))
+
+	content, err := os.ReadFile(filepath.Join(resolved, "rules.yaml"))
+	require.NoError(t, err)
+	rules := []map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(content, &rules))
+	require.Len(t, rules, 1)
+	assert.Equal(t, map[string]interface{}{
+		"or": []interface{}{
+			map[string]interface{}{"java.referenced": map[string]interface{}{"pattern": "javax.ejb.Stateless"}},
+			map[string]interface{}{"java.referenced": map[string]interface{}{"pattern": "javax.ejb.Stateful"}},
+		},
+	}, rules[0]["when"])
+}
+
+func TestResolveRuleIncludesFile(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFiles(t, dir, map[string]string{
+		"plain.yaml":     "- ruleID: plain-00001\n  when:\n    builtin.file:\n      pattern: pom.xml\n",
+		"rules.yaml":     "- ruleID: pom-00001\n  when: !include when.yaml\n",
+		"when.yaml":      "builtin.file:\n  pattern: pom.xml\n",
+		"cycle-a.yaml":   "- ruleID: cycle-00001\n  when: !include cycle-b.yaml\n",
+		"cycle-b.yaml":   "or:\n- !include cycle-a.yaml\n",
+		"missing.yaml":   "- ruleID: missing-00001\n  when: !include nothing.yaml\n",
+		"ruleset/r.yaml": "- ruleID: r-00001\n",
+	})
+
+	a := &analyzeCommand{}
+	a.log = logr.Discard()
+	resolved, err := a.resolveRuleIncludes(filepath.Join(dir, "plain.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "plain.yaml"), resolved)
+	resolved, err = a.resolveRuleIncludes(filepath.Join(dir, "ruleset"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "ruleset"), resolved)
+	assert.Empty(t, a.tempDirs)
+
+	resolved, err = a.resolveRuleIncludes(filepath.Join(dir, "rules.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "rules.yaml", filepath.Base(resolved))
+	content, err := os.ReadFile(resolved)
+	require.NoError(t, err)
+	assert.Equal(t, "- ruleID: pom-00001\n  when:\n    builtin.file:\n      pattern: pom.xml\n", string(content))
+
+	_, err = a.resolveRuleIncludes(filepath.Join(dir, "cycle-a.yaml"))
+	assert.ErrorContains(t, err, "include cycle")
+	_, err = a.resolveRuleIncludes(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "nothing.yaml")
+}
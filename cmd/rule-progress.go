@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/progress"
+)
+
+// ruleProgressLogInterval is how often rule evaluation progress is logged
+// when the progress bar is disabled
+const ruleProgressLogInterval = 5 * time.Second
+
+// ruleProgressReporter records the latest rule execution progress reported by
+// the engine so it can be logged periodically when the progress bar is disabled
+type ruleProgressReporter struct {
+	mu      sync.Mutex
+	current int
+	total   int
+}
+
+func (r *ruleProgressReporter) Report(event progress.ProgressEvent) {
+	if event.Stage != progress.StageRuleExecution || event.Total <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = event.Current
+	r.total = event.Total
+}
+
+func (r *ruleProgressReporter) snapshot() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current, r.total
+}
+
+// startRuleProgressLog logs rule evaluation progress every interval while the
+// engine runs rules. RunRules is synchronous so the returned function must be
+// called once it returns to stop logging.
+func startRuleProgressLog(ctx context.Context, log logr.Logger, reporter progress.ProgressReporter, rulesetCount int, interval time.Duration) func() {
+	r, ok := reporter.(*ruleProgressReporter)
+	if !ok {
+		// the progress bar already shows rule evaluation progress
+		return func() {}
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	start := time.Now()
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				logRuleProgress(log, r, rulesetCount, time.Since(start))
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+func logRuleProgress(log logr.Logger, r *ruleProgressReporter, rulesetCount int, elapsed time.Duration) {
+	elapsed = elapsed.Round(time.Second)
+	current, total := r.snapshot()
+	if total == 0 {
+		// no progress reported by the engine yet
+		log.Info("evaluating rules", "rulesets", rulesetCount, "elapsed", elapsed.String())
+		return
+	}
+	log.Info("evaluating rules", "completed", current, "total", total,
+		"percent", (current*100)/total, "rulesets", rulesetCount, "elapsed", elapsed.String())
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/konveyor/analyzer-lsp/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleProgressReporter(t *testing.T) {
+	r := &ruleProgressReporter{}
+
+	r.Report(progress.ProgressEvent{Stage: progress.StageProviderPrepare, Current: 5, Total: 10})
+	current, total := r.snapshot()
+	assert.Equal(t, 0, current)
+	assert.Equal(t, 0, total)
+
+	r.Report(progress.ProgressEvent{Stage: progress.StageRuleExecution, Current: 3, Total: 12})
+	current, total = r.snapshot()
+	assert.Equal(t, 3, current)
+	assert.Equal(t, 12, total)
+
+	// zero total events don't reset progress
+	r.Report(progress.ProgressEvent{Stage: progress.StageRuleExecution})
+	current, total = r.snapshot()
+	assert.Equal(t, 3, current)
+	assert.Equal(t, 12, total)
+}
+
+func TestStartRuleProgressLog(t *testing.T) {
+	var mu sync.Mutex
+	lines := []string{}
+	log := funcr.New(func(prefix, args string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, args)
+	}, funcr.Options{})
+
+	r := &ruleProgressReporter{}
+	r.Report(progress.ProgressEvent{Stage: progress.StageRuleExecution, Current: 1, Total: 4})
+	stop := startRuleProgressLog(context.Background(), log, r, 2, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(lines) > 0
+	}, time.Second, 5*time.Millisecond)
+	stop()
+	// stop can safely be called again
+	stop()
+
+	mu.Lock()
+	assert.Contains(t, lines[0], `"completed"=1`)
+	assert.Contains(t, lines[0], `"total"=4`)
+	assert.Contains(t, lines[0], `"percent"=25`)
+	count := len(lines)
+	mu.Unlock()
+
+	// nothing is logged once stopped
+	time.Sleep(30 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, count, len(lines))
+	mu.Unlock()
+}
+
+func TestStartRuleProgressLog_progressBarReporter(t *testing.T) {
+	stop := startRuleProgressLog(context.Background(), logr.Discard(), progress.NewNoopReporter(), 1, time.Millisecond)
+	stop()
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/konveyor/analyzer-lsp/engine"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"gopkg.in/yaml.v2"
+)
+
+// ruleStatsFile lists every loaded rule with --rule-stats
+const ruleStatsFile = "rule-stats.yaml"
+
+// ruleStat tells whether a loaded rule matched and how many incidents it
+// produced, before any incidents are filtered out
+type ruleStat struct {
+	RuleSet   string `yaml:"ruleset"`
+	RuleID    string `yaml:"ruleID"`
+	Matched   bool   `yaml:"matched"`
+	Incidents int    `yaml:"incidents"`
+	Skipped   bool   `yaml:"skipped,omitempty"`
+	TimedOut  bool   `yaml:"timedOut,omitempty"`
+	Error     string `yaml:"error,omitempty"`
+}
+
+// ruleStats correlates the loaded rules with the engine results. Rules left
+// out by the selectors are skipped, rules without violations have zero
+// incidents.
+func ruleStats(loaded []engine.RuleSet, results []outputv1.RuleSet) []ruleStat {
+	resultsByName := map[string]outputv1.RuleSet{}
+	for _, result := range results {
+		resultsByName[result.Name] = result
+	}
+	stats := []ruleStat{}
+	for _, ruleSet := range loaded {
+		result := resultsByName[ruleSet.Name]
+		for _, rule := range ruleSet.Rules {
+			stat := ruleStat{RuleSet: ruleSet.Name, RuleID: rule.RuleID}
+			violation, matched := result.Violations[rule.RuleID]
+			if !matched {
+				violation, matched = result.Insights[rule.RuleID]
+			}
+			stat.Matched = matched
+			stat.Incidents = len(violation.Incidents)
+			stat.Skipped = slices.Contains(result.Skipped, rule.RuleID)
+			stat.Error = result.Errors[rule.RuleID]
+			stats = append(stats, stat)
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].RuleSet != stats[j].RuleSet {
+			return stats[i].RuleSet < stats[j].RuleSet
+		}
+		return stats[i].RuleID < stats[j].RuleID
+	})
+	return stats
+}
+
+// writeRuleStats writes rule-stats.yaml to the output dir with --rule-stats
+func (a *analyzeCommand) writeRuleStats(loaded []engine.RuleSet, results []outputv1.RuleSet) error {
+	if !a.ruleStats {
+		return nil
+	}
+	stats := ruleStats(loaded, results)
+	for i := range stats {
+		stats[i].TimedOut = a.ruleTimeouts.timedOut(stats[i].RuleSet, stats[i].RuleID)
+	}
+	data, err := yaml.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(a.output, ruleStatsFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ruleStatsFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konveyor/analyzer-lsp/engine"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestRuleStats(t *testing.T) {
+	rule := func(id string) engine.Rule {
+		return engine.Rule{RuleMeta: engine.RuleMeta{RuleID: id}}
+	}
+	loaded := []engine.RuleSet{
+		{Name: "quarkus", Rules: []engine.Rule{rule("quarkus-02"), rule("quarkus-01"), rule("quarkus-03"), rule("quarkus-04"), rule("quarkus-05")}},
+		{Name: "custom", Rules: []engine.Rule{rule("custom-01")}},
+	}
+	results := []outputv1.RuleSet{
+		{
+			Name: "quarkus",
+			Violations: map[string]outputv1.Violation{
+				"quarkus-01": {Incidents: []outputv1.Incident{{}, {}}},
+			},
+			Insights: map[string]outputv1.Violation{
+				"quarkus-02": {Incidents: []outputv1.Incident{{}}},
+			},
+			Errors:    map[string]string{"quarkus-04": "invalid condition"},
+			Unmatched: []string{"quarkus-03"},
+			Skipped:   []string{"quarkus-05"},
+		},
+	}
+	assert.Equal(t, []ruleStat{
+		{RuleSet: "custom", RuleID: "custom-01"},
+		{RuleSet: "quarkus", RuleID: "quarkus-01", Matched: true, Incidents: 2},
+		{RuleSet: "quarkus", RuleID: "quarkus-02", Matched: true, Incidents: 1},
+		{RuleSet: "quarkus", RuleID: "quarkus-03"},
+		{RuleSet: "quarkus", RuleID: "quarkus-04", Error: "invalid condition"},
+		{RuleSet: "quarkus", RuleID: "quarkus-05", Skipped: true},
+	}, ruleStats(loaded, results))
+}
+
+func TestWriteRuleStats(t *testing.T) {
+	loaded := []engine.RuleSet{{Name: "custom", Rules: []engine.Rule{{RuleMeta: engine.RuleMeta{RuleID: "custom-01"}}}}}
+	a := &analyzeCommand{output: t.TempDir()}
+	require.NoError(t, a.writeRuleStats(loaded, nil))
+	assert.NoFileExists(t, filepath.Join(a.output, ruleStatsFile))
+
+	a.ruleStats = true
+	require.NoError(t, a.writeRuleStats(loaded, nil))
+	content, err := os.ReadFile(filepath.Join(a.output, ruleStatsFile))
+	require.NoError(t, err)
+	stats := []ruleStat{}
+	require.NoError(t, yaml.Unmarshal(content, &stats))
+	assert.Equal(t, []ruleStat{{RuleSet: "custom", RuleID: "custom-01"}}, stats)
+	assert.Contains(t, string(content), "matched: false\n  incidents: 0\n")
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/engine"
+)
+
+// ruleTimeoutKey identifies a rule, rule IDs are only unique in a ruleset
+type ruleTimeoutKey struct {
+	ruleSet string
+	ruleID  string
+}
+
+// ruleTimeouts records the rules cancelled by --rule-timeout, rules are
+// evaluated concurrently by the engine workers
+type ruleTimeouts struct {
+	mu    sync.Mutex
+	rules map[ruleTimeoutKey]bool
+}
+
+func (t *ruleTimeouts) add(ruleSet, ruleID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.rules == nil {
+		t.rules = map[ruleTimeoutKey]bool{}
+	}
+	t.rules[ruleTimeoutKey{ruleSet: ruleSet, ruleID: ruleID}] = true
+}
+
+func (t *ruleTimeouts) timedOut(ruleSet, ruleID string) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rules[ruleTimeoutKey{ruleSet: ruleSet, ruleID: ruleID}]
+}
+
+// timeoutConditional bounds the evaluation of the conditions of a rule. The
+// engine has no per rule timeout, a rule whose provider ignores the
+// cancellation is left running in the background so the engine goes on.
+type timeoutConditional struct {
+	when     engine.Conditional
+	ruleSet  string
+	ruleID   string
+	timeout  time.Duration
+	timeouts *ruleTimeouts
+	log      logr.Logger
+}
+
+type conditionResult struct {
+	response engine.ConditionResponse
+	err      error
+}
+
+func (c *timeoutConditional) Evaluate(ctx context.Context, log logr.Logger, condCtx engine.ConditionContext) (engine.ConditionResponse, error) {
+	ruleCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	done := make(chan conditionResult, 1)
+	go func() {
+		response, err := c.when.Evaluate(ruleCtx, log, condCtx)
+		done <- conditionResult{response: response, err: err}
+	}()
+
+	var result conditionResult
+	select {
+	case result = <-done:
+	case <-ruleCtx.Done():
+		result.err = ruleCtx.Err()
+	}
+	if result.err != nil && ctx.Err() == nil && errors.Is(ruleCtx.Err(), context.DeadlineExceeded) {
+		c.log.Info("WARNING: rule evaluation timed out and was cancelled", "ruleset", c.ruleSet, "ruleID", c.ruleID, "timeout", c.timeout.String())
+		c.timeouts.add(c.ruleSet, c.ruleID)
+		return engine.ConditionResponse{}, fmt.Errorf("rule %s timed out after %s", c.ruleID, c.timeout)
+	}
+	return result.response, result.err
+}
+
+func (a *analyzeCommand) validateRuleTimeout() error {
+	if a.ruleTimeout < 0 {
+		return fmt.Errorf("--rule-timeout must not be negative")
+	}
+	return nil
+}
+
+// applyRuleTimeout wraps the conditions of every rule with --rule-timeout so
+// a slow rule is cancelled and reported as a rule error instead of stalling
+// the analysis
+func (a *analyzeCommand) applyRuleTimeout(ruleSets []engine.RuleSet) {
+	if a.ruleTimeout <= 0 {
+		return
+	}
+	if a.ruleTimeouts == nil {
+		a.ruleTimeouts = &ruleTimeouts{}
+	}
+	for i := range ruleSets {
+		for j, rule := range ruleSets[i].Rules {
+			if rule.When == nil {
+				continue
+			}
+			ruleSets[i].Rules[j].When = &timeoutConditional{
+				when:     rule.When,
+				ruleSet:  ruleSets[i].Name,
+				ruleID:   rule.RuleID,
+				timeout:  a.ruleTimeout,
+				timeouts: a.ruleTimeouts,
+				log:      a.log,
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/engine"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+// blockingConditional ignores the cancellation like a hanging provider
+type blockingConditional struct {
+	release chan struct{}
+}
+
+func (c blockingConditional) Evaluate(context.Context, logr.Logger, engine.ConditionContext) (engine.ConditionResponse, error) {
+	<-c.release
+	return engine.ConditionResponse{Matched: true}, nil
+}
+
+type matchingConditional struct{}
+
+func (matchingConditional) Evaluate(context.Context, logr.Logger, engine.ConditionContext) (engine.ConditionResponse, error) {
+	return engine.ConditionResponse{Matched: true, Incidents: []engine.IncidentContext{{}}}, nil
+}
+
+func TestValidateRuleTimeout(t *testing.T) {
+	assert.NoError(t, (&analyzeCommand{}).validateRuleTimeout())
+	assert.NoError(t, (&analyzeCommand{ruleTimeout: time.Minute}).validateRuleTimeout())
+	assert.Error(t, (&analyzeCommand{ruleTimeout: -time.Minute}).validateRuleTimeout())
+}
+
+func TestApplyRuleTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	ruleSets := []engine.RuleSet{
+		{
+			Name: "custom",
+			Rules: []engine.Rule{
+				{RuleMeta: engine.RuleMeta{RuleID: "slow-01"}, Perform: engine.Perform{Message: engine.Message{Text: &[]string{"slow"}[0]}}, When: blockingConditional{release: release}},
+				{RuleMeta: engine.RuleMeta{RuleID: "fast-01"}, Perform: engine.Perform{Message: engine.Message{Text: &[]string{"fast"}[0]}}, When: matchingConditional{}},
+			},
+		},
+	}
+	a := &analyzeCommand{
+		output:      t.TempDir(),
+		ruleStats:   true,
+		ruleTimeout: 100 * time.Millisecond,
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	a.applyRuleTimeout(ruleSets)
+
+	eng := engine.CreateRuleEngine(context.Background(), 2, logr.Discard())
+	defer eng.Stop()
+	done := make(chan []outputv1.RuleSet, 1)
+	go func() {
+		done <- eng.RunRules(context.Background(), ruleSets)
+	}()
+	var rulesets []outputv1.RuleSet
+	select {
+	case rulesets = <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the engine is stalled by the slow rule")
+	}
+	require.Len(t, rulesets, 1)
+	assert.Contains(t, rulesets[0].Errors["slow-01"], "timed out after 100ms")
+	assert.Contains(t, rulesets[0].Insights, "fast-01")
+	require.NoError(t, a.writeRuleStats(ruleSets, rulesets))
+
+	assert.True(t, a.ruleTimeouts.timedOut("custom", "slow-01"))
+	assert.False(t, a.ruleTimeouts.timedOut("custom", "fast-01"))
+	content, err := os.ReadFile(filepath.Join(a.output, ruleStatsFile))
+	require.NoError(t, err)
+	stats := []ruleStat{}
+	require.NoError(t, yaml.Unmarshal(content, &stats))
+	require.Len(t, stats, 2)
+	assert.Equal(t, "fast-01", stats[0].RuleID)
+	assert.False(t, stats[0].TimedOut)
+	assert.Equal(t, "slow-01", stats[1].RuleID)
+	assert.True(t, stats[1].TimedOut)
+}
+
+func TestApplyRuleTimeoutDisabled(t *testing.T) {
+	ruleSets := []engine.RuleSet{{Name: "custom", Rules: []engine.Rule{{When: matchingConditional{}}}}}
+	a := &analyzeCommand{}
+	a.applyRuleTimeout(ruleSets)
+	assert.Equal(t, matchingConditional{}, ruleSets[0].Rules[0].When)
+	assert.Nil(t, a.ruleTimeouts)
+}
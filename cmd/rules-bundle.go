@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// rulesBundleSignatureSuffix is appended to the path of a rules bundle to
+// find its detached signature
+const rulesBundleSignatureSuffix = ".sig"
+
+// isRulesBundle returns true when the --rules path is a .zip or .jar file
+func isRulesBundle(rulePath string) bool {
+	ext := strings.ToLower(filepath.Ext(rulePath))
+	if ext != ".zip" && ext != ".jar" {
+		return false
+	}
+	stat, err := os.Stat(rulePath)
+	return err == nil && stat.Mode().IsRegular()
+}
+
+// resolveRulesBundles extracts the rules bundles given with --rules to temp
+// dirs and replaces them with the extracted rules, so both the containerless
+// and the container flows handle them as any other rules dir. With
+// --rules-bundle-key every bundle must have a valid signature.
+func (a *analyzeCommand) resolveRulesBundles() error {
+	var publicKey crypto.PublicKey
+	if a.rulesBundleKey != "" {
+		var err error
+		publicKey, err = loadRulesBundleKey(a.rulesBundleKey)
+		if err != nil {
+			return err
+		}
+	}
+	for i, rulePath := range a.rules {
+		if !isRulesBundle(rulePath) {
+			continue
+		}
+		if publicKey != nil {
+			if err := verifyRulesBundle(rulePath, publicKey); err != nil {
+				return err
+			}
+			a.log.V(1).Info("verified rules bundle signature", "bundle", rulePath)
+		}
+
+		tempDir, err := os.MkdirTemp("", "analyze-rules-bundle-")
+		if err != nil {
+			return fmt.Errorf("failed to create temp dir for rules bundle: %w", err)
+		}
+		a.tempDirs = append(a.tempDirs, tempDir)
+		if err := extractZip(rulePath, tempDir); err != nil {
+			return fmt.Errorf("failed to extract rules bundle %s: %w", rulePath, err)
+		}
+		// the manifest and signature files of a jar aren't rules
+		if err := os.RemoveAll(filepath.Join(tempDir, "META-INF")); err != nil {
+			return err
+		}
+		root, err := archiveRoot(tempDir)
+		if err != nil {
+			return err
+		}
+		a.log.V(1).Info("extracted rules bundle", "bundle", rulePath, "dir", root)
+		a.rules[i] = root
+	}
+	return nil
+}
+
+// loadRulesBundleKey reads a PEM encoded RSA, ECDSA or Ed25519 public key
+func loadRulesBundleKey(keyPath string) (crypto.PublicKey, error) {
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules bundle key %s: %w", keyPath, err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("rules bundle key %s is not PEM encoded", keyPath)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rules bundle key %s: %w", keyPath, err)
+	}
+	return key, nil
+}
+
+// verifyRulesBundle checks the raw or base64 encoded detached signature of a
+// bundle in <bundle>.sig. RSA and ECDSA signatures are of the sha256 digest
+// of the bundle, as made by openssl dgst -sha256 -sign.
+func verifyRulesBundle(bundlePath string, publicKey crypto.PublicKey) error {
+	signaturePath := bundlePath + rulesBundleSignatureSuffix
+	signature, err := os.ReadFile(signaturePath)
+	if err != nil {
+		return fmt.Errorf("failed to read signature of rules bundle %s: %w", bundlePath, err)
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature))); err == nil {
+		signature = decoded
+	}
+	content, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return fmt.Errorf("failed to read rules bundle %s: %w", bundlePath, err)
+	}
+	digest := sha256.Sum256(content)
+
+	verified := false
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		verified = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		verified = ecdsa.VerifyASN1(key, digest[:], signature)
+	case ed25519.PublicKey:
+		verified = ed25519.Verify(key, content, signature)
+	default:
+		return fmt.Errorf("unsupported rules bundle key type %T", publicKey)
+	}
+	if !verified {
+		return fmt.Errorf("invalid signature for rules bundle %s", bundlePath)
+	}
+	return nil
+}
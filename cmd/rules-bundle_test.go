@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"archive/zip"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRulesBundle(t *testing.T, bundlePath string, files map[string]string) {
+	f, err := os.Create(bundlePath)
+	require.NoError(t, err)
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+func TestResolveRulesBundles(t *testing.T) {
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "rules.jar")
+	writeRulesBundle(t, bundle, map[string]string{
+		"META-INF/MANIFEST.MF":    "Manifest-Version: 1.0\n",
+		"company/ruleset.yaml":    "name: company\n",
+		"company/rules/ejb.yaml":  "- ruleID: company-00001\n  when:\n    builtin.file:\n      pattern: ejb-jar.xml\n",
+		"company/rules/jndi.yaml": "- ruleID: company-00002\n  when:\n    builtin.file:\n      pattern: jndi.properties\n",
+	})
+	rulesDir := filepath.Join(dir, "plain")
+	require.NoError(t, os.Mkdir(rulesDir, 0755))
+
+	a := &analyzeCommand{
+		rules:                 []string{rulesDir, bundle},
+		AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+	}
+	require.NoError(t, a.resolveRulesBundles())
+	require.Len(t, a.tempDirs, 1)
+	assert.Equal(t, rulesDir, a.rules[0])
+	assert.Equal(t, filepath.Join(a.tempDirs[0], "company"), a.rules[1])
+	assert.FileExists(t, filepath.Join(a.rules[1], "rules", "ejb.yaml"))
+	assert.NoDirExists(t, filepath.Join(a.tempDirs[0], "META-INF"))
+}
+
+func TestResolveRulesBundlesZipSlip(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "rules.zip")
+	writeRulesBundle(t, bundle, map[string]string{"../../escaped.yaml": "- ruleID: escaped\n"})
+	a := &analyzeCommand{
+		rules:                 []string{bundle},
+		AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+	}
+	assert.ErrorContains(t, a.resolveRulesBundles(), "outside of extraction dir")
+}
+
+func TestResolveRulesBundlesSignature(t *testing.T) {
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "rules.zip")
+	writeRulesBundle(t, bundle, map[string]string{"rules.yaml": "- ruleID: company-00001\n"})
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, "rules.pub")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+	content, err := os.ReadFile(bundle)
+	require.NoError(t, err)
+
+	newCommand := func() *analyzeCommand {
+		return &analyzeCommand{
+			rules:                 []string{bundle},
+			rulesBundleKey:        keyFile,
+			AnalyzeCommandContext: AnalyzeCommandContext{log: logr.Discard()},
+		}
+	}
+	assert.ErrorContains(t, newCommand().resolveRulesBundles(), "failed to read signature", "unsigned bundles are rejected")
+
+	signature := ed25519.Sign(privateKey, content)
+	require.NoError(t, os.WriteFile(bundle+rulesBundleSignatureSuffix, []byte(base64.StdEncoding.EncodeToString(signature)+"\n"), 0644))
+	a := newCommand()
+	require.NoError(t, a.resolveRulesBundles())
+	assert.FileExists(t, filepath.Join(a.rules[0], "rules.yaml"))
+
+	// a raw signature of other content doesn't verify
+	require.NoError(t, os.WriteFile(bundle+rulesBundleSignatureSuffix, ed25519.Sign(privateKey, []byte("other")), 0644))
+	assert.ErrorContains(t, newCommand().resolveRulesBundles(), "invalid signature")
+}
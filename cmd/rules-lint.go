@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	lintMissingDescription = "missing-description"
+	lintDuplicateRuleID    = "duplicate-rule-id"
+	lintMissingLabels      = "missing-labels"
+	lintUnreachableWhen    = "unreachable-when"
+)
+
+type rulesLintCommand struct {
+	rules          []string
+	errorOnWarning bool
+	log            logr.Logger
+}
+
+// lintWarning is a rule smell found by rules lint
+type lintWarning struct {
+	File     string
+	Line     int
+	Category string
+	Message  string
+}
+
+func (w lintWarning) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s", w.File, w.Line, w.Category, w.Message)
+}
+
+// ruleLocation is where a rule ID was first seen
+type ruleLocation struct {
+	file string
+	line int
+}
+
+func NewRulesCommand(log logr.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Work with YAML rule files",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+	cmd.AddCommand(NewRulesLintCommand(log))
+	return cmd
+}
+
+func NewRulesLintCommand(log logr.Logger) *cobra.Command {
+	lintCmd := &rulesLintCommand{
+		log: log,
+	}
+
+	lintCommand := &cobra.Command{
+		Use:   "lint <path>...",
+		Short: "Check rule files for best-practice issues",
+		Long: "Warn about rules without a description or labels, rule IDs used more than once and when conditions that can never match. " +
+			"Use validate-rules for syntax and schema errors.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lintCmd.rules = args
+			if err := lintCmd.Validate(); err != nil {
+				log.Error(err, "failed to validate flags")
+				return &ValidationError{Err: err}
+			}
+			return lintCmd.Run(os.Stdout)
+		},
+	}
+	lintCommand.Flags().BoolVar(&lintCmd.errorOnWarning, "error-on-warning", false, "exit non-zero when any warning is found")
+	return lintCommand
+}
+
+func (l *rulesLintCommand) Validate() error {
+	for idx, rulePath := range l.rules {
+		absPath, err := filepath.Abs(rulePath)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path for rules %s: %w", rulePath, err)
+		}
+		if _, err := os.Stat(absPath); err != nil {
+			return fmt.Errorf("failed to stat rules %s: %w", rulePath, err)
+		}
+		l.rules[idx] = absPath
+	}
+	return nil
+}
+
+// Run prints the warnings of every rule file, an error is returned when a
+// file can't be parsed or, with --error-on-warning, when there are warnings
+func (l *rulesLintCommand) Run(out io.Writer) error {
+	seen := map[string]ruleLocation{}
+	warnings := 0
+	files := 0
+	invalid := 0
+	for _, rulePath := range l.rules {
+		ruleFiles, err := findRuleFiles(rulePath)
+		if err != nil {
+			return fmt.Errorf("failed to find rule files in %s: %w", rulePath, err)
+		}
+		for _, ruleFile := range ruleFiles {
+			// ruleset.yaml holds the ruleset metadata, not rules
+			if filepath.Base(ruleFile) == "ruleset.yaml" {
+				continue
+			}
+			content, err := os.ReadFile(ruleFile)
+			if err != nil {
+				return err
+			}
+			fileWarnings, err := lintRuleFile(ruleFile, content, seen)
+			if err != nil {
+				invalid++
+				fmt.Fprintf(out, "%s: error: %v\n", ruleFile, err)
+				continue
+			}
+			files++
+			for _, warning := range fileWarnings {
+				fmt.Fprintln(out, warning)
+			}
+			warnings += len(fileWarnings)
+		}
+	}
+	fmt.Fprintf(out, "%d warnings in %d rule files\n", warnings, files)
+	if invalid > 0 {
+		return &RuleParseError{Err: fmt.Errorf("%d rule files could not be parsed", invalid)}
+	}
+	if l.errorOnWarning && warnings > 0 {
+		return fmt.Errorf("found %d rule lint warnings", warnings)
+	}
+	return nil
+}
+
+// lintRuleFile returns the warnings of the rules in a rule file, seen holds
+// the rule IDs of the files linted before it. Files that aren't a list of
+// rules have no warnings.
+func lintRuleFile(file string, content []byte, seen map[string]ruleLocation) ([]lintWarning, error) {
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.SequenceNode {
+		return nil, nil
+	}
+	warnings := []lintWarning{}
+	for _, rule := range doc.Content[0].Content {
+		if rule.Kind != yaml.MappingNode {
+			continue
+		}
+		warn := func(line int, category string, format string, args ...interface{}) {
+			warnings = append(warnings, lintWarning{File: file, Line: line, Category: category, Message: fmt.Sprintf(format, args...)})
+		}
+		ruleID := "rule"
+		if idNode := mappingValue(rule, "ruleID"); idNode != nil && idNode.Value != "" {
+			ruleID = idNode.Value
+			if first, ok := seen[ruleID]; ok {
+				warn(idNode.Line, lintDuplicateRuleID, "%s is already defined at %s:%d", ruleID, first.file, first.line)
+			} else {
+				seen[ruleID] = ruleLocation{file: file, line: idNode.Line}
+			}
+		}
+		if description := mappingValue(rule, "description"); description == nil || description.Value == "" {
+			warn(rule.Line, lintMissingDescription, "%s has no description", ruleID)
+		}
+		if labels := mappingValue(rule, "labels"); labels == nil || len(labels.Content) == 0 {
+			warn(rule.Line, lintMissingLabels, "%s has no labels", ruleID)
+		}
+		for _, unreachable := range unreachableConditions(rule) {
+			warn(unreachable.Line, lintUnreachableWhen, "%s %s", ruleID, unreachable.Message)
+		}
+	}
+	return warnings, nil
+}
+
+// unreachableConditions returns the parts of the when of a rule that can
+// never match: a missing or empty when, an and/or without conditions and a
+// from that no condition of the rule defines with as
+func unreachableConditions(rule *yaml.Node) []lintWarning {
+	when := mappingValue(rule, "when")
+	if when == nil || len(when.Content) == 0 {
+		return []lintWarning{{Line: rule.Line, Message: "has no when condition, it never matches"}}
+	}
+	unreachable := []lintWarning{}
+	defined := map[string]bool{}
+	froms := []*yaml.Node{}
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				switch key.Value {
+				case "and", "or":
+					if value.Kind == yaml.SequenceNode && len(value.Content) == 0 {
+						unreachable = append(unreachable, lintWarning{Line: key.Line, Message: fmt.Sprintf("has an empty %s condition", key.Value)})
+					}
+				case "as":
+					defined[value.Value] = true
+				case "from":
+					froms = append(froms, value)
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(when)
+	for _, from := range froms {
+		if !defined[from.Value] {
+			unreachable = append(unreachable, lintWarning{Line: from.Line, Message: fmt.Sprintf("has a condition reading from %q which no condition defines with as", from.Value)})
+		}
+	}
+	return unreachable
+}
+
+// mappingValue returns the value of key in a yaml mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesLintCommand(t *testing.T) {
+	rulesDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "ruleset.yaml"), []byte("name: custom\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "a.yaml"), []byte(`- ruleID: custom-00001
+  description: healthy rule
+  labels:
+  - konveyor.io/target=quarkus
+  when:
+    and:
+    - java.referenced:
+        pattern: javax.ejb.Stateless
+      as: ejb
+    - builtin.xml:
+        xpath: //beans
+      from: ejb
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "b.yaml"), []byte(`- ruleID: custom-00001
+  description: duplicate
+  labels:
+  - konveyor.io/target=quarkus
+  when:
+    builtin.file:
+      pattern: pom.xml
+- ruleID: custom-00002
+  when:
+    or: []
+- ruleID: custom-00003
+  description: reads an undefined chain
+  labels:
+  - konveyor.io/target=quarkus
+  when:
+    builtin.xml:
+      xpath: //beans
+    from: missing
+- ruleID: custom-00004
+  description: no when
+  labels:
+  - konveyor.io/target=quarkus
+`), 0644))
+
+	l := &rulesLintCommand{rules: []string{rulesDir}, log: logr.Discard()}
+	require.NoError(t, l.Validate())
+	out := &bytes.Buffer{}
+	require.NoError(t, l.Run(out))
+
+	b := filepath.Join(rulesDir, "b.yaml")
+	for _, want := range []string{
+		b + ":1: duplicate-rule-id: custom-00001 is already defined at " + filepath.Join(rulesDir, "a.yaml") + ":1",
+		b + ":8: missing-description: custom-00002 has no description",
+		b + ":8: missing-labels: custom-00002 has no labels",
+		b + ":10: unreachable-when: custom-00002 has an empty or condition",
+		b + ":18: unreachable-when: custom-00003 has a condition reading from \"missing\" which no condition defines with as",
+		b + ":19: unreachable-when: custom-00004 has no when condition, it never matches",
+		"6 warnings in 2 rule files",
+	} {
+		assert.Contains(t, out.String(), want)
+	}
+	// the healthy rule of a.yaml has no warnings
+	for _, line := range strings.Split(out.String(), "\n") {
+		assert.False(t, strings.HasPrefix(line, filepath.Join(rulesDir, "a.yaml")), line)
+	}
+
+	l.errorOnWarning = true
+	assert.Error(t, l.Run(&bytes.Buffer{}))
+}
+
+func TestRulesLintCommandInvalidFile(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "invalid.yaml")
+	require.NoError(t, os.WriteFile(rulesFile, []byte("- ruleID: [\n"), 0644))
+
+	l := &rulesLintCommand{rules: []string{rulesFile}, log: logr.Discard()}
+	require.NoError(t, l.Validate())
+	out := &bytes.Buffer{}
+	assert.Error(t, l.Run(out))
+	assert.Contains(t, out.String(), rulesFile+": error:")
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/konveyor/analyzer-lsp/engine"
+	"github.com/konveyor/analyzer-lsp/engine/labels"
+)
+
+// rulesVersionLabel is the label rulesets and rules declare their version with
+const rulesVersionLabel = "konveyor.io/rules-version"
+
+// rulesVersionSelectors returns the --rules-version selector to append to
+// the engine selectors. Rules are matched with their ruleset labels, so
+// rulesets without the label are left out, except rules always included.
+func (a *analyzeCommand) rulesVersionSelectors() ([]engine.RuleSelector, error) {
+	if a.rulesVersion == "" {
+		return nil, nil
+	}
+	expr := labels.AsString(rulesVersionLabel, a.rulesVersion)
+	selector, err := labels.NewLabelSelector[*engine.RuleMeta](expr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rules version %q: %w", a.rulesVersion, err)
+	}
+	return []engine.RuleSelector{selector}, nil
+}
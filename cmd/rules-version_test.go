@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/konveyor/analyzer-lsp/engine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulesVersionSelectors(t *testing.T) {
+	a := &analyzeCommand{}
+	selectors, err := a.rulesVersionSelectors()
+	require.NoError(t, err)
+	assert.Empty(t, selectors)
+
+	a.rulesVersion = "v2"
+	selectors, err = a.rulesVersionSelectors()
+	require.NoError(t, err)
+	require.Len(t, selectors, 1)
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   bool
+	}{
+		{name: "matching version", labels: []string{"konveyor.io/target=quarkus", "konveyor.io/rules-version=v2"}, want: true},
+		{name: "other version", labels: []string{"konveyor.io/rules-version=v1"}},
+		{name: "no version label", labels: []string{"konveyor.io/target=quarkus"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := selectors[0].Matches(&engine.RuleMeta{RuleID: "rule", Labels: tt.labels})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, matches)
+		})
+	}
+}
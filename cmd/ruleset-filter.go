@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	"github.com/konveyor/analyzer-lsp/engine"
+	"github.com/konveyor/analyzer-lsp/engine/labels"
+	"gopkg.in/yaml.v3"
+)
+
+// discoveryLabel is on the rulesets whose rules detect the technologies of
+// the input, they are loaded whatever the sources and targets are
+const discoveryLabel = "discovery"
+
+// rulesetLabels holds only the fields needed to pre-filter rulesets
+type rulesetLabels struct {
+	Labels []string `yaml:"labels"`
+}
+
+// defaultRulesetPaths returns the paths to load for the default rulesets in
+// rulesDir. With --source or --target, or --rules-from-labels and a label
+// selector, rulesDir is expanded into its ruleset directories, skipping those
+// where no rule can match the selector so they are never parsed.
+// --load-all-rulesets always loads rulesDir as a whole, while
+// --discovery-rulesets-only only loads its discovery rulesets.
+func (a *analyzeCommand) defaultRulesetPaths(rulesDir string) []string {
+	// --strict-target checks the technologies of every default ruleset
+	a.defaultRulesetsDir = rulesDir
+	if a.discoveryRulesetsOnly {
+		return a.discoveryRulesetPaths(rulesDir)
+	}
+	labelSelector := a.getLabelSelector()
+	if a.loadAllRulesets || labelSelector == "" {
+		return []string{rulesDir}
+	}
+	if !a.rulesFromLabels && len(a.sources) == 0 && len(a.targets) == 0 {
+		return []string{rulesDir}
+	}
+	selector, err := labels.NewLabelSelector[*engine.RuleMeta](labelSelector, nil)
+	if err != nil {
+		return []string{rulesDir}
+	}
+	var ruleSelector engine.RuleSelector = selector
+	if a.rulesIncludeDisabled {
+		ruleSelector = &includeDisabledSelector{selector: selector}
+	}
+	paths, err := filterRulesetDirs(a.log, rulesDir, ruleSelector)
+	if err != nil {
+		a.log.Error(err, "failed to pre-filter default rulesets, loading all", "dir", rulesDir)
+		return []string{rulesDir}
+	}
+	return paths
+}
+
+// discoveryRulesetPaths returns the discovery rules of rulesDir, for
+// --discovery-rulesets-only. Rulesets mixing discovery and other rules are
+// copied with only their discovery rules, so the others are never parsed.
+func (a *analyzeCommand) discoveryRulesetPaths(rulesDir string) []string {
+	selector, err := labels.NewLabelSelector[*engine.RuleMeta](discoveryLabel, nil)
+	if err != nil {
+		return []string{rulesDir}
+	}
+	paths, err := filterRulesetDirs(a.log, rulesDir, selector)
+	if err != nil {
+		a.log.Error(err, "failed to find the discovery rulesets, loading all", "dir", rulesDir)
+		return []string{rulesDir}
+	}
+	for i, path := range paths {
+		if path == rulesDir {
+			continue
+		}
+		filtered, err := a.filterRulesetRules(path, selector)
+		if err != nil {
+			a.log.Error(err, "failed to filter the discovery rules, loading the whole ruleset", "ruleset", path)
+			continue
+		}
+		paths[i] = filtered
+	}
+	return paths
+}
+
+// filterRulesetRules returns the path to load the rules of the ruleset dir
+// matching selector from, a temp copy without the other rules when it has
+// any. Files it can't read are kept for the rule parser to report.
+func (a *analyzeCommand) filterRulesetRules(dir string, selector engine.RuleSelector) (string, error) {
+	ruleset := rulesetLabels{}
+	content, err := os.ReadFile(filepath.Join(dir, "ruleset.yaml"))
+	if err != nil {
+		return dir, nil
+	}
+	if err := yaml.Unmarshal(content, &ruleset); err != nil {
+		return dir, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	filtered := map[string][]byte{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			return dir, nil
+		}
+		if name == "ruleset.yaml" || strings.HasSuffix(name, ".test.yaml") || strings.HasSuffix(name, ".test.yml") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return "", err
+		}
+		rules, changed, err := filterRuleFile(content, ruleset.Labels, selector)
+		if err != nil {
+			return "", fmt.Errorf("failed to filter rules %s: %w", filepath.Join(dir, name), err)
+		}
+		if changed {
+			filtered[name] = rules
+		}
+	}
+	if len(filtered) == 0 {
+		return dir, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "analyze-discovery-rules-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir for discovery rules: %w", err)
+	}
+	a.tempDirs = append(a.tempDirs, tempDir)
+	dest := filepath.Join(tempDir, filepath.Base(dir))
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, ".test.yaml") || strings.HasSuffix(name, ".test.yml") {
+			continue
+		}
+		rules, ok := filtered[name]
+		switch {
+		case !ok:
+			err = util.CopyFileContents(filepath.Join(dir, name), filepath.Join(dest, name))
+		case rules != nil:
+			err = os.WriteFile(filepath.Join(dest, name), rules, 0644)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	a.log.V(1).Info("filtered discovery rules", "ruleset", dir, "dest", dest)
+	return dest, nil
+}
+
+// filterRuleFile drops the rules of a rule file not matching selector, the
+// ruleset labels are appended to the rule labels as the engine does. It
+// reports whether any rule was dropped, the returned content is nil when no
+// rule is left. Content that isn't a list of rules is kept as is.
+func filterRuleFile(content []byte, setLabels []string, selector engine.RuleSelector) ([]byte, bool, error) {
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal(content, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.SequenceNode {
+		return content, false, nil
+	}
+	rules := doc.Content[0]
+	kept := []*yaml.Node{}
+	for _, node := range rules.Content {
+		rule := struct {
+			Labels []string `yaml:"labels"`
+		}{}
+		if err := node.Decode(&rule); err != nil {
+			kept = append(kept, node)
+			continue
+		}
+		meta := &engine.RuleMeta{Labels: append(rule.Labels, setLabels...)}
+		if matches, err := selector.Matches(meta); err != nil || matches {
+			kept = append(kept, node)
+		}
+	}
+	if len(kept) == len(rules.Content) {
+		return content, false, nil
+	}
+	if len(kept) == 0 {
+		return nil, true, nil
+	}
+	rules.Content = kept
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, false, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, false, err
+	}
+	return buf.Bytes(), true, nil
+}
+
+// filterRulesetDirs returns the ruleset directories under rulesDir that
+// contain at least one rule matching selector, the same check the engine
+// applies to each rule with the ruleset labels appended
+func filterRulesetDirs(log logr.Logger, rulesDir string, selector engine.RuleSelector) ([]string, error) {
+	entries, err := os.ReadDir(rulesDir)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{}
+	for _, entry := range entries {
+		// loose rule files are only loaded through their parent ruleset,
+		// keep the layout as is when there are any
+		if !entry.IsDir() {
+			return []string{rulesDir}, nil
+		}
+	}
+	for _, entry := range entries {
+		dir := filepath.Join(rulesDir, entry.Name())
+		if !rulesetCanMatch(log, dir, selector) {
+			log.V(5).Info("skipping ruleset not matching label selector", "ruleset", dir)
+			continue
+		}
+		paths = append(paths, dir)
+	}
+	log.V(1).Info("pre-filtered default rulesets by label selector", "loaded", len(paths), "total", len(entries))
+	return paths, nil
+}
+
+// rulesetCanMatch reports whether any rule in the ruleset dir can match
+// selector, anything it can't read is assumed to match and left for the
+// rule parser to load and report
+func rulesetCanMatch(log logr.Logger, dir string, selector engine.RuleSelector) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return true
+	}
+	ruleset := rulesetLabels{}
+	content, err := os.ReadFile(filepath.Join(dir, "ruleset.yaml"))
+	if err != nil {
+		// not a ruleset, the parser loads nested rulesets from it
+		return true
+	}
+	if err := yaml.Unmarshal(content, &ruleset); err != nil {
+		return true
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			return true
+		}
+		if name == "ruleset.yaml" || strings.HasSuffix(name, ".test.yaml") || strings.HasSuffix(name, ".test.yml") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return true
+		}
+		rules := []rulesetLabels{}
+		if err := yaml.Unmarshal(content, &rules); err != nil {
+			log.V(5).Info("unable to read rule labels, keeping ruleset", "file", name, "error", err.Error())
+			return true
+		}
+		for _, rule := range rules {
+			meta := &engine.RuleMeta{Labels: append(rule.Labels, ruleset.Labels...)}
+			matches, err := selector.Matches(meta)
+			if err != nil || matches {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/engine"
+	"github.com/konveyor/analyzer-lsp/engine/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterRulesetDirs(t *testing.T) {
+	rulesDir := t.TempDir()
+	files := map[string]string{
+		"quarkus/ruleset.yaml": "name: quarkus\nlabels:\n- konveyor.io/source=java-ee\n",
+		"quarkus/rules.yaml":   "- ruleID: quarkus-00001\n  labels:\n  - konveyor.io/target=quarkus\n",
+		"azure/ruleset.yaml":   "name: azure\n",
+		"azure/rules.yaml":     "- ruleID: azure-00001\n  labels:\n  - konveyor.io/target=azure-appservice\n",
+		// the target label is on the ruleset, not the rules
+		"eap/ruleset.yaml":        "name: eap\nlabels:\n- konveyor.io/target=quarkus\n",
+		"eap/rules.yaml":          "- ruleID: eap-00001\n",
+		"always/ruleset.yaml":     "name: always\n",
+		"always/rules.yaml":       "- ruleID: always-00001\n  labels:\n  - konveyor.io/include=always\n",
+		"broken/ruleset.yaml":     "name: broken\n",
+		"broken/rules.yaml":       "ruleID: [",
+		"skipped/ruleset.yaml":    "name: skipped\n",
+		"skipped/rules.test.yaml": "- ruleID: test\n  labels:\n  - konveyor.io/target=quarkus\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rulesDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	selector, err := labels.NewLabelSelector[*engine.RuleMeta]("konveyor.io/target=quarkus", nil)
+	require.NoError(t, err)
+	paths, err := filterRulesetDirs(logr.Discard(), rulesDir, selector)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(rulesDir, "quarkus"),
+		filepath.Join(rulesDir, "eap"),
+		filepath.Join(rulesDir, "always"),
+		filepath.Join(rulesDir, "broken"),
+	}, paths)
+}
+
+func TestFilterRulesetDirsLooseFiles(t *testing.T) {
+	rulesDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "rules.yaml"), []byte("- ruleID: loose\n"), 0644))
+	selector, err := labels.NewLabelSelector[*engine.RuleMeta]("konveyor.io/target=quarkus", nil)
+	require.NoError(t, err)
+	paths, err := filterRulesetDirs(logr.Discard(), rulesDir, selector)
+	require.NoError(t, err)
+	assert.Equal(t, []string{rulesDir}, paths)
+}
+
+func TestDefaultRulesetPaths(t *testing.T) {
+	rulesDir := t.TempDir()
+	a := &analyzeCommand{
+		targets: []string{"quarkus"},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	assert.Empty(t, a.defaultRulesetPaths(rulesDir), "targets pre-filter the default rulesets")
+
+	a.loadAllRulesets = true
+	assert.Equal(t, []string{rulesDir}, a.defaultRulesetPaths(rulesDir))
+
+	a.loadAllRulesets = false
+	a.targets = nil
+	a.labelSelector = "konveyor.io/target=quarkus"
+	assert.Equal(t, []string{rulesDir}, a.defaultRulesetPaths(rulesDir), "a label selector needs --rules-from-labels")
+
+	a.rulesFromLabels = true
+	assert.Empty(t, a.defaultRulesetPaths(rulesDir))
+}
+
+func TestDiscoveryRulesetPaths(t *testing.T) {
+	rulesDir := t.TempDir()
+	files := map[string]string{
+		"00-discovery/ruleset.yaml": "name: discovery\nlabels:\n- discovery\n",
+		"00-discovery/rules.yaml":   "- ruleID: discover-java-files\n",
+		"quarkus/ruleset.yaml":      "name: quarkus\n",
+		"quarkus/rules.yaml":        "- ruleID: quarkus-00001\n  labels:\n  - konveyor.io/target=quarkus\n",
+		"technology/ruleset.yaml":   "name: technology\n",
+		"technology/java.yaml":      "- ruleID: java-00001\n  labels:\n  - konveyor.io/target=quarkus\n- ruleID: discover-maven\n  labels:\n  - discovery\n  tag:\n  - Maven\n",
+		"technology/eap.yaml":       "- ruleID: eap-00001\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rulesDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	a := &analyzeCommand{
+		discoveryRulesetsOnly: true,
+		targets:               []string{"quarkus"},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	paths := a.defaultRulesetPaths(rulesDir)
+	require.Len(t, paths, 2)
+	assert.Equal(t, filepath.Join(rulesDir, "00-discovery"), paths[0], "rulesets with only discovery rules are loaded as is")
+	t.Cleanup(func() { os.RemoveAll(filepath.Dir(paths[1])) })
+	assert.Equal(t, []string{filepath.Dir(paths[1])}, a.tempDirs)
+	assert.Equal(t, "technology", filepath.Base(paths[1]))
+	assert.FileExists(t, filepath.Join(paths[1], "ruleset.yaml"))
+	assert.NoFileExists(t, filepath.Join(paths[1], "eap.yaml"), "files without discovery rules are left out")
+	rules, err := os.ReadFile(filepath.Join(paths[1], "java.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "- ruleID: discover-maven\n  labels:\n    - discovery\n  tag:\n    - Maven\n", string(rules))
+}
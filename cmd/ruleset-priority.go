@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
+)
+
+func (a *analyzeCommand) validateRulesetPriority() error {
+	for i, name := range a.rulesetPriority {
+		if name == "" {
+			return fmt.Errorf("--ruleset-priority cannot contain an empty ruleset name")
+		}
+		if slices.Contains(a.rulesetPriority[:i], name) {
+			return fmt.Errorf("ruleset %q is listed more than once in --ruleset-priority", name)
+		}
+	}
+	return nil
+}
+
+// incidentLocation is where an incident is reported, incidents of different
+// rulesets at the same location are duplicates
+type incidentLocation struct {
+	uri  uri.URI
+	line int
+}
+
+// rulesetPriorityOrder returns the indexes of rulesets from the highest to
+// the lowest priority, the --ruleset-priority ones first in the given order
+// and the others after them sorted by name
+func rulesetPriorityOrder(rulesets []outputv1.RuleSet, priority []string) []int {
+	order := make([]int, len(rulesets))
+	for i := range order {
+		order[i] = i
+	}
+	rank := func(name string) int {
+		if i := slices.Index(priority, name); i >= 0 {
+			return i
+		}
+		return len(priority)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		ri, rj := rank(rulesets[order[i]].Name), rank(rulesets[order[j]].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return rulesets[order[i]].Name < rulesets[order[j]].Name
+	})
+	return order
+}
+
+// dedupeByRulesetPriority keeps a single ruleset's incidents for each
+// location with --ruleset-priority, dropping those of lower priority
+// rulesets. Incidents of the same ruleset and incidents without a line number
+// are always kept. Violations and insights are deduplicated separately.
+func (a *analyzeCommand) dedupeByRulesetPriority(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if len(a.rulesetPriority) == 0 {
+		return rulesets
+	}
+	seenViolations := map[incidentLocation]bool{}
+	seenInsights := map[incidentLocation]bool{}
+	dropped := 0
+	for _, i := range rulesetPriorityOrder(rulesets, a.rulesetPriority) {
+		dropped += dedupeViolations(rulesets[i].Violations, seenViolations)
+		dropped += dedupeViolations(rulesets[i].Insights, seenInsights)
+	}
+	if dropped > 0 {
+		a.log.V(1).Info("dropped incidents reported by a higher priority ruleset", "count", dropped)
+	}
+	return rulesets
+}
+
+// dedupeViolations drops the incidents at a location in seen, then adds the
+// locations of the remaining ones, and returns how many were dropped
+func dedupeViolations(violations map[string]outputv1.Violation, seen map[incidentLocation]bool) int {
+	dropped := 0
+	locations := []incidentLocation{}
+	for ruleID, violation := range violations {
+		incidents := []outputv1.Incident{}
+		for _, incident := range violation.Incidents {
+			if incident.LineNumber == nil {
+				incidents = append(incidents, incident)
+				continue
+			}
+			location := incidentLocation{uri: incident.URI, line: *incident.LineNumber}
+			if seen[location] {
+				dropped++
+				continue
+			}
+			locations = append(locations, location)
+			incidents = append(incidents, incident)
+		}
+		if len(incidents) == 0 && len(violation.Incidents) > 0 {
+			delete(violations, ruleID)
+			continue
+		}
+		violation.Incidents = incidents
+		violations[ruleID] = violation
+	}
+	for _, location := range locations {
+		seen[location] = true
+	}
+	return dropped
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+)
+
+func priorityRulesets() []outputv1.RuleSet {
+	line := func(n int) *int { return &n }
+	file := uri.URI("file:///app/src/Main.java")
+	return []outputv1.RuleSet{
+		{
+			Name: "cloud-readiness",
+			Violations: map[string]outputv1.Violation{
+				"local-storage-00001": {Incidents: []outputv1.Incident{
+					{URI: file, LineNumber: line(10)},
+					{URI: file, LineNumber: line(20)},
+				}},
+			},
+		},
+		{
+			Name: "custom",
+			Violations: map[string]outputv1.Violation{
+				"custom-00001": {Incidents: []outputv1.Incident{{URI: file, LineNumber: line(10)}}},
+				"custom-00002": {Incidents: []outputv1.Incident{{URI: file, LineNumber: line(10)}}},
+				"custom-00003": {Incidents: []outputv1.Incident{{URI: file}}},
+			},
+		},
+		{
+			Name: "eap8",
+			Violations: map[string]outputv1.Violation{
+				"eap8-00001": {Incidents: []outputv1.Incident{
+					{URI: file, LineNumber: line(20)},
+					{URI: file, LineNumber: line(30)},
+				}},
+			},
+			Insights: map[string]outputv1.Violation{
+				"eap8-00002": {Incidents: []outputv1.Incident{{URI: file, LineNumber: line(10)}}},
+			},
+		},
+	}
+}
+
+func TestDedupeByRulesetPriority(t *testing.T) {
+	a := &analyzeCommand{
+		rulesetPriority: []string{"custom"},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	rulesets := a.dedupeByRulesetPriority(priorityRulesets())
+
+	// custom wins at line 10, both of its rules keep their incident
+	assert.Len(t, rulesets[1].Violations, 3)
+	// cloud-readiness sorts before eap8 and wins at line 20
+	require.Contains(t, rulesets[0].Violations, "local-storage-00001")
+	assert.Len(t, rulesets[0].Violations["local-storage-00001"].Incidents, 1)
+	assert.Equal(t, 20, *rulesets[0].Violations["local-storage-00001"].Incidents[0].LineNumber)
+	assert.Len(t, rulesets[2].Violations["eap8-00001"].Incidents, 1)
+	assert.Equal(t, 30, *rulesets[2].Violations["eap8-00001"].Incidents[0].LineNumber)
+	// insights are deduplicated separately from violations
+	assert.Len(t, rulesets[2].Insights["eap8-00002"].Incidents, 1)
+}
+
+func TestDedupeByRulesetPriorityDropsEmptyViolations(t *testing.T) {
+	a := &analyzeCommand{
+		rulesetPriority: []string{"eap8", "custom"},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	rulesets := a.dedupeByRulesetPriority(priorityRulesets())
+
+	assert.Len(t, rulesets[2].Violations["eap8-00001"].Incidents, 2)
+	assert.Len(t, rulesets[1].Violations, 3)
+	// both cloud-readiness incidents are reported by a higher priority ruleset
+	assert.NotContains(t, rulesets[0].Violations, "local-storage-00001")
+}
+
+func TestDedupeByRulesetPriorityDisabled(t *testing.T) {
+	a := &analyzeCommand{}
+	rulesets := a.dedupeByRulesetPriority(priorityRulesets())
+	assert.Equal(t, priorityRulesets(), rulesets)
+}
+
+func TestValidateRulesetPriority(t *testing.T) {
+	a := &analyzeCommand{rulesetPriority: []string{"custom", "eap8"}}
+	assert.NoError(t, a.validateRulesetPriority())
+
+	a.rulesetPriority = []string{"custom", "eap8", "custom"}
+	assert.Error(t, a.validateRulesetPriority())
+
+	a.rulesetPriority = []string{""}
+	assert.Error(t, a.validateRulesetPriority())
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// runMetadataFile describes the analysis run in the output dir
+const runMetadataFile = "metadata.json"
+
+const analyzerModule = "github.com/konveyor/analyzer-lsp"
+
+// sensitiveFlags are written to the run metadata without their values
+var sensitiveFlags = map[string]bool{
+	"maven-password": true,
+}
+
+// runMetadata makes an analysis output self-describing, recording what
+// produced it and when
+type runMetadata struct {
+	KantraVersion        string            `json:"kantraVersion"`
+	BuildCommit          string            `json:"buildCommit,omitempty"`
+	AnalyzerVersion      string            `json:"analyzerVersion,omitempty"`
+	Providers            []string          `json:"providers"`
+	UnavailableProviders []string          `json:"unavailableProviders,omitempty"`
+	Rules                []string          `json:"rules"`
+	Flags                map[string]string `json:"flags,omitempty"`
+	StartTime            time.Time         `json:"startTime"`
+	EndTime              time.Time         `json:"endTime"`
+	DurationSeconds      float64           `json:"durationSeconds"`
+}
+
+// analyzerVersion returns the analyzer-lsp module version kantra was built
+// with
+func analyzerVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != analyzerModule {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return ""
+}
+
+// resolvedFlags returns the value of every analyze flag after config files,
+// profiles and validation are applied. Passwords and credentials in urls are
+// redacted.
+func resolvedFlags(flags *pflag.FlagSet) map[string]string {
+	if flags == nil {
+		return nil
+	}
+	values := map[string]string{}
+	flags.VisitAll(func(flag *pflag.Flag) {
+		value := flag.Value.String()
+		if sensitiveFlags[flag.Name] && value != "" {
+			value = "xxxxx"
+		} else if u, err := url.Parse(value); err == nil && u.User != nil {
+			value = u.Redacted()
+		}
+		values[flag.Name] = value
+	})
+	return values
+}
+
+// writeRunMetadata writes metadata.json to the output dir at the end of the
+// analysis, or to stderr with --output -
+func (a *analyzeCommand) writeRunMetadata(start time.Time, providers []string) error {
+	end := time.Now()
+	sortedProviders := append([]string{}, providers...)
+	sort.Strings(sortedProviders)
+	metadata := runMetadata{
+		KantraVersion:        Version,
+		BuildCommit:          BuildCommit,
+		AnalyzerVersion:      analyzerVersion(),
+		Providers:            sortedProviders,
+		UnavailableProviders: a.unavailableProviders,
+		Rules:                append([]string{}, a.rules...),
+		Flags:                resolvedFlags(a.flags),
+		StartTime:            start.UTC(),
+		EndTime:              end.UTC(),
+		DurationSeconds:      end.Sub(start).Seconds(),
+	}
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run metadata: %w", err)
+	}
+	if a.outputToStdout {
+		// the temporary output dir is removed, stdout only holds the results
+		if _, err := fmt.Fprintln(os.Stderr, string(data)); err != nil {
+			return fmt.Errorf("failed to write run metadata to stderr: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(filepath.Join(a.output, runMetadataFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", runMetadataFile, err)
+	}
+	return nil
+}
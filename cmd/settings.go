@@ -19,6 +19,11 @@ var (
 	JavaProviderImage    = "quay.io/konveyor/java-external-provider"
 	GenericProviderImage = "quay.io/konveyor/generic-external-provider"
 	DotnetProviderImage  = "quay.io/konveyor/dotnet-external-provider"
+	// binaries used to run the go and python providers in containerless mode
+	GenericProviderBinary      = "generic-external-provider"
+	PythonLSPBinary            = "pylsp"
+	GoLSPBinary                = "gopls"
+	GoDependencyProviderBinary = "golang-dependency-provider"
 )
 
 var Settings = &Config{}
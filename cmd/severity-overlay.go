@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"gopkg.in/yaml.v2"
+)
+
+// severityOverlay maps rule IDs to the category their violations are
+// reported with, set with --severity-overlay
+type severityOverlay struct {
+	Rules map[string]outputv1.Category `yaml:"rules"`
+}
+
+func loadSeverityOverlay(path string) (map[string]outputv1.Category, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read severity overlay %s: %w", path, err)
+	}
+	overlay := severityOverlay{}
+	if err := yaml.UnmarshalStrict(content, &overlay); err != nil {
+		return nil, fmt.Errorf("failed to parse severity overlay %s: %w", path, err)
+	}
+	for ruleID, category := range overlay.Rules {
+		if _, ok := categorySeverity[category]; !ok {
+			return nil, fmt.Errorf("invalid category %q for rule %s in severity overlay %s, must be one of 'mandatory', 'optional' or 'potential'",
+				category, ruleID, path)
+		}
+	}
+	return overlay.Rules, nil
+}
+
+func (a *analyzeCommand) validateSeverityOverlay() error {
+	if a.severityOverlay == "" {
+		return nil
+	}
+	overrides, err := loadSeverityOverlay(a.severityOverlay)
+	if err != nil {
+		return err
+	}
+	a.severityOverrides = overrides
+	return nil
+}
+
+// applySeverityOverlay rewrites the category of the violations of the rules
+// in the --severity-overlay, before the output is written and --fail-on is
+// checked. Unlisted rules keep their category.
+func (a *analyzeCommand) applySeverityOverlay(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if len(a.severityOverrides) == 0 {
+		return rulesets
+	}
+	for i := range rulesets {
+		for ruleID, violation := range rulesets[i].Violations {
+			category, ok := a.severityOverrides[ruleID]
+			if !ok {
+				continue
+			}
+			violation.Category = &category
+			rulesets[i].Violations[ruleID] = violation
+		}
+	}
+	return rulesets
+}
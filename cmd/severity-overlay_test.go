@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSeverityOverlay(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]outputv1.Category
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			content: "rules:\n  jakarta-00010: mandatory\n  eap7-00001: potential\n",
+			want: map[string]outputv1.Category{
+				"jakarta-00010": outputv1.Mandatory,
+				"eap7-00001":    outputv1.Potential,
+			},
+		},
+		{
+			name:    "invalid category",
+			content: "rules:\n  jakarta-00010: critical\n",
+			wantErr: `invalid category "critical" for rule jakarta-00010`,
+		},
+		{
+			name:    "unknown field",
+			content: "rulez:\n  jakarta-00010: mandatory\n",
+			wantErr: "failed to parse severity overlay",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "overlay.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+			got, err := loadSeverityOverlay(path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplySeverityOverlay(t *testing.T) {
+	optional := outputv1.Optional
+	rulesets := []outputv1.RuleSet{{
+		Name: "ruleset",
+		Violations: map[string]outputv1.Violation{
+			"rule-1": {Category: &optional, Incidents: []outputv1.Incident{{URI: "file:///app/A.java"}}},
+			"rule-2": {Category: &optional, Incidents: []outputv1.Incident{{URI: "file:///app/B.java"}}},
+			"rule-3": {Incidents: []outputv1.Incident{{URI: "file:///app/C.java"}}},
+		},
+	}}
+	a := &analyzeCommand{severityOverrides: map[string]outputv1.Category{
+		"rule-1": outputv1.Mandatory,
+		"rule-3": outputv1.Mandatory,
+	}}
+
+	require.NoError(t, checkFailOn(rulesets, "mandatory"))
+	got := a.applySeverityOverlay(rulesets)
+
+	assert.Equal(t, outputv1.Mandatory, *got[0].Violations["rule-1"].Category)
+	assert.Equal(t, outputv1.Optional, *got[0].Violations["rule-2"].Category)
+	assert.Equal(t, outputv1.Mandatory, *got[0].Violations["rule-3"].Category)
+	assert.Equal(t, outputv1.Optional, optional, "shared category pointers are not modified")
+
+	err := checkFailOn(got, "mandatory")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "found 2 incidents")
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
+)
+
+var errNotGitWorkTree = errors.New("not a git working tree")
+
+// isGitWorkTree reports whether dir is inside a git working tree
+func isGitWorkTree(ctx context.Context, dir string) bool {
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "--is-inside-work-tree").Output()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// gitChangedFiles returns the files under dir changed since ref, relative to
+// dir. Deleted files are left out as there is nothing to analyze.
+func gitChangedFiles(ctx context.Context, dir string, ref string) ([]string, error) {
+	if !isGitWorkTree(ctx, dir) {
+		return nil, fmt.Errorf("%s: %w", dir, errNotGitWorkTree)
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "diff", "--name-only", "--relative", "--diff-filter=d", ref, "--")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since %s: %w: %s", ref, err, strings.TrimSpace(stderr.String()))
+	}
+	files := []string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, filepath.FromSlash(line))
+		}
+	}
+	return files, nil
+}
+
+// resolveSince limits the analysis to the files changed since --since when
+// the input is a git working tree, otherwise all files are analyzed
+func (a *analyzeCommand) resolveSince(ctx context.Context) error {
+	if a.since == "" {
+		return nil
+	}
+	if a.isFileInput {
+		a.log.Info("WARNING: --since only applies to source directories, analyzing the whole binary", "input", a.input)
+		return nil
+	}
+	files, err := gitChangedFiles(ctx, a.input, a.since)
+	if err != nil {
+		if errors.Is(err, errNotGitWorkTree) {
+			a.log.Info("WARNING: input is not a git working tree, ignoring --since and analyzing all files", "input", a.input)
+			return nil
+		}
+		return err
+	}
+	a.log.Info("limiting analysis to files changed since ref", "ref", a.since, "files", len(files))
+	a.sinceFiles = files
+	return nil
+}
+
+// sinceIncludedPaths returns the files changed since --since as the
+// includedPaths provider config value, relative to the input location
+func (a *analyzeCommand) sinceIncludedPaths() []interface{} {
+	included := []interface{}{}
+	for _, file := range a.sinceFiles {
+		included = append(included, filepath.ToSlash(file))
+	}
+	return included
+}
+
+// filterIncidentsSince drops incidents outside the files changed since
+// --since, e.g. from dependency rules, which the providers don't restrict
+func (a *analyzeCommand) filterIncidentsSince(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if a.sinceFiles == nil {
+		return rulesets
+	}
+	changed := map[string]bool{}
+	for _, file := range a.sinceFiles {
+		changed[filepath.ToSlash(file)] = true
+	}
+	roots := a.incidentRoots()
+	return filterIncidents(rulesets, func(incidentURI uri.URI) bool {
+		if !strings.HasPrefix(string(incidentURI), "file:") {
+			return true
+		}
+		incidentPath := incidentURI.Filename()
+		for _, root := range roots {
+			rel, err := filepath.Rel(root, incidentPath)
+			if err == nil && changed[filepath.ToSlash(rel)] {
+				return true
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+)
+
+func initGitRepo(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+}
+
+func TestGitChangedFiles(t *testing.T) {
+	repo := t.TempDir()
+	initGitRepo(t, repo, map[string]string{
+		"app/src/Changed.java":   "class Changed {}",
+		"app/src/Unchanged.java": "class Unchanged {}",
+		"app/Deleted.java":       "class Deleted {}",
+		"other/Other.java":       "class Other {}",
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "app", "src", "Changed.java"), []byte("class Changed { int a; }"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "other", "Other.java"), []byte("class Other { int a; }"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(repo, "app", "Deleted.java")))
+
+	files, err := gitChangedFiles(context.Background(), filepath.Join(repo, "app"), "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("src", "Changed.java")}, files)
+
+	_, err = gitChangedFiles(context.Background(), repo, "no-such-ref")
+	assert.Error(t, err)
+
+	_, err = gitChangedFiles(context.Background(), t.TempDir(), "HEAD")
+	assert.ErrorIs(t, err, errNotGitWorkTree)
+}
+
+func TestResolveSinceNotGitRepo(t *testing.T) {
+	a := &analyzeCommand{
+		input: t.TempDir(),
+		since: "origin/main",
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	require.NoError(t, a.resolveSince(context.Background()))
+	assert.Nil(t, a.sinceFiles)
+	assert.Empty(t, a.sinceIncludedPaths())
+}
+
+func TestFilterIncidentsSince(t *testing.T) {
+	input := t.TempDir()
+	a := &analyzeCommand{
+		input:      input,
+		sinceFiles: []string{filepath.Join("src", "Changed.java")},
+		AnalyzeCommandContext: AnalyzeCommandContext{
+			log: logr.Discard(),
+		},
+	}
+	assert.Equal(t, []interface{}{"src/Changed.java"}, a.sinceIncludedPaths())
+
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "test",
+			Violations: map[string]outputv1.Violation{
+				"rule": {
+					Incidents: []outputv1.Incident{
+						{URI: uri.File(filepath.Join(input, "src", "Changed.java"))},
+						{URI: uri.File(filepath.Join(input, "src", "Unchanged.java"))},
+					},
+				},
+				"dependency": {
+					Incidents: []outputv1.Incident{{URI: uri.File(filepath.Join(input, "pom.xml"))}},
+				},
+			},
+		},
+	}
+	got := a.filterIncidentsSince(rulesets)
+	require.Contains(t, got[0].Violations, "rule")
+	assert.Len(t, got[0].Violations["rule"].Incidents, 1)
+	assert.NotContains(t, got[0].Violations, "dependency")
+}
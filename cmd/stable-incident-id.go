@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"strings"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// stableIncidentIDVariable is the incident variable holding the id written
+// with --stable-incident-ids
+const stableIncidentIDVariable = "stableIncidentID"
+
+// stableIDContextLines are the code snippet lines around the incident line
+// hashed into its stable id, edits further away don't change it
+const stableIDContextLines = 2
+
+// codeSnipLine matches a code snippet line, the line number is followed by
+// two spaces
+var codeSnipLine = regexp.MustCompile(`^\s*(\d+)  ?(.*)$`)
+
+// stableIncidentID identifies an incident by rule, file relative to the input
+// and the code around the incident line, so it survives code moving within
+// the file. Incidents without a code snippet fall back to their fingerprint.
+func stableIncidentID(ruleID string, file string, incident outputv1.Incident) string {
+	code := incidentCode(incident)
+	if code == "" {
+		return incidentFingerprint(ruleID, file, incident.Message)
+	}
+	sum := sha256.Sum256([]byte(ruleID + "\x00" + file + "\x00" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// incidentCode returns the code snippet lines around the incident line
+// without their line numbers and indentation
+func incidentCode(incident outputv1.Incident) string {
+	lines := []string{}
+	for _, snipLine := range strings.Split(incident.CodeSnip, "\n") {
+		match := codeSnipLine.FindStringSubmatch(snipLine)
+		if match == nil {
+			continue
+		}
+		if incident.LineNumber != nil {
+			lineNumber, err := strconv.Atoi(match[1])
+			if err != nil || lineNumber < *incident.LineNumber-stableIDContextLines || lineNumber > *incident.LineNumber+stableIDContextLines {
+				continue
+			}
+		}
+		lines = append(lines, strings.Join(strings.Fields(match[2]), " "))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// incidentStableID returns the id stored with --stable-incident-ids, empty
+// for outputs written without it
+func incidentStableID(incident outputv1.Incident) string {
+	id, _ := incident.Variables[stableIncidentIDVariable].(string)
+	return id
+}
+
+// addStableIncidentIDs stores the stable id of every incident in its
+// variables with --stable-incident-ids
+func (a *analyzeCommand) addStableIncidentIDs(rulesets []outputv1.RuleSet) []outputv1.RuleSet {
+	if !a.stableIncidentIDs {
+		return rulesets
+	}
+	for i := range rulesets {
+		for ruleID, violation := range rulesets[i].Violations {
+			for j := range violation.Incidents {
+				incident := &violation.Incidents[j]
+				if incident.Variables == nil {
+					incident.Variables = map[string]interface{}{}
+				}
+				incident.Variables[stableIncidentIDVariable] = stableIncidentID(ruleID, a.incidentFile(*incident), *incident)
+			}
+		}
+	}
+	return rulesets
+}
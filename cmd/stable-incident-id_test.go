@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStableIncidentID(t *testing.T) {
+	incident := func(line int, codeSnip string) outputv1.Incident {
+		return outputv1.Incident{URI: "file:///app/A.java", Message: "use jakarta", LineNumber: &line, CodeSnip: codeSnip}
+	}
+	original := incident(3, " 1  package app;\n 2  \n 3  import javax.ejb.Stateless;\n 4  \n 5  @Stateless\n 6  public class A {\n 7  }")
+	reindented := incident(3, " 1  package app;\n 2  \n 3      import  javax.ejb.Stateless;\n 4  \n 5  @Stateless\n 6  public class A {\n 7  }")
+	farEdit := incident(3, " 1  package app;\n 2  \n 3  import javax.ejb.Stateless;\n 4  \n 5  @Stateless\n 6  public class B {\n 7  }")
+	changed := incident(3, " 1  package app;\n 2  \n 3  import javax.ejb.Singleton;\n 4  \n 5  @Stateless\n 6  public class A {\n 7  }")
+
+	id := stableIncidentID("rule-a", "A.java", original)
+	assert.Equal(t, id, stableIncidentID("rule-a", "A.java", reindented))
+	assert.Equal(t, id, stableIncidentID("rule-a", "A.java", farEdit))
+	assert.NotEqual(t, id, stableIncidentID("rule-a", "A.java", changed))
+	assert.NotEqual(t, id, stableIncidentID("rule-b", "A.java", original))
+	assert.NotEqual(t, id, stableIncidentID("rule-a", "B.java", original))
+
+	noSnip := outputv1.Incident{URI: "file:///app/pom.xml", Message: "update dependency"}
+	assert.Equal(t, incidentFingerprint("rule-a", "pom.xml", "update dependency"), stableIncidentID("rule-a", "pom.xml", noSnip))
+}
+
+func TestStableIncidentIDMovedCode(t *testing.T) {
+	before := outputv1.Incident{LineNumber: intPtr(3), CodeSnip: " 2  \n 3  import javax.ejb.Stateless;\n 4  \n 5  @Stateless"}
+	after := outputv1.Incident{LineNumber: intPtr(30), CodeSnip: "29  \n30  import javax.ejb.Stateless;\n31  \n32  @Stateless"}
+	assert.Equal(t, stableIncidentID("rule-a", "A.java", before), stableIncidentID("rule-a", "A.java", after))
+}
+
+func TestAddStableIncidentIDs(t *testing.T) {
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "ruleset",
+			Violations: map[string]outputv1.Violation{
+				"rule-a": {Incidents: []outputv1.Incident{{URI: "file:///app/A.java", LineNumber: intPtr(1), CodeSnip: "1  import javax.ejb.Stateless;"}}},
+			},
+		},
+	}
+	a := &analyzeCommand{input: "/app"}
+	assert.Empty(t, incidentStableID(a.addStableIncidentIDs(rulesets)[0].Violations["rule-a"].Incidents[0]))
+
+	a.stableIncidentIDs = true
+	incident := a.addStableIncidentIDs(rulesets)[0].Violations["rule-a"].Incidents[0]
+	assert.Equal(t, stableIncidentID("rule-a", "A.java", incident), incidentStableID(incident))
+}
+
+func TestDiffIncidentsStableIDs(t *testing.T) {
+	oldIncidents := []diffIncident{{RuleID: "rule-a", File: "A.java", Line: 3, Fingerprint: "message-a", StableID: "code-a"}}
+	newIncidents := []diffIncident{{RuleID: "rule-a", File: "A.java", Line: 30, Fingerprint: "message-b", StableID: "code-a"}}
+	diff := diffIncidents(oldIncidents, newIncidents)
+	assert.Equal(t, diffSummary{Unchanged: 1}, diff.Summary)
+
+	// outputs written without --stable-incident-ids are matched by fingerprint
+	newIncidents[0].StableID = ""
+	diff = diffIncidents(oldIncidents, newIncidents)
+	assert.Equal(t, diffSummary{Added: 1, Removed: 1}, diff.Summary)
+}
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"html"
+	"io"
 	"os"
+	"path/filepath"
+	"regexp"
 	"text/template"
 
 	"github.com/go-logr/logr"
@@ -54,7 +59,7 @@ func validateFlags(analysisOutputPaths []string, appNames []string, depsOutputs
 // loadApplications loads applications from provider config
 func loadApplications(apps []*Application) error {
 	for _, app := range apps {
-		analysisReport, err := os.ReadFile(app.analysisPath)
+		analysisReport, err := readOutputFile(app.analysisPath)
 		if err != nil {
 			return err
 		}
@@ -63,7 +68,7 @@ func loadApplications(apps []*Application) error {
 			return err
 		}
 		if app.depsPath != "" {
-			depsReport, err := os.ReadFile(app.depsPath)
+			depsReport, err := readOutputFile(app.depsPath)
 			if err != nil {
 				return err
 			}
@@ -98,7 +103,142 @@ func loadApplications(apps []*Application) error {
 	return nil
 }
 
-func generateJSBundle(apps []*Application, outputPath string, log logr.Logger) error {
+// staticReportInfo is the heading of the static report, set with
+// --report-title and --report-description, and its --report-theme. The
+// report UI doesn't render the description yet, it is only kept in output.js.
+type staticReportInfo struct {
+	Title       string
+	Description string
+	Theme       string
+}
+
+var staticReportInfoTemplate = template.Must(template.New("").Parse(`
+{{- if .Title}}
+window["reportTitle"] = {{.Title}}
+{{- end}}
+{{- if .Description}}
+window["reportDescription"] = {{.Description}}
+{{- end}}
+{{- if .Theme}}
+window["reportTheme"] = {{.Theme}}
+{{- end}}
+`))
+
+// writeStaticReportInfo writes the report heading and theme as JS globals
+func writeStaticReportInfo(w io.Writer, info staticReportInfo) error {
+	title, err := jsString(info.Title)
+	if err != nil {
+		return err
+	}
+	description, err := jsString(info.Description)
+	if err != nil {
+		return err
+	}
+	theme, err := jsString(info.Theme)
+	if err != nil {
+		return err
+	}
+	return staticReportInfoTemplate.Execute(w, staticReportInfo{
+		Title:       title,
+		Description: description,
+		Theme:       theme,
+	})
+}
+
+// jsString quotes s as a JS string literal, an empty s stays empty
+func jsString(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	encoded, err := json.Marshal(s)
+	return string(encoded), err
+}
+
+var htmlTitleRegex = regexp.MustCompile(`(?s)<title>.*?</title>`)
+
+// setStaticReportTitle sets the browser tab title of the static report
+func setStaticReportTitle(reportDir string, title string) error {
+	if title == "" {
+		return nil
+	}
+	indexPath := filepath.Join(reportDir, "index.html")
+	content, err := os.ReadFile(indexPath)
+	if err != nil {
+		return err
+	}
+	titleTag := []byte("<title>" + html.EscapeString(title) + "</title>")
+	return os.WriteFile(indexPath, htmlTitleRegex.ReplaceAllLiteral(content, titleTag), 0644)
+}
+
+const (
+	reportThemeLight = "light"
+	reportThemeDark  = "dark"
+	reportThemeAuto  = "auto"
+)
+
+// validateReportTheme checks --report-theme, empty is the light theme
+func (a *analyzeCommand) validateReportTheme() error {
+	switch a.reportTheme {
+	case "":
+		a.reportTheme = reportThemeLight
+	case reportThemeLight, reportThemeDark, reportThemeAuto:
+	default:
+		return fmt.Errorf("report-theme must be one of 'light', 'dark' or 'auto'")
+	}
+	return nil
+}
+
+var reportThemeScriptRegex = regexp.MustCompile(`(?s)<script id="report-theme">.*?</script>`)
+
+// reportThemeScript switches the static report to the patternfly dark theme
+// before it renders, auto follows the browser's prefers-color-scheme
+var reportThemeScript = template.Must(template.New("").Parse(`<script id="report-theme">
+(function () {
+  var theme = {{.}};
+  var media = window.matchMedia && window.matchMedia("(prefers-color-scheme: dark)");
+  function apply() {
+    var dark = theme === "dark" || (theme === "auto" && media && media.matches);
+    var root = document.documentElement;
+    ["pf-theme-dark", "pf-v5-theme-dark", "pf-v6-theme-dark"].forEach(function (c) {
+      root.classList.toggle(c, dark);
+    });
+    root.style.colorScheme = dark ? "dark" : "light";
+  }
+  apply();
+  if (theme === "auto" && media && media.addEventListener) {
+    media.addEventListener("change", apply);
+  }
+})();
+</script>`))
+
+// setStaticReportTheme adds the --report-theme script to the head of the
+// static report, the light theme is the report default and needs none
+func setStaticReportTheme(reportDir string, theme string) error {
+	if theme == "" || theme == reportThemeLight {
+		return nil
+	}
+	indexPath := filepath.Join(reportDir, "index.html")
+	content, err := os.ReadFile(indexPath)
+	if err != nil {
+		return err
+	}
+	quotedTheme, err := jsString(theme)
+	if err != nil {
+		return err
+	}
+	script := &bytes.Buffer{}
+	if err := reportThemeScript.Execute(script, quotedTheme); err != nil {
+		return err
+	}
+	if reportThemeScriptRegex.Match(content) {
+		content = reportThemeScriptRegex.ReplaceAllLiteral(content, script.Bytes())
+	} else {
+		content = bytes.Replace(content, []byte("</head>"), append(script.Bytes(), []byte("</head>")...), 1)
+	}
+	return os.WriteFile(indexPath, content, 0644)
+}
+
+func generateJSBundle(apps []*Application, info staticReportInfo, outputPath string, log logr.Logger) error {
 	output, err := json.Marshal(apps)
 	if err != nil {
 		log.Error(err, "failed to marshal applications")
@@ -119,5 +259,8 @@ window["apps"] = {{.Apps}}
 	}{
 		Apps: string(output),
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	return writeStaticReportInfo(file, info)
 }
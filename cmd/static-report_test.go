@@ -244,7 +244,7 @@ func TestGenerateJSBundle(t *testing.T) {
 		},
 	}
 
-	err = generateJSBundle(apps, outputFile, logger)
+	err = generateJSBundle(apps, staticReportInfo{}, outputFile, logger)
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -276,7 +276,7 @@ func TestGenerateJSBundle_InvalidPath(t *testing.T) {
 	apps := []*Application{}
 	invalidPath := "/nonexistent/dir/output.js"
 
-	err := generateJSBundle(apps, invalidPath, logger)
+	err := generateJSBundle(apps, staticReportInfo{}, invalidPath, logger)
 	if err == nil {
 		t.Error("Expected error for invalid output path")
 	}
@@ -304,3 +304,106 @@ func TestApplication_Structure(t *testing.T) {
 	}
 }
 
+
+func TestGenerateJSBundle_ReportInfo(t *testing.T) {
+	logger := logrusr.New(logrus.New())
+	outputFile := filepath.Join(t.TempDir(), "output.js")
+
+	info := staticReportInfo{Title: `Billing "prod"`, Description: "Q3 migration"}
+	if err := generateJSBundle([]*Application{}, info, outputFile, logger); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	for _, want := range []string{
+		`window["apps"] = []`,
+		`window["reportTitle"] = "Billing \"prod\""`,
+		`window["reportDescription"] = "Q3 migration"`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected output to contain %s, got:\n%s", want, content)
+		}
+	}
+
+	if err := generateJSBundle([]*Application{}, staticReportInfo{}, outputFile, logger); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	content, _ = os.ReadFile(outputFile)
+	if strings.Contains(string(content), "reportTitle") || strings.Contains(string(content), "reportDescription") {
+		t.Errorf("Expected no report info without title and description, got:\n%s", content)
+	}
+}
+
+func TestCustomizeStaticReport(t *testing.T) {
+	reportDir := t.TempDir()
+	indexPath := filepath.Join(reportDir, "index.html")
+	outputJSPath := filepath.Join(reportDir, "output.js")
+	if err := os.WriteFile(indexPath, []byte("<html><head><title>Konveyor</title></head></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(outputJSPath, []byte("window[\"apps\"] = []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &analyzeCommand{input: "/apps/billing", reportTitle: "Billing <prod>", reportDescription: "Q3"}
+	if got := a.reportAppName(); got != "Billing <prod>" {
+		t.Errorf("reportAppName() = %s, want the report title", got)
+	}
+	if err := a.customizeStaticReport(reportDir, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	index, _ := os.ReadFile(indexPath)
+	if !strings.Contains(string(index), "<title>Billing &lt;prod&gt;</title>") {
+		t.Errorf("Expected index.html title to be set, got %s", index)
+	}
+	outputJS, _ := os.ReadFile(outputJSPath)
+	if !strings.Contains(string(outputJS), `window["apps"] = []`) || !strings.Contains(string(outputJS), `window["reportDescription"] = "Q3"`) {
+		t.Errorf("Expected report info appended to output.js, got %s", outputJS)
+	}
+
+	a = &analyzeCommand{input: "/apps/billing"}
+	if got := a.reportAppName(); got != "billing" {
+		t.Errorf("reportAppName() = %s, want billing", got)
+	}
+}
+
+func TestCustomizeStaticReportTheme(t *testing.T) {
+	reportDir := t.TempDir()
+	indexPath := filepath.Join(reportDir, "index.html")
+	outputJSPath := filepath.Join(reportDir, "output.js")
+	if err := os.WriteFile(indexPath, []byte("<html><head><title>Konveyor</title></head></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(outputJSPath, []byte("window[\"apps\"] = []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &analyzeCommand{reportTheme: reportThemeLight}
+	if err := a.customizeStaticReport(reportDir, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	index, _ := os.ReadFile(indexPath)
+	if strings.Contains(string(index), "report-theme") {
+		t.Errorf("Expected no theme script for the light theme, got %s", index)
+	}
+
+	for _, theme := range []string{reportThemeDark, reportThemeAuto} {
+		a = &analyzeCommand{reportTheme: theme}
+		if err := a.customizeStaticReport(reportDir, true); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		index, _ = os.ReadFile(indexPath)
+		if strings.Count(string(index), `<script id="report-theme">`) != 1 {
+			t.Errorf("Expected a single theme script in index.html, got %s", index)
+		}
+		if !strings.Contains(string(index), `var theme = "`+theme+`";`) || !strings.HasSuffix(string(index), "</script></head></html>") {
+			t.Errorf("Expected the %s theme script at the end of the head, got %s", theme, index)
+		}
+	}
+	outputJS, _ := os.ReadFile(outputJSPath)
+	if !strings.Contains(string(outputJS), `window["reportTheme"] = "auto"`) {
+		t.Errorf("Expected report theme appended to output.js, got %s", outputJS)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// stdinRulesPath is the --rules value to read rules from stdin
+const stdinRulesPath = "-"
+
+// resolveStdinRules reads rules from in when --rules - is given and replaces
+// it with a temp rules file, so both the containerless and the container
+// flows handle it as any other rules file
+func (a *analyzeCommand) resolveStdinRules(in io.Reader) error {
+	stdinIdx := -1
+	for i, rulePath := range a.rules {
+		if rulePath != stdinRulesPath {
+			continue
+		}
+		if stdinIdx != -1 {
+			return fmt.Errorf("--rules %s can only be given once", stdinRulesPath)
+		}
+		stdinIdx = i
+	}
+	if stdinIdx == -1 {
+		return nil
+	}
+
+	content, err := io.ReadAll(in)
+	if err != nil {
+		return fmt.Errorf("failed to read rules from stdin: %w", err)
+	}
+	if err := validateStdinRules(content); err != nil {
+		return err
+	}
+
+	tempDir, err := os.MkdirTemp("", "analyze-stdin-rules-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir for stdin rules: %w", err)
+	}
+	a.tempDirs = append(a.tempDirs, tempDir)
+	rulesFile := filepath.Join(tempDir, "stdin-rules.yaml")
+	err = os.WriteFile(rulesFile, content, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write stdin rules: %w", err)
+	}
+	a.log.V(1).Info("wrote rules from stdin", "file", rulesFile)
+	a.rules[stdinIdx] = rulesFile
+	return nil
+}
+
+// validateStdinRules checks that content is a non-empty list of rules
+func validateStdinRules(content []byte) error {
+	if strings.TrimSpace(string(content)) == "" {
+		return fmt.Errorf("no rules read from stdin")
+	}
+	rules := []map[string]interface{}{}
+	err := yaml.Unmarshal(content, &rules)
+	if err != nil {
+		return fmt.Errorf("failed to parse rules from stdin: %w", err)
+	}
+	if len(rules) == 0 {
+		return fmt.Errorf("no rules read from stdin")
+	}
+	for i, rule := range rules {
+		if _, ok := rule["ruleID"]; !ok {
+			return fmt.Errorf("rule %d read from stdin is missing a ruleID", i)
+		}
+		if _, ok := rule["when"]; !ok {
+			return fmt.Errorf("rule %v read from stdin is missing a when condition", rule["ruleID"])
+		}
+	}
+	return nil
+}
+
+func stdinReader(cmd *cobra.Command) io.Reader {
+	if cmd != nil {
+		return cmd.InOrStdin()
+	}
+	return os.Stdin
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stdinTestRule = `- ruleID: stdin-rule-00001
+  message: found a pom
+  when:
+    builtin.file:
+      pattern: pom.xml
+`
+
+func TestResolveStdinRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []string
+		stdin   string
+		wantErr string
+	}{
+		{
+			name:  "no stdin rules",
+			rules: []string{"/test/rules"},
+		},
+		{
+			name:  "rules from stdin",
+			rules: []string{"/test/rules", "-"},
+			stdin: stdinTestRule,
+		},
+		{
+			name:    "empty stdin",
+			rules:   []string{"-"},
+			stdin:   "  \n",
+			wantErr: "no rules read from stdin",
+		},
+		{
+			name:    "invalid yaml",
+			rules:   []string{"-"},
+			stdin:   "- ruleID: [",
+			wantErr: "failed to parse rules from stdin",
+		},
+		{
+			name:    "missing condition",
+			rules:   []string{"-"},
+			stdin:   "- ruleID: stdin-rule-00001\n  message: no condition\n",
+			wantErr: "missing a when condition",
+		},
+		{
+			name:    "stdin given twice",
+			rules:   []string{"-", "-"},
+			stdin:   stdinTestRule,
+			wantErr: "can only be given once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzeCommand{
+				rules: append([]string{}, tt.rules...),
+				AnalyzeCommandContext: AnalyzeCommandContext{
+					log: logr.Discard(),
+				},
+			}
+			t.Cleanup(func() {
+				for _, dir := range a.tempDirs {
+					os.RemoveAll(dir)
+				}
+			})
+			err := a.resolveStdinRules(strings.NewReader(tt.stdin))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotContains(t, a.rules, stdinRulesPath)
+			assert.Len(t, a.rules, len(tt.rules))
+			if tt.stdin == "" {
+				assert.Empty(t, a.tempDirs)
+				return
+			}
+			require.Len(t, a.tempDirs, 1)
+			content, err := os.ReadFile(a.rules[len(a.rules)-1])
+			require.NoError(t, err)
+			assert.Equal(t, tt.stdin, string(content))
+		})
+	}
+}
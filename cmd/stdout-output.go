@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// stdoutOutput is the --output value streaming the results to stdout
+const stdoutOutput = "-"
+
+// streamedOutput is the JSON document written to stdout with --output -,
+// dependencies are inlined since no dependencies file is kept
+type streamedOutput struct {
+	RuleSets     []outputv1.RuleSet      `json:"rulesets"`
+	Dependencies []outputv1.DepsFlatItem `json:"dependencies,omitempty"`
+}
+
+// setupStdoutOutput prepares streaming the results to stdout when --output
+// is '-'. The analysis still needs a working dir for its logs and provider
+// settings, a temporary one is used and removed afterwards. Logs are moved
+// to stderr so they don't corrupt the piped output.
+func (a *analyzeCommand) setupStdoutOutput() error {
+	if a.output != stdoutOutput {
+		return nil
+	}
+	switch {
+	case a.bulk:
+		return fmt.Errorf("--output %s cannot be used with --bulk", stdoutOutput)
+	case a.verboseProvider:
+		return fmt.Errorf("--output %s cannot be used with --verbose-provider", stdoutOutput)
+	case a.githubAnnotations:
+		// the annotations would be mixed with the results on stdout
+		return fmt.Errorf("--output %s cannot be used with --github-annotations", stdoutOutput)
+	case a.junitOutput, a.csvOutput:
+		return fmt.Errorf("--output %s cannot be used with --junit-output or --csv-output", stdoutOutput)
+	case a.outputArchive != "":
+		return fmt.Errorf("--output %s cannot be used with --output-archive", stdoutOutput)
+	case a.effortReport:
+		// effort.json would be removed with the temporary output dir
+		return fmt.Errorf("--output %s cannot be used with --effort-report", stdoutOutput)
+	case a.ruleStats:
+		return fmt.Errorf("--output %s cannot be used with --rule-stats", stdoutOutput)
+	}
+	dir, err := os.MkdirTemp("", "analyze-output-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary output dir: %w", err)
+	}
+	a.tempDirs = append(a.tempDirs, dir)
+	a.output = dir
+	a.outputToStdout = true
+	// the temporary dir already exists
+	a.overwrite = true
+	a.skipStaticReport = true
+	a.noProgress = true
+	a.log = quietConsoleLogger(logrusLog)
+	return nil
+}
+
+// writeStdoutOutput writes the rulesets and the flat dependencies as a single
+// JSON document
+func (a *analyzeCommand) writeStdoutOutput(out io.Writer, rulesets []outputv1.RuleSet) error {
+	data, err := json.MarshalIndent(streamedOutput{
+		RuleSets:     rulesets,
+		Dependencies: a.depsFlat,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal analysis output: %w", err)
+	}
+	if _, err := fmt.Fprintln(out, string(data)); err != nil {
+		return fmt.Errorf("failed to write analysis output to stdout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupStdoutOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     analyzeCommand
+		wantErr string
+	}{
+		{
+			name: "output dir",
+			cmd:  analyzeCommand{output: "out"},
+		},
+		{
+			name: "stdout",
+			cmd:  analyzeCommand{output: stdoutOutput},
+		},
+		{
+			name:    "with bulk",
+			cmd:     analyzeCommand{output: stdoutOutput, bulk: true},
+			wantErr: "--bulk",
+		},
+		{
+			name:    "with verbose provider",
+			cmd:     analyzeCommand{output: stdoutOutput, verboseProvider: true},
+			wantErr: "--verbose-provider",
+		},
+		{
+			name:    "with github annotations",
+			cmd:     analyzeCommand{output: stdoutOutput, githubAnnotations: true},
+			wantErr: "--github-annotations",
+		},
+		{
+			name:    "with csv output",
+			cmd:     analyzeCommand{output: stdoutOutput, csvOutput: true},
+			wantErr: "--csv-output",
+		},
+		{
+			name:    "with output archive",
+			cmd:     analyzeCommand{output: stdoutOutput, outputArchive: "out.zip"},
+			wantErr: "--output-archive",
+		},
+		{
+			name:    "with effort report",
+			cmd:     analyzeCommand{output: stdoutOutput, effortReport: true},
+			wantErr: "--effort-report",
+		},
+		{
+			name:    "with rule stats",
+			cmd:     analyzeCommand{output: stdoutOutput, ruleStats: true},
+			wantErr: "--rule-stats",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.cmd
+			a.log = logr.Discard()
+			err := a.setupStdoutOutput()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.cmd.output != stdoutOutput {
+				assert.False(t, a.outputToStdout)
+				assert.Equal(t, tt.cmd.output, a.output)
+				return
+			}
+			t.Cleanup(func() { os.RemoveAll(a.output) })
+			assert.True(t, a.outputToStdout)
+			assert.True(t, a.skipStaticReport)
+			assert.DirExists(t, a.output)
+			assert.Equal(t, []string{a.output}, a.tempDirs)
+		})
+	}
+}
+
+func TestWriteStdoutOutput(t *testing.T) {
+	a := &analyzeCommand{
+		depsFlat: []outputv1.DepsFlatItem{{Provider: "java", FileURI: "file:///app/pom.xml"}},
+	}
+	rulesets := []outputv1.RuleSet{{
+		Name: "ruleset",
+		Violations: map[string]outputv1.Violation{
+			"rule-1": {Incidents: []outputv1.Incident{{URI: "file:///app/Main.java"}}},
+		},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, a.writeStdoutOutput(&out, rulesets))
+
+	var got streamedOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	require.Len(t, got.RuleSets, 1)
+	assert.Equal(t, "ruleset", got.RuleSets[0].Name)
+	assert.Len(t, got.RuleSets[0].Violations["rule-1"].Incidents, 1)
+	require.Len(t, got.Dependencies, 1)
+	assert.Equal(t, "java", got.Dependencies[0].Provider)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"gopkg.in/yaml.v2"
+)
+
+// writeRulesetsJSON writes rulesets as an indented json array one ruleset at
+// a time, so only the largest ruleset is marshaled in memory. The output is
+// the same as json.MarshalIndent(rulesets, "", "\t").
+func writeRulesetsJSON(w io.Writer, rulesets []outputv1.RuleSet) error {
+	if rulesets == nil {
+		_, err := io.WriteString(w, "null")
+		return err
+	}
+	if len(rulesets) == 0 {
+		_, err := io.WriteString(w, "[]")
+		return err
+	}
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i := range rulesets {
+		data, err := json.MarshalIndent(&rulesets[i], "\t", "\t")
+		if err != nil {
+			return err
+		}
+		separator := ",\n\t"
+		if i == 0 {
+			separator = "\n\t"
+		}
+		if _, err := io.WriteString(w, separator); err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "\n]")
+	return err
+}
+
+// writeRulesetsYAML writes rulesets as a yaml sequence one ruleset at a time,
+// the output is the same as yaml.Marshal(rulesets)
+func writeRulesetsYAML(w io.Writer, rulesets []outputv1.RuleSet) error {
+	if len(rulesets) == 0 {
+		_, err := io.WriteString(w, "[]\n")
+		return err
+	}
+	for i := range rulesets {
+		data, err := yaml.Marshal([]outputv1.RuleSet{rulesets[i]})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeYAMLOutput streams the analysis results to output.yaml
+func (a *analyzeCommand) writeYAMLOutput(rulesets []outputv1.RuleSet) error {
+	return a.streamOutputFile("output.yaml", 0644, func(w io.Writer) error {
+		return writeRulesetsYAML(w, rulesets)
+	})
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"testing"
+	"time"
+
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.lsp.dev/uri"
+	"gopkg.in/yaml.v2"
+)
+
+// syntheticRulesets returns rulesets with the given number of incidents per
+// rule, as a large analysis produces
+func syntheticRulesets(rulesets, rules, incidents int) []outputv1.RuleSet {
+	mandatory := outputv1.Mandatory
+	effort := 3
+	result := []outputv1.RuleSet{}
+	for i := 0; i < rulesets; i++ {
+		ruleset := outputv1.RuleSet{
+			Name:        fmt.Sprintf("ruleset-%d", i),
+			Description: "synthetic ruleset <with> & html",
+			Violations:  map[string]outputv1.Violation{},
+		}
+		for j := 0; j < rules; j++ {
+			violation := outputv1.Violation{
+				Description: fmt.Sprintf("rule %d", j),
+				Category:    &mandatory,
+				Effort:      &effort,
+				Labels:      []string{"konveyor.io/target=quarkus"},
+			}
+			for k := 0; k < incidents; k++ {
+				violation.Incidents = append(violation.Incidents, outputv1.Incident{
+					URI:        uri.URI(fmt.Sprintf("file:///app/src/main/java/App%d.java", k)),
+					Message:    "replace the javax.ejb import with jakarta.ejb",
+					CodeSnip:   " 1  import javax.ejb.Stateless;\n 2  \n 3  @Stateless\n",
+					LineNumber: &k,
+				})
+			}
+			ruleset.Violations[fmt.Sprintf("rule-%d", j)] = violation
+		}
+		result = append(result, ruleset)
+	}
+	return result
+}
+
+func TestWriteRulesetsJSON(t *testing.T) {
+	for _, rulesets := range [][]outputv1.RuleSet{nil, {}, syntheticRulesets(1, 1, 1), syntheticRulesets(3, 2, 2)} {
+		expected, err := json.MarshalIndent(rulesets, "", "\t")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, writeRulesetsJSON(&buf, rulesets))
+		assert.Equal(t, string(expected), buf.String())
+	}
+}
+
+func TestWriteRulesetsYAML(t *testing.T) {
+	for _, rulesets := range [][]outputv1.RuleSet{nil, {}, syntheticRulesets(1, 1, 1), syntheticRulesets(3, 2, 2)} {
+		expected, err := yaml.Marshal(rulesets)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, writeRulesetsYAML(&buf, rulesets))
+		assert.Equal(t, string(expected), buf.String())
+	}
+}
+
+func TestWriteYAMLOutput(t *testing.T) {
+	rulesets := syntheticRulesets(2, 2, 2)
+	for _, compress := range []bool{false, true} {
+		a := &analyzeCommand{output: t.TempDir(), compressOutput: compress}
+		require.NoError(t, a.writeYAMLOutput(rulesets))
+		content, err := readOutputFile(a.outputFilePath("output.yaml"))
+		require.NoError(t, err)
+		written := []outputv1.RuleSet{}
+		require.NoError(t, yaml.Unmarshal(content, &written))
+		assert.Len(t, written, 2)
+	}
+	a := &analyzeCommand{output: filepath.Join(t.TempDir(), "missing")}
+	assert.Error(t, a.writeYAMLOutput(rulesets))
+	_, err := os.Stat(a.output)
+	assert.True(t, os.IsNotExist(err))
+}
+
+// benchmarkPeakHeap runs write b.N times and reports the peak heap in use
+// while writing, which the streaming writers keep to about one ruleset. Run
+// e.g. go test ./cmd -run XXX -bench Rulesets -benchmem
+func benchmarkPeakHeap(b *testing.B, write func() error) {
+	samples := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	runtime.GC()
+	metrics.Read(samples)
+	baseline := samples[0].Value.Uint64()
+	peak := uint64(0)
+	done := make(chan struct{})
+	sampled := make(chan struct{})
+	go func() {
+		defer close(sampled)
+		local := []metrics.Sample{{Name: samples[0].Name}}
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				metrics.Read(local)
+				if value := local[0].Value.Uint64(); value > peak {
+					peak = value
+				}
+			}
+		}
+	}()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := write(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	close(done)
+	<-sampled
+	if peak > baseline {
+		b.ReportMetric(float64(peak-baseline), "peak-heap-B")
+	}
+}
+
+func BenchmarkWriteRulesetsJSON(b *testing.B) {
+	rulesets := syntheticRulesets(50, 20, 100)
+	benchmarkPeakHeap(b, func() error {
+		return writeRulesetsJSON(io.Discard, rulesets)
+	})
+}
+
+func BenchmarkMarshalRulesetsJSON(b *testing.B) {
+	rulesets := syntheticRulesets(50, 20, 100)
+	benchmarkPeakHeap(b, func() error {
+		data, err := json.MarshalIndent(rulesets, "", "\t")
+		if err != nil {
+			return err
+		}
+		_, err = io.Discard.Write(data)
+		return err
+	})
+}
+
+func BenchmarkWriteRulesetsYAML(b *testing.B) {
+	rulesets := syntheticRulesets(20, 20, 50)
+	benchmarkPeakHeap(b, func() error {
+		return writeRulesetsYAML(io.Discard, rulesets)
+	})
+}
+
+func BenchmarkMarshalRulesetsYAML(b *testing.B) {
+	rulesets := syntheticRulesets(20, 20, 50)
+	benchmarkPeakHeap(b, func() error {
+		data, err := yaml.Marshal(rulesets)
+		if err != nil {
+			return err
+		}
+		_, err = io.Discard.Write(data)
+		return err
+	})
+}
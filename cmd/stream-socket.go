@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/engine"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// streamBufferSize is how many violations are queued for the consumers of
+// --stream-socket before new ones are dropped
+const streamBufferSize = 1024
+
+// streamWriteTimeout bounds a write to a consumer, a consumer that doesn't
+// read is disconnected
+var streamWriteTimeout = 5 * time.Second
+
+// streamedViolation is a JSON line written to --stream-socket when a rule
+// matches. Incidents are as found by the providers, output.yaml still holds
+// the final results after filtering.
+type streamedViolation struct {
+	RuleSet string `json:"ruleset"`
+	RuleID  string `json:"ruleID"`
+	outputv1.Violation
+}
+
+// incidentStream serves violations to the consumers connected to a Unix
+// socket. Violations found while no consumer is connected are dropped, the
+// rules never wait for a consumer.
+type incidentStream struct {
+	path     string
+	listener net.Listener
+	log      logr.Logger
+	lines    chan []byte
+	done     chan struct{}
+	mu       sync.Mutex
+	conns    []net.Conn
+	closed   bool
+	dropped  int
+	// redact holds the --redact patterns, streamed incidents are redacted
+	// like the written output
+	redact []*regexp.Regexp
+}
+
+// openIncidentStream listens on the --stream-socket path, a socket left by a
+// previous run is replaced
+func (a *analyzeCommand) openIncidentStream() (*incidentStream, error) {
+	if a.streamSocket == "" {
+		return nil, nil
+	}
+	if stat, err := os.Lstat(a.streamSocket); err == nil {
+		if stat.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("--stream-socket %s exists and is not a socket", a.streamSocket)
+		}
+		if err := os.Remove(a.streamSocket); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", a.streamSocket, err)
+		}
+	}
+	listener, err := net.Listen("unix", a.streamSocket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on --stream-socket %s: %w", a.streamSocket, err)
+	}
+	s := &incidentStream{
+		path:     a.streamSocket,
+		listener: listener,
+		log:      a.log,
+		lines:    make(chan []byte, streamBufferSize),
+		done:     make(chan struct{}),
+		redact:   a.redactRegexps,
+	}
+	go s.accept()
+	go s.write()
+	a.log.Info("streaming violations", "socket", a.streamSocket)
+	return s, nil
+}
+
+func (s *incidentStream) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				s.log.V(1).Error(err, "failed to accept stream consumer", "socket", s.path)
+			}
+			return
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		s.log.V(1).Info("stream consumer connected", "socket", s.path)
+	}
+}
+
+func (s *incidentStream) write() {
+	defer close(s.done)
+	for line := range s.lines {
+		// write without holding the lock so a slow consumer doesn't block
+		// the rules sending violations
+		s.mu.Lock()
+		conns := append([]net.Conn{}, s.conns...)
+		s.mu.Unlock()
+		failed := map[net.Conn]bool{}
+		for _, conn := range conns {
+			conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if _, err := conn.Write(line); err != nil {
+				s.log.V(1).Info("stream consumer disconnected", "socket", s.path, "error", err.Error())
+				conn.Close()
+				failed[conn] = true
+			}
+		}
+		if len(failed) == 0 {
+			continue
+		}
+		s.mu.Lock()
+		s.conns = slices.DeleteFunc(s.conns, func(conn net.Conn) bool { return failed[conn] })
+		s.mu.Unlock()
+	}
+}
+
+// send queues a violation for the connected consumers without blocking
+func (s *incidentStream) send(violation streamedViolation) {
+	line, err := json.Marshal(violation)
+	if err != nil {
+		s.log.V(1).Error(err, "failed to marshal streamed violation", "ruleID", violation.RuleID)
+		return
+	}
+	line = append(line, '\n')
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || len(s.conns) == 0 {
+		return
+	}
+	select {
+	case s.lines <- line:
+	default:
+		s.dropped++
+	}
+}
+
+// Close writes the queued violations, disconnects the consumers and removes
+// the socket
+func (s *incidentStream) Close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.closed = true
+	close(s.lines)
+	s.mu.Unlock()
+	<-s.done
+	if err := s.listener.Close(); err != nil {
+		s.log.V(1).Error(err, "failed to close stream socket", "socket", s.path)
+	}
+	s.mu.Lock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+	if s.dropped > 0 {
+		s.log.Info("WARNING: stream consumers were too slow, violations were not streamed", "socket", s.path, "dropped", s.dropped)
+	}
+	s.mu.Unlock()
+}
+
+// streamConditional sends the incidents of a rule to the stream when its
+// conditions match
+type streamConditional struct {
+	when    engine.Conditional
+	ruleSet string
+	rule    engine.Rule
+	stream  *incidentStream
+}
+
+func (c *streamConditional) Evaluate(ctx context.Context, log logr.Logger, condCtx engine.ConditionContext) (engine.ConditionResponse, error) {
+	response, err := c.when.Evaluate(ctx, log, condCtx)
+	if err != nil || !response.Matched {
+		return response, err
+	}
+	violation := streamedViolation{
+		RuleSet: c.ruleSet,
+		RuleID:  c.rule.RuleID,
+		Violation: outputv1.Violation{
+			Description: c.rule.Description,
+			Category:    c.rule.Category,
+			Labels:      c.rule.Labels,
+			Effort:      c.rule.Effort,
+			Incidents:   []outputv1.Incident{},
+		},
+	}
+	for _, incident := range response.Incidents {
+		violation.Incidents = append(violation.Incidents, outputv1.Incident{
+			URI:        incident.FileURI,
+			LineNumber: incident.LineNumber,
+			Variables:  incident.Variables,
+		})
+	}
+	if len(c.stream.redact) > 0 {
+		redactViolation(&violation.Violation, c.stream.redact)
+	}
+	c.stream.send(violation)
+	return response, err
+}
+
+// applyIncidentStream wraps the conditions of every rule that reports
+// violations to send them to the stream. It wraps the --rule-timeout
+// conditions so rules that time out aren't streamed.
+func (a *analyzeCommand) applyIncidentStream(ruleSets []engine.RuleSet) {
+	if a.stream == nil {
+		return
+	}
+	for i := range ruleSets {
+		for j, rule := range ruleSets[i].Rules {
+			if rule.When == nil || rule.Perform.Message.Text == nil {
+				continue
+			}
+			ruleSets[i].Rules[j].When = &streamConditional{
+				when:    rule.When,
+				ruleSet: ruleSets[i].Name,
+				rule:    rule,
+				stream:  a.stream,
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// ruleLabels returns the source or target labels of every default ruleset
+// and --rules path. The default rulesets are walked before they are
+// pre-filtered, like --list-targets does, so the valid technologies don't
+// depend on the requested ones.
+func (a *analyzeCommand) ruleLabels(label string) ([]string, error) {
+	labelsSlice := []string{}
+	paths := append([]string{}, a.rules...)
+	if a.defaultRulesetsDir != "" {
+		paths = append(paths, a.defaultRulesetsDir)
+	}
+	for _, path := range paths {
+		if err := filepath.WalkDir(path, util.WalkRuleSets(path, label, &labelsSlice)); err != nil {
+			return nil, err
+		}
+	}
+	return labelsSlice, nil
+}
+
+// checkStrictTargets fails with --strict-target when a --source or --target
+// is not on any rule, listing the ones that are, so a mistyped target
+// doesn't run a whole analysis for nothing
+func (a *analyzeCommand) checkStrictTargets() error {
+	if !a.strictTarget {
+		return nil
+	}
+	sourceLabels, err := a.ruleLabels(outputv1.SourceTechnologyLabel)
+	if err != nil {
+		return fmt.Errorf("failed to read rule labels: %w", err)
+	}
+	if err := checkTechnologies("source", a.sources, util.OptionsFromLabels(sourceLabels, outputv1.SourceTechnologyLabel)); err != nil {
+		return &ValidationError{Err: err}
+	}
+	targetLabels, err := a.ruleLabels(outputv1.TargetTechnologyLabel)
+	if err != nil {
+		return fmt.Errorf("failed to read rule labels: %w", err)
+	}
+	if err := checkTechnologies("target", a.targets, util.OptionsFromLabels(targetLabels, outputv1.TargetTechnologyLabel)); err != nil {
+		return &ValidationError{Err: err}
+	}
+	return nil
+}
+
+func checkTechnologies(kind string, requested []string, loaded []string) error {
+	for _, technology := range requested {
+		if slices.Contains(loaded, technology) {
+			continue
+		}
+		if len(loaded) == 0 {
+			return fmt.Errorf("%s %q matches no rule, no rule has a %s", kind, technology, kind)
+		}
+		return fmt.Errorf("%s %q matches no rule, valid %ss: %s", kind, technology, kind, strings.Join(loaded, ", "))
+	}
+	return nil
+}
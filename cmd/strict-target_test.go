@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStrictTargets(t *testing.T) {
+	rulesDir := t.TempDir()
+	files := map[string]string{
+		"eap/ruleset.yaml":     "name: eap\nlabels:\n- konveyor.io/source=eap7\n",
+		"eap/rules.yaml":       "- ruleID: eap-01\n  labels:\n  - konveyor.io/target=eap8+\n- ruleID: eap-02\n  labels:\n  - konveyor.io/target=quarkus\n",
+		"azure/ruleset.yaml":   "name: azure\n",
+		"azure/rules.yaml":     "- ruleID: azure-01\n  labels:\n  - konveyor.io/target=azure-appservice\n",
+		"custom/rules.yaml":    "- ruleID: custom-01\n  labels:\n  - konveyor.io/source=springboot\n",
+		"custom/ruleset.yaml":  "name: custom\n",
+		"default/ruleset.yaml": "name: default\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(rulesDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	defaultRulesets := filepath.Join(rulesDir, "default")
+	for _, name := range []string{"eap", "azure"} {
+		require.NoError(t, os.Rename(filepath.Join(rulesDir, name), filepath.Join(defaultRulesets, name)))
+	}
+	customRules := filepath.Join(rulesDir, "custom")
+
+	tests := []struct {
+		name    string
+		sources []string
+		targets []string
+		rules   []string
+		strict  bool
+		wantErr string
+	}{
+		{name: "default technologies", sources: []string{"eap7"}, targets: []string{"eap8", "quarkus"}, strict: true},
+		{name: "target of a filtered out default ruleset", sources: []string{"eap7"}, targets: []string{"azure-appservice"}, strict: true},
+		{name: "source of the rules", sources: []string{"springboot"}, rules: []string{customRules}, strict: true},
+		{name: "mistyped target", targets: []string{"quarkas"}, strict: true, wantErr: `target "quarkas" matches no rule, valid targets: azure-appservice, eap8, quarkus`},
+		{name: "unknown source", sources: []string{"springboot"}, strict: true, wantErr: `source "springboot" matches no rule, valid sources: eap7`},
+		{name: "not strict", targets: []string{"quarkas"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzeCommand{
+				sources:      tt.sources,
+				targets:      tt.targets,
+				rules:        tt.rules,
+				strictTarget: tt.strict,
+				AnalyzeCommandContext: AnalyzeCommandContext{
+					log: logr.Discard(),
+				},
+			}
+			// the default rulesets are pre-filtered by the targets
+			a.rules = append(a.rules, a.defaultRulesetPaths(defaultRulesets)...)
+			err := a.checkStrictTargets()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+			var validationErr *ValidationError
+			assert.True(t, errors.As(err, &validationErr))
+		})
+	}
+}
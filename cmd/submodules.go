@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// gitSubmodulePaths parses the paths out of `git submodule status` output,
+// each line is a status character, the commit, the path and the described
+// ref in parentheses
+func gitSubmodulePaths(status string) []string {
+	paths := []string{}
+	for _, line := range strings.Split(status, "\n") {
+		fields := strings.Fields(strings.TrimLeft(line, " -+U"))
+		if len(fields) < 2 {
+			continue
+		}
+		paths = append(paths, fields[1])
+	}
+	return paths
+}
+
+// initGitSubmodules checks out the submodules of the input recursively with
+// --init-submodules, they are empty dirs in a fresh clone otherwise. It may
+// fetch from the submodule remotes, so it only runs when asked for.
+func (a *analyzeCommand) initGitSubmodules(ctx context.Context) error {
+	if !a.initSubmodules {
+		return nil
+	}
+	if a.isFileInput {
+		a.log.Info("WARNING: --init-submodules only applies to source directories", "input", a.input)
+		return nil
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("--init-submodules requires git, install git or run without --init-submodules: %w", err)
+	}
+	if !isGitWorkTree(ctx, a.input) {
+		a.log.Info("WARNING: input is not a git working tree, ignoring --init-submodules", "input", a.input)
+		return nil
+	}
+
+	a.log.Info("initializing git submodules", "input", a.input)
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "-C", a.input, "submodule", "update", "--init", "--recursive")
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to initialize git submodules of %s: %w: %s", a.input, err, strings.TrimSpace(stderr.String()))
+	}
+
+	out, err := exec.CommandContext(ctx, "git", "-C", a.input, "submodule", "status", "--recursive").Output()
+	if err != nil {
+		return fmt.Errorf("failed to list git submodules of %s: %w", a.input, err)
+	}
+	paths := gitSubmodulePaths(string(out))
+	for _, path := range paths {
+		a.log.Info("initialized git submodule", "path", path)
+	}
+	if len(paths) == 0 {
+		a.log.Info("input has no git submodules", "input", a.input)
+	}
+	return nil
+}
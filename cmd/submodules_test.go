@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitSubmodulePaths(t *testing.T) {
+	status := ` 0016fa290b0f70fd02e5a14bf5c663f609ece2de libs/sub (heads/master)
+-9a1e3f6f0e2c4b1e8d7c6b5a4f3e2d1c0b9a8f7e libs/uninitialized
++1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e libs/sub/nested (v1.0.0-1-g1b2c3d4)
+`
+	assert.Equal(t, []string{"libs/sub", "libs/uninitialized", "libs/sub/nested"}, gitSubmodulePaths(status))
+	assert.Empty(t, gitSubmodulePaths(""))
+}
+
+func TestInitGitSubmodules(t *testing.T) {
+	// submodules are added from local paths
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "protocol.file.allow")
+	t.Setenv("GIT_CONFIG_VALUE_0", "always")
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	parent := filepath.Join(root, "parent")
+	initGitRepo(t, sub, map[string]string{"src/Lib.java": "class Lib {}"})
+	initGitRepo(t, parent, map[string]string{"src/App.java": "class App {}"})
+	git := func(args ...string) {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("-C", parent, "submodule", "add", "-q", sub, "libs/sub")
+	git("-C", parent, "commit", "-q", "-m", "add submodule")
+	clone := filepath.Join(root, "clone")
+	git("clone", "-q", parent, clone)
+	require.NoFileExists(t, filepath.Join(clone, "libs", "sub", "src", "Lib.java"))
+
+	a := &analyzeCommand{input: clone}
+	a.log = logr.Discard()
+	require.NoError(t, a.initGitSubmodules(context.Background()))
+	assert.NoFileExists(t, filepath.Join(clone, "libs", "sub", "src", "Lib.java"), "not initialized without the flag")
+
+	a.initSubmodules = true
+	require.NoError(t, a.initGitSubmodules(context.Background()))
+	assert.FileExists(t, filepath.Join(clone, "libs", "sub", "src", "Lib.java"))
+}
+
+func TestInitGitSubmodulesNotGitWorkTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	a := &analyzeCommand{input: t.TempDir(), initSubmodules: true}
+	a.log = logr.Discard()
+	assert.NoError(t, a.initGitSubmodules(context.Background()))
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// outputSchema is the JSON schema of output.json, the contract for tools
+// consuming the analysis output
+//
+//go:embed schema/output.schema.json
+var outputSchema []byte
+
+// validateOutputSchema validates JSON analysis output against outputSchema
+func validateOutputSchema(data []byte) error {
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewBytesLoader(outputSchema),
+		gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return fmt.Errorf("failed to validate output against schema: %w", err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	violations := []string{}
+	for _, resultErr := range result.Errors() {
+		violations = append(violations, resultErr.String())
+	}
+	return fmt.Errorf("output does not conform to the output schema:\n  %s", strings.Join(violations, "\n  "))
+}
+
+// validateOutputFile validates the output.json written by the analysis, used
+// with --validate-output
+func (a *analyzeCommand) validateOutputFile() error {
+	if !a.validateOutput {
+		return nil
+	}
+	outputPath := a.outputFilePath("output.json")
+	data, err := readOutputFile(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s for validation: %w", outputPath, err)
+	}
+	if err := validateOutputSchema(data); err != nil {
+		return fmt.Errorf("%s: %w", outputPath, err)
+	}
+	a.log.V(1).Info("output conforms to the output schema", "file", outputPath)
+	return nil
+}
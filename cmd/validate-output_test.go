@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOutputSchema(t *testing.T) {
+	line := 12
+	effort := 3
+	rulesets := []outputv1.RuleSet{{
+		Name:      "ruleset",
+		Tags:      []string{"Java EE"},
+		Unmatched: []string{"rule-2"},
+		Errors:    map[string]string{"rule-3": "failed"},
+		Violations: map[string]outputv1.Violation{
+			"rule-1": {
+				Description: "description",
+				Category:    &outputv1.Mandatory,
+				Effort:      &effort,
+				Links:       []outputv1.Link{{URL: "https://example.com", Title: "docs"}},
+				Incidents: []outputv1.Incident{{
+					URI:        "file:///app/Main.java",
+					Message:    "message",
+					LineNumber: &line,
+					Variables:  map[string]interface{}{"name": "value"},
+				}},
+			},
+		},
+	}}
+	data, err := json.Marshal(rulesets)
+	require.NoError(t, err)
+	assert.NoError(t, validateOutputSchema(data))
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not an array", data: `{"name": "ruleset"}`},
+		{name: "unknown category", data: `[{"violations": {"r": {"description": "d", "category": "critical", "incidents": []}}}]`},
+		{name: "incident without uri", data: `[{"violations": {"r": {"description": "d", "incidents": [{"message": "m"}]}}}]`},
+		{name: "string line number", data: `[{"violations": {"r": {"description": "d", "incidents": [{"uri": "u", "message": "m", "lineNumber": "1"}]}}}]`},
+		{name: "unknown field", data: `[{"name": "ruleset", "rulez": []}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateOutputSchema([]byte(tt.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "does not conform")
+		})
+	}
+}
+
+func TestValidateOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	a := &analyzeCommand{output: dir, validateOutput: true}
+	a.log = logr.Discard()
+
+	assert.Error(t, a.validateOutputFile(), "missing output.json")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "output.json"), []byte(`[{"name": "ruleset"}]`), 0644))
+	assert.NoError(t, a.validateOutputFile())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "output.json"), []byte(`[{"name": 1}]`), 0644))
+	assert.Error(t, a.validateOutputFile())
+
+	a.validateOutput = false
+	assert.NoError(t, a.validateOutputFile())
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/konveyor-ecosystem/kantra/pkg/util"
+	java "github.com/konveyor/analyzer-lsp/external-providers/java-external-provider/pkg/java_external_provider"
+	"github.com/konveyor/analyzer-lsp/parser"
+	"github.com/konveyor/analyzer-lsp/provider"
+	"github.com/konveyor/analyzer-lsp/provider/lib"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type validateRulesCommand struct {
+	rules []string
+	log   logr.Logger
+}
+
+func NewValidateRulesCommand(log logr.Logger) *cobra.Command {
+	validateRulesCmd := &validateRulesCommand{
+		log: log,
+	}
+
+	validateRulesCommand := &cobra.Command{
+		Use:   "validate-rules <path>...",
+		Short: "Validate rule files without running analysis",
+		Long:  "Parse rule files or directories and report syntax and schema errors without running analysis. Exits non-zero when any rule is invalid.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			validateRulesCmd.rules = args
+			err := validateRulesCmd.Validate()
+			if err != nil {
+				log.Error(err, "failed to validate flags")
+				return &ValidationError{Err: err}
+			}
+			return validateRulesCmd.Run(os.Stdout)
+		},
+	}
+	return validateRulesCommand
+}
+
+func (v *validateRulesCommand) Validate() error {
+	for idx, rulePath := range v.rules {
+		absPath, err := filepath.Abs(rulePath)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path for rules %s: %w", rulePath, err)
+		}
+		if _, err := os.Stat(absPath); err != nil {
+			return fmt.Errorf("failed to stat rules %s: %w", rulePath, err)
+		}
+		v.rules[idx] = absPath
+	}
+	return nil
+}
+
+// Run parses each rules path and prints the result, returning an error when
+// any of the rules can not be parsed. Paths with rules for providers other
+// than java and builtin are reported as unvalidated.
+func (v *validateRulesCommand) Run(out io.Writer) error {
+	providers, err := v.ruleValidationProviders()
+	if err != nil {
+		return err
+	}
+	ruleParser := parser.RuleParser{
+		ProviderNameToClient: providers,
+		Log:                  v.log.WithName("parser"),
+	}
+
+	invalid := 0
+	for _, rulePath := range v.rules {
+		ruleSets, _, _, err := ruleParser.LoadRules(rulePath)
+		if err != nil {
+			invalid++
+			fmt.Fprintf(out, "invalid: %s\n  %v\n", rulePath, err)
+			continue
+		}
+		ruleCount := 0
+		for _, ruleSet := range ruleSets {
+			ruleCount += len(ruleSet.Rules)
+		}
+		// the parser skips the rules of providers it has no client for
+		skipped, skippedProviders, err := unvalidatedRules(rulePath, providers)
+		if err != nil {
+			return err
+		}
+		if skipped > 0 {
+			fmt.Fprintf(out, "unvalidated: %s (%d rulesets, %d rules)\n  %d rules use providers that can't be validated: %s\n",
+				rulePath, len(ruleSets), ruleCount, skipped, strings.Join(skippedProviders, ", "))
+			continue
+		}
+		fmt.Fprintf(out, "valid: %s (%d rulesets, %d rules)\n", rulePath, len(ruleSets), ruleCount)
+	}
+	if invalid > 0 {
+		return &RuleParseError{Err: fmt.Errorf("%d of %d rule paths are invalid", invalid, len(v.rules))}
+	}
+	return nil
+}
+
+// ruleValidationProviders creates the java and builtin provider clients
+// needed for parsing rule conditions, the providers are never started
+func (v *validateRulesCommand) ruleValidationProviders() (map[string]provider.InternalProviderClient, error) {
+	location, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	javaConfig := provider.Config{
+		Name: util.JavaProvider,
+		InitConfig: []provider.InitConfig{
+			{
+				Location:               location,
+				AnalysisMode:           provider.SourceOnlyAnalysisMode,
+				ProviderSpecificConfig: map[string]interface{}{},
+			},
+		},
+	}
+	builtinConfig := provider.Config{
+		Name: "builtin",
+		InitConfig: []provider.InitConfig{
+			{
+				Location:     location,
+				AnalysisMode: provider.SourceOnlyAnalysisMode,
+			},
+		},
+	}
+	builtinProvider, err := lib.GetProviderClient(builtinConfig, logr.Discard())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create builtin provider: %w", err)
+	}
+	return map[string]provider.InternalProviderClient{
+		util.JavaProvider: java.NewJavaProvider(logr.Discard(), util.JavaProvider, 0, javaConfig),
+		"builtin":         builtinProvider,
+	}, nil
+}
+
+// unvalidatedRules returns how many rules in the rule files of rulePath have
+// a condition for a provider without a client, and the sorted names of those
+// providers
+func unvalidatedRules(rulePath string, providers map[string]provider.InternalProviderClient) (int, []string, error) {
+	ruleFiles, err := findRuleFiles(rulePath)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to find rule files in %s: %w", rulePath, err)
+	}
+	skipped := 0
+	names := map[string]bool{}
+	for _, ruleFile := range ruleFiles {
+		if filepath.Base(ruleFile) == "ruleset.yaml" {
+			continue
+		}
+		content, err := os.ReadFile(ruleFile)
+		if err != nil {
+			return 0, nil, err
+		}
+		doc := yaml.Node{}
+		// files the parser accepted but that aren't rules are ignored
+		if err := yaml.Unmarshal(content, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, rule := range doc.Content[0].Content {
+			missing := false
+			for _, name := range conditionProviders(mappingValue(rule, "when")) {
+				if _, ok := providers[name]; !ok {
+					names[name] = true
+					missing = true
+				}
+			}
+			if missing {
+				skipped++
+			}
+		}
+	}
+	return skipped, slices.Sorted(maps.Keys(names)), nil
+}
+
+// conditionProviders returns the provider names of the conditions in when,
+// e.g. go for go.referenced
+func conditionProviders(when *yaml.Node) []string {
+	if when == nil || when.Kind != yaml.MappingNode {
+		return nil
+	}
+	names := []string{}
+	for i := 0; i+1 < len(when.Content); i += 2 {
+		key, value := when.Content[i].Value, when.Content[i+1]
+		switch key {
+		case "and", "or":
+			for _, condition := range value.Content {
+				names = append(names, conditionProviders(condition)...)
+			}
+		case "not", "as", "from", "ignore":
+		default:
+			if name, _, ok := strings.Cut(key, "."); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRulesCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	validRules := filepath.Join(tmpDir, "valid.yaml")
+	require.NoError(t, os.WriteFile(validRules, []byte(`- ruleID: test-rule-00001
+  message: found a file
+  when:
+    builtin.file:
+      pattern: pom.xml
+`), 0644))
+	goRules := filepath.Join(tmpDir, "go.yaml")
+	require.NoError(t, os.WriteFile(goRules, []byte(`- ruleID: test-rule-00003
+  message: found a go module
+  when:
+    or:
+    - builtin.file:
+        pattern: go.mod
+    - go.referenced:
+        pattern: net/http
+`), 0644))
+	invalidRules := filepath.Join(tmpDir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidRules, []byte(`- ruleID: test-rule-00002
+  when: [
+`), 0644))
+
+	tests := []struct {
+		name     string
+		rules    []string
+		wantErr  bool
+		contains string
+	}{
+		{
+			name:     "valid rules",
+			rules:    []string{validRules},
+			contains: "valid: " + validRules,
+		},
+		{
+			name:     "rules of providers without a client",
+			rules:    []string{goRules},
+			contains: "1 rules use providers that can't be validated: go",
+		},
+		{
+			name:     "invalid rules",
+			rules:    []string{validRules, invalidRules},
+			wantErr:  true,
+			contains: "invalid: " + invalidRules,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &validateRulesCommand{
+				rules: tt.rules,
+				log:   logr.Discard(),
+			}
+			require.NoError(t, v.Validate())
+			out := &bytes.Buffer{}
+			err := v.Run(out)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Contains(t, out.String(), tt.contains)
+		})
+	}
+}
+
+func TestValidateRulesCommand_MissingPath(t *testing.T) {
+	v := &validateRulesCommand{
+		rules: []string{filepath.Join(t.TempDir(), "missing")},
+		log:   logr.Discard(),
+	}
+	assert.Error(t, v.Validate())
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"github.com/konveyor/analyzer-lsp/engine"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/parser"
+	"github.com/konveyor/analyzer-lsp/provider"
+)
+
+// watchDebounce groups the events of a single save, editors often write a
+// file in several steps
+const watchDebounce = 500 * time.Millisecond
+
+// runWatchContainerless keeps the started providers running and re-runs the
+// rules every time a file under --rules changes until the analysis is
+// interrupted, used with --watch. The results are only printed to out.
+func (a *analyzeCommand) runWatchContainerless(ctx context.Context, eng engine.RuleEngine, ruleParser parser.RuleParser,
+	providers map[string]provider.InternalProviderClient, selectors []engine.RuleSelector, out io.Writer) error {
+	defer func() {
+		eng.Stop()
+		for _, provider := range providers {
+			provider.Stop()
+		}
+	}()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create rules watcher: %w", err)
+	}
+	defer watcher.Close()
+	watchedRules := make([]string, 0, len(a.rules))
+	for _, rulePath := range a.rules {
+		watchedRules = append(watchedRules, filepath.Clean(rulePath))
+	}
+	if err := addRuleWatches(watcher, watchedRules); err != nil {
+		return fmt.Errorf("failed to watch rules: %w", err)
+	}
+
+	rulePaths := append([]string{}, watchedRules...)
+	if a.enableDefaultRulesets {
+		rulePaths = append(rulePaths, a.defaultRulesetPaths(filepath.Join(a.kantraDir, RulesetsLocation))...)
+	}
+	for {
+		start := time.Now()
+		rulesets := a.runWatchCycle(ctx, eng, ruleParser, rulePaths, selectors, out)
+		if ctx.Err() != nil {
+			return nil
+		}
+		printWatchResults(out, rulesets, time.Since(start))
+		fmt.Fprintln(out, "Watching --rules for changes, press Ctrl-C to exit")
+		if !waitForRuleChange(ctx, watcher, watchedRules, a.log) {
+			return nil
+		}
+	}
+}
+
+// runWatchCycle parses the rules again and runs them with the running
+// providers. Rules that fail to parse are reported and left out, a rule
+// being edited is often invalid for a moment.
+func (a *analyzeCommand) runWatchCycle(ctx context.Context, eng engine.RuleEngine, ruleParser parser.RuleParser,
+	rulePaths []string, selectors []engine.RuleSelector, out io.Writer) []outputv1.RuleSet {
+	ruleSets := []engine.RuleSet{}
+	needProviders := map[string]provider.InternalProviderClient{}
+	providerConditions := map[string][]provider.ConditionsByCap{}
+	for _, rulePath := range rulePaths {
+		resolvedPath, err := a.resolveRuleIncludes(rulePath)
+		if err != nil {
+			fmt.Fprintf(out, "unable to resolve the includes of ruleset %s: %v\n", rulePath, err)
+			continue
+		}
+		internRuleSet, internNeedProviders, provConditions, err := ruleParser.LoadRules(resolvedPath)
+		if err != nil {
+			fmt.Fprintf(out, "unable to parse all the rules for ruleset %s: %v\n", rulePath, err)
+		}
+		ruleSets = append(ruleSets, internRuleSet...)
+		for k, v := range internNeedProviders {
+			needProviders[k] = v
+		}
+		for k, v := range provConditions {
+			providerConditions[k] = append(providerConditions[k], v...)
+		}
+	}
+	for name, conditions := range providerConditions {
+		if provider, ok := needProviders[name]; ok {
+			if err := provider.Prepare(ctx, conditions); err != nil {
+				a.log.Error(err, "unable to prepare provider", "provider", name)
+			}
+		}
+	}
+	a.applyRuleTimeout(ruleSets)
+	a.applyIncidentStream(ruleSets)
+	return eng.RunRules(ctx, ruleSets, selectors...)
+}
+
+// addRuleWatches watches every directory below the given rules directories,
+// fsnotify does not watch recursively. The parent directory of rule files is
+// watched as editors often replace a file on save.
+func addRuleWatches(watcher *fsnotify.Watcher, rules []string) error {
+	for _, rulePath := range rules {
+		info, err := os.Stat(rulePath)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			if err := watcher.Add(filepath.Dir(rulePath)); err != nil {
+				return err
+			}
+			continue
+		}
+		err = filepath.WalkDir(rulePath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return watcher.Add(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isWatchedRule reports whether the file is one of the rules or below one of
+// the rules directories
+func isWatchedRule(rules []string, file string) bool {
+	file = filepath.Clean(file)
+	for _, rulePath := range rules {
+		if file == rulePath || strings.HasPrefix(file, rulePath+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForRuleChange blocks until a rule changed, returning false when the
+// analysis is interrupted
+func waitForRuleChange(ctx context.Context, watcher *fsnotify.Watcher, rules []string, log logr.Logger) bool {
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return false
+			}
+			if event.Op == fsnotify.Chmod || !isWatchedRule(rules, event.Name) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				// directories created below --rules are watched as well
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addRuleWatches(watcher, []string{event.Name}); err != nil {
+						log.Error(err, "failed to watch rules directory", "dir", event.Name)
+					}
+				}
+			}
+			debounce = time.After(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return false
+			}
+			log.Error(err, "error watching rules")
+		case <-debounce:
+			return true
+		}
+	}
+}
+
+// printWatchResults prints the incidents of every matched rule of a --watch
+// run
+func printWatchResults(out io.Writer, rulesets []outputv1.RuleSet, duration time.Duration) {
+	sort.SliceStable(rulesets, func(i, j int) bool {
+		return rulesets[i].Name < rulesets[j].Name
+	})
+	fmt.Fprintf(out, "\nResults at %s (%s):\n", time.Now().Format(time.TimeOnly), duration.Round(time.Millisecond))
+	matched, incidents, unmatched := 0, 0, 0
+	for _, ruleset := range rulesets {
+		ruleIDs := make([]string, 0, len(ruleset.Violations))
+		for ruleID := range ruleset.Violations {
+			ruleIDs = append(ruleIDs, ruleID)
+		}
+		sort.Strings(ruleIDs)
+		for _, ruleID := range ruleIDs {
+			count := len(ruleset.Violations[ruleID].Incidents)
+			fmt.Fprintf(out, "  %s/%s: %d incidents\n", ruleset.Name, ruleID, count)
+			matched++
+			incidents += count
+		}
+		errorIDs := make([]string, 0, len(ruleset.Errors))
+		for ruleID := range ruleset.Errors {
+			errorIDs = append(errorIDs, ruleID)
+		}
+		sort.Strings(errorIDs)
+		for _, ruleID := range errorIDs {
+			fmt.Fprintf(out, "  %s/%s: error: %s\n", ruleset.Name, ruleID, ruleset.Errors[ruleID])
+		}
+		unmatched += len(ruleset.Unmatched)
+	}
+	fmt.Fprintf(out, "%d rules matched with %d incidents, %d rules unmatched\n", matched, incidents, unmatched)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	outputv1 "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsWatchedRule(t *testing.T) {
+	rules := []string{filepath.Join("rules", "java"), filepath.Join("custom", "rule.yaml")}
+	assert.True(t, isWatchedRule(rules, filepath.Join("rules", "java", "ruleset.yaml")))
+	assert.True(t, isWatchedRule(rules, filepath.Join("rules", "java", "nested", "rule.yaml")))
+	assert.True(t, isWatchedRule(rules, filepath.Join("custom", "rule.yaml")))
+	assert.False(t, isWatchedRule(rules, filepath.Join("custom", "other.yaml")))
+	assert.False(t, isWatchedRule(rules, filepath.Join("rules", "javascript", "rule.yaml")))
+}
+
+func TestWaitForRuleChange(t *testing.T) {
+	dir := t.TempDir()
+	rulesDir := filepath.Join(dir, "rules")
+	require.NoError(t, os.MkdirAll(filepath.Join(rulesDir, "nested"), 0755))
+	ruleFile := filepath.Join(dir, "rule.yaml")
+	require.NoError(t, os.WriteFile(ruleFile, []byte("- ruleID: test\n"), 0644))
+
+	watcher, err := fsnotify.NewWatcher()
+	require.NoError(t, err)
+	defer watcher.Close()
+	rules := []string{rulesDir, ruleFile}
+	require.NoError(t, addRuleWatches(watcher, rules))
+
+	for _, changed := range []string{filepath.Join(rulesDir, "nested", "rule.yaml"), ruleFile} {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			os.WriteFile(changed, []byte("- ruleID: changed\n"), 0644)
+		}()
+		assert.True(t, waitForRuleChange(ctx, watcher, rules, logr.Discard()), changed)
+		cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, waitForRuleChange(ctx, watcher, rules, logr.Discard()))
+}
+
+func TestPrintWatchResults(t *testing.T) {
+	rulesets := []outputv1.RuleSet{
+		{
+			Name: "quarkus",
+			Violations: map[string]outputv1.Violation{
+				"quarkus-01": {Incidents: []outputv1.Incident{{}, {}}},
+			},
+			Errors:    map[string]string{"quarkus-02": "invalid condition"},
+			Unmatched: []string{"quarkus-03"},
+		},
+	}
+	out := &bytes.Buffer{}
+	printWatchResults(out, rulesets, time.Second)
+	assert.Contains(t, out.String(), "  quarkus/quarkus-01: 2 incidents\n")
+	assert.Contains(t, out.String(), "  quarkus/quarkus-02: error: invalid condition\n")
+	assert.Contains(t, out.String(), "1 rules matched with 2 incidents, 1 rules unmatched\n")
+}
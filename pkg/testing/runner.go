@@ -326,7 +326,7 @@ func runLocal(logFile io.Writer, dir string, analysisParams AnalysisParams, inpu
 	}
 	if analysisParams.DepLabelSelector != "" {
 		args = append(args, []string{
-			"--label-selector",
+			"--dep-label-selector",
 			analysisParams.DepLabelSelector,
 		}...)
 	}
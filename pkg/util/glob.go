@@ -0,0 +1,69 @@
+package util
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GlobToRegexp converts a slash separated glob pattern into a regular expression.
+// '*' matches within a single path segment, '?' matches a single character and
+// '**' matches across any number of path segments.
+func GlobToRegexp(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+	pattern = strings.TrimPrefix(pattern, "./")
+	if pattern == "" {
+		return nil, fmt.Errorf("empty glob pattern")
+	}
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// '**/' matches zero or more leading directories
+					i++
+					expr.WriteString("(?:.*/)?")
+				} else {
+					expr.WriteString(".*")
+				}
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
+
+// MatchesAnyGlob reports whether relPath, or any of its parent directories,
+// matches one of the given glob patterns.
+func MatchesAnyGlob(patterns []*regexp.Regexp, relPath string) bool {
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "./")
+	for _, pattern := range patterns {
+		candidate := relPath
+		for candidate != "" && candidate != "." && candidate != "/" {
+			if pattern.MatchString(candidate) {
+				return true
+			}
+			idx := strings.LastIndex(candidate, "/")
+			if idx < 0 {
+				break
+			}
+			candidate = candidate[:idx]
+		}
+	}
+	return false
+}
+
+// IsGlobPattern reports whether the pattern contains glob meta characters
+func IsGlobPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?")
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchesAnyGlob(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		want     bool
+	}{
+		{name: "plain directory", patterns: []string{"vendor"}, path: "vendor/lib/a.go", want: true},
+		{name: "plain directory trailing slash", patterns: []string{"vendor/"}, path: "vendor/a.go", want: true},
+		{name: "plain directory not at root", patterns: []string{"vendor"}, path: "src/vendor/a.go", want: false},
+		{name: "recursive directory", patterns: []string{"**/node_modules"}, path: "web/app/node_modules/x/index.js", want: true},
+		{name: "recursive directory at root", patterns: []string{"**/node_modules"}, path: "node_modules/x/index.js", want: true},
+		{name: "single segment wildcard", patterns: []string{"src/*.java"}, path: "src/Main.java", want: true},
+		{name: "single segment wildcard does not cross dirs", patterns: []string{"src/*.java"}, path: "src/pkg/Main.java", want: false},
+		{name: "recursive extension", patterns: []string{"**/*.generated.java"}, path: "src/pkg/A.generated.java", want: true},
+		{name: "no match", patterns: []string{"**/test/**"}, path: "src/main/A.java", want: false},
+		{name: "match trailing recursion", patterns: []string{"**/test/**"}, path: "src/test/A.java", want: true},
+		{name: "question mark", patterns: []string{"lib?"}, path: "lib1/a.js", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled := []*regexp.Regexp{}
+			for _, p := range tt.patterns {
+				re, err := GlobToRegexp(p)
+				if err != nil {
+					t.Fatalf("GlobToRegexp(%q) error = %v", p, err)
+				}
+				compiled = append(compiled, re)
+			}
+			if got := MatchesAnyGlob(compiled, tt.path); got != tt.want {
+				t.Errorf("MatchesAnyGlob(%v, %q) = %v, want %v", tt.patterns, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobToRegexpEmpty(t *testing.T) {
+	if _, err := GlobToRegexp(""); err == nil {
+		t.Error("GlobToRegexp(\"\") expected error")
+	}
+}
@@ -192,7 +192,22 @@ func getSourceOrTargetLabel(text string, label string) string {
 }
 
 func ListOptionsFromLabels(sl []string, label string, out io.Writer) {
-	var newSl []string
+	newSl := OptionsFromLabels(sl, label)
+
+	if label == outputv1.SourceTechnologyLabel {
+		fmt.Fprintln(out, "available source technologies:")
+	} else {
+		fmt.Fprintln(out, "available target technologies:")
+	}
+	for _, tech := range newSl {
+		fmt.Fprintln(out, tech)
+	}
+}
+
+// OptionsFromLabels returns the sorted unique values of the given label,
+// without version suffixes
+func OptionsFromLabels(sl []string, label string) []string {
+	newSl := []string{}
 	l := label + "="
 
 	for _, label := range sl {
@@ -209,15 +224,7 @@ func ListOptionsFromLabels(sl []string, label string, out io.Writer) {
 		}
 	}
 	sort.Strings(newSl)
-
-	if label == outputv1.SourceTechnologyLabel {
-		fmt.Fprintln(out, "available source technologies:")
-	} else {
-		fmt.Fprintln(out, "available target technologies:")
-	}
-	for _, tech := range newSl {
-		fmt.Fprintln(out, tech)
-	}
+	return newSl
 }
 
 const ProfilesPath = ".konveyor/profiles"